@@ -62,4 +62,5 @@ func setUpDirectories(path string) {
 	os.Mkdir(path+"extensions/plugins", 0700)
 	os.Mkdir(path+"extensions/behavior_packs", 0700)
 	os.Mkdir(path+"extensions/resource_packs", 0700)
+	os.Mkdir(path+"worlds", 0700)
 }
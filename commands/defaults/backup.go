@@ -0,0 +1,60 @@
+package defaults
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/arguments"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/utils"
+	"github.com/irmine/gomine/worlds/backups"
+)
+
+func NewBackup(server interfaces.IServer) *commands.Command {
+	var backup = commands.NewCommand("backup", "Creates, lists or restores backups of worlds", "gomine.backup", []string{}, func(sender commands.Sender, action string, world string, archive string) {
+		switch action {
+		case "create":
+			var err = server.BackupLevel(world, "", func(path string, err error) {
+				if err != nil {
+					sender.SendMessage(utils.Red + "Could not back up world " + world + ": " + err.Error())
+					return
+				}
+				sender.SendMessage(utils.Yellow + "Backed up world " + world + " to " + path)
+			})
+			if err != nil {
+				sender.SendMessage(utils.Red + "Could not back up world " + world + ": " + err.Error())
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Backing up world " + world + "...")
+
+		case "list":
+			var names []string
+			for _, path := range backups.GetBackups(server.GetBackupPath(), world) {
+				names = append(names, filepath.Base(path))
+			}
+			if len(names) == 0 {
+				sender.SendMessage(utils.Yellow + "World " + world + " has no backups")
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Backups of " + world + ": " + strings.Join(names, ", "))
+
+		case "restore":
+			if archive == "" {
+				sender.SendMessage(utils.Red + "Usage: /backup restore <world> <archive>")
+				return
+			}
+			// Archives are looked up in the backup folder, so that no other files can be restored.
+			if err := server.RestoreLevel(world, server.GetBackupPath()+filepath.Base(archive)); err != nil {
+				sender.SendMessage(utils.Red + "Could not restore world " + world + ": " + err.Error())
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Restored world " + world + " from " + archive)
+		}
+	})
+
+	backup.AppendArgument(arguments.NewStringEnum("action", false, []string{"create", "list", "restore"}))
+	backup.AppendArgument(arguments.NewString("world", false))
+	backup.AppendArgument(arguments.NewString("archive", true))
+	return backup
+}
@@ -0,0 +1,66 @@
+package defaults
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/arguments"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/utils"
+	"github.com/irmine/gomine/worlds"
+)
+
+// namedTimes are the names of times of the day that can be used in place of a number with /time set.
+var namedTimes = map[string]int64{
+	"day":      worlds.TimeDay,
+	"noon":     worlds.TimeNoon,
+	"sunset":   worlds.TimeSunset,
+	"night":    worlds.TimeNight,
+	"midnight": worlds.TimeMidnight,
+	"sunrise":  worlds.TimeSunrise,
+}
+
+func NewTime(server interfaces.IServer) *commands.Command {
+	var time = commands.NewCommand("time", "Queries or changes the time of the level", "gomine.time", []string{}, func(sender commands.Sender, action string, value string) {
+		var level = server.GetDefaultLevel()
+		if player, ok := sender.(interfaces.IPlayer); ok {
+			level = player.GetLevel()
+		}
+
+		switch action {
+		case "query":
+			sender.SendMessage(utils.Yellow + "The time of " + level.GetName() + " is " + strconv.FormatInt(level.GetTime()%worlds.TimeFull, 10) + " (day " + strconv.FormatInt(level.GetTime()/worlds.TimeFull, 10) + ")")
+			return
+		case "start":
+			level.StartTime()
+			sender.SendMessage(utils.Yellow + "Started the time of " + level.GetName())
+			return
+		case "stop":
+			level.StopTime()
+			sender.SendMessage(utils.Yellow + "Stopped the time of " + level.GetName())
+			return
+		}
+
+		var amount, ok = namedTimes[strings.ToLower(value)]
+		if !ok {
+			var err error
+			if amount, err = strconv.ParseInt(value, 10, 64); err != nil {
+				sender.SendMessage(utils.Red + "Invalid time: " + value)
+				return
+			}
+		}
+
+		if action == "add" {
+			level.SetTime(level.GetTime() + amount)
+		} else {
+			// Setting the time keeps the amount of days passed.
+			level.SetTime(level.GetTime() - level.GetTime()%worlds.TimeFull + amount)
+		}
+		sender.SendMessage(utils.Yellow + "Set the time of " + level.GetName() + " to " + strconv.FormatInt(level.GetTime()%worlds.TimeFull, 10))
+	})
+
+	time.AppendArgument(arguments.NewStringEnum("action", false, []string{"set", "add", "query", "start", "stop"}))
+	time.AppendArgument(arguments.NewString("value", true))
+	return time
+}
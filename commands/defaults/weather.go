@@ -0,0 +1,55 @@
+package defaults
+
+import (
+	"strconv"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/arguments"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/utils"
+	"github.com/irmine/gomine/worlds"
+)
+
+// weathers are the names of the weathers that can be set with /weather.
+var weathers = map[string]int{
+	"clear":   worlds.WeatherClear,
+	"rain":    worlds.WeatherRain,
+	"thunder": worlds.WeatherThunder,
+}
+
+func NewWeather(server interfaces.IServer) *commands.Command {
+	var weather = commands.NewCommand("weather", "Queries or changes the weather of the level", "gomine.weather", []string{}, func(sender commands.Sender, name string, seconds string) {
+		var level = server.GetDefaultLevel()
+		if player, ok := sender.(interfaces.IPlayer); ok {
+			level = player.GetLevel()
+		}
+
+		if name == "query" {
+			for weatherName, id := range weathers {
+				if id == level.GetWeather() {
+					sender.SendMessage(utils.Yellow + "The weather of " + level.GetName() + " is " + weatherName + " for " + strconv.Itoa(level.GetWeatherDuration()/20) + " more seconds")
+				}
+			}
+			return
+		}
+
+		var duration = 0
+		if seconds != "" {
+			var value, err = strconv.Atoi(seconds)
+			if err != nil || value <= 0 {
+				sender.SendMessage(utils.Red + "Invalid duration: " + seconds)
+				return
+			}
+			duration = value * 20
+		}
+		if !level.SetWeather(weathers[name], duration) {
+			sender.SendMessage(utils.Red + "The weather change was cancelled")
+			return
+		}
+		sender.SendMessage(utils.Yellow + "Changed the weather of " + level.GetName() + " to " + name)
+	})
+
+	weather.AppendArgument(arguments.NewStringEnum("weather", false, []string{"clear", "rain", "thunder", "query"}))
+	weather.AppendArgument(arguments.NewString("duration", true))
+	return weather
+}
@@ -0,0 +1,90 @@
+package defaults
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/arguments"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/resources"
+	"github.com/irmine/gomine/utils"
+)
+
+func NewWorld(server interfaces.IServer) *commands.Command {
+	var world = commands.NewCommand("world", "Lists, loads, unloads, creates or teleports to worlds", "gomine.world", []string{}, func(sender commands.Sender, action string, name string, generator string, seed string) {
+		if action != "list" && name == "" {
+			sender.SendMessage(utils.Red + "Usage: /world " + action + " <world>")
+			return
+		}
+
+		switch action {
+		case "list":
+			var names []string
+			for _, level := range server.GetLoadedLevels() {
+				names = append(names, level.GetName())
+			}
+			sort.Strings(names)
+			sender.SendMessage(utils.Yellow + "Loaded worlds (" + strconv.Itoa(len(names)) + "): " + strings.Join(names, ", "))
+
+		case "load":
+			if server.IsLevelLoaded(name) {
+				sender.SendMessage(utils.Red + "World " + name + " is already loaded")
+				return
+			}
+			if !server.IsLevelGenerated(name) {
+				sender.SendMessage(utils.Red + "World " + name + " does not exist. Use /world create to generate it")
+				return
+			}
+			if !server.LoadLevel(name) {
+				sender.SendMessage(utils.Red + "Could not load world " + name)
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Loaded world " + name)
+
+		case "unload":
+			if err := server.UnloadLevel(name); err != nil {
+				sender.SendMessage(utils.Red + "Could not unload world " + name + ": " + err.Error())
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Unloaded world " + name)
+
+		case "create":
+			var options = resources.WorldConfig{Generator: generator}
+			if seed != "" {
+				var value, err = strconv.ParseInt(seed, 10, 64)
+				if err != nil {
+					sender.SendMessage(utils.Red + "Invalid seed: " + seed)
+					return
+				}
+				options.Seed = value
+			}
+			if err := server.GenerateLevel(name, options); err != nil {
+				sender.SendMessage(utils.Red + "Could not create world " + name + ": " + err.Error())
+				return
+			}
+			sender.SendMessage(utils.Yellow + "Created world " + name)
+
+		case "tp":
+			var player, ok = sender.(interfaces.IPlayer)
+			if !ok {
+				sender.SendMessage(utils.Red + "Only players can teleport to worlds")
+				return
+			}
+			var level, err = server.GetLevelByName(name)
+			if err != nil {
+				sender.SendMessage(utils.Red + "World " + name + " is not loaded")
+				return
+			}
+			player.ChangeDimension(level.GetDefaultDimension(), level.GetSpawnPosition())
+			sender.SendMessage(utils.Yellow + "Teleported to world " + name)
+		}
+	})
+
+	world.AppendArgument(arguments.NewStringEnum("action", false, []string{"list", "load", "unload", "create", "tp"}))
+	world.AppendArgument(arguments.NewString("world", true))
+	world.AppendArgument(arguments.NewString("generator", true))
+	world.AppendArgument(arguments.NewString("seed", true))
+	return world
+}
@@ -13,10 +13,12 @@ import (
 var RuntimeId uint64 = 0
 
 const (
-	DataFlags = 0
+	DataFlags      = 0
+	DataFuseLength = 55
 )
 
 const (
+	Ignited           = 10
 	AffectedByGravity = 46
 )
 
@@ -33,6 +35,14 @@ type Entity struct {
 	SpawnedTo    map[uint64]interfaces.IPlayer
 	mutex        sync.Mutex
 	EntityData   map[uint32][]interface{}
+
+	width, height float64
+	gravity, drag float64
+	stepHeight    float64
+	physics       bool
+	onGround      bool
+
+	chunk interfaces.IChunk
 }
 
 func NewEntity(position r3.Vector, rotation *math.Rotation, motion r3.Vector, level interfaces.ILevel, dimension interfaces.IDimension) *Entity {
@@ -50,6 +60,14 @@ func NewEntity(position r3.Vector, rotation *math.Rotation, motion r3.Vector, le
 		make(map[uint64]interfaces.IPlayer),
 		sync.Mutex{},
 		make(map[uint32][]interface{}),
+		DefaultWidth,
+		DefaultHeight,
+		Gravity,
+		Drag,
+		0,
+		true,
+		false,
+		nil,
 	}
 
 	ent.InitDataFlags()
@@ -107,28 +125,67 @@ func (entity *Entity) GetPosition() r3.Vector {
 	return entity.Position
 }
 
-// SetPosition sets the position of this entity
+// SetPosition sets the position of this entity.
+// The entity gets moved to the chunk at the new position if that chunk is loaded. Otherwise the chunk gets requested,
+// and the entity stays in the chunk it was in until the chunk at its position has been loaded.
 func (entity *Entity) SetPosition(v r3.Vector) {
-	var newChunkX = int32(math2.Floor(float64(v.X))) >> 4
-	var newChunkZ = int32(math2.Floor(float64(v.Z))) >> 4
+	entity.Position = v
+	entity.updateChunk()
+}
 
-	var oldChunk = entity.GetChunk()
-	var newChunk = entity.GetDimension().GetChunk(newChunkX, newChunkZ)
+// updateChunk moves the entity from the chunk it is in to the chunk at its position, if that chunk is loaded.
+func (entity *Entity) updateChunk() {
+	var dimension = entity.GetDimension()
+	if dimension == nil {
+		return
+	}
+	var x = int32(math2.Floor(entity.Position.X)) >> 4
+	var z = int32(math2.Floor(entity.Position.Z)) >> 4
 
-	entity.Position = v
+	var oldChunk = entity.GetChunk()
+	if oldChunk != nil && oldChunk.GetX() == x && oldChunk.GetZ() == z {
+		return
+	}
+	var newChunk = dimension.GetLoadedChunk(x, z)
+	if newChunk == nil {
+		entity.chunk = oldChunk
+		dimension.RequestChunkAsync(x, z, func(interfaces.IChunk) {
+			if !entity.closed && entity.Dimension == dimension {
+				entity.updateChunk()
+			}
+		})
+		return
+	}
 
-	if oldChunk != newChunk {
-		newChunk.AddEntity(entity)
-		entity.SpawnToAll()
-		oldChunk.RemoveEntity(entity)
+	// The chunk holds the type embedding this entity, which has to be moved instead of the entity itself.
+	var instance interfaces.IEntity = entity
+	if oldChunk != nil {
+		if registered, ok := oldChunk.GetEntities()[entity.runtimeId]; ok {
+			instance = registered
+		}
+	}
+	newChunk.AddEntity(instance)
+	entity.chunk = newChunk
+	instance.SpawnToAll()
+	if oldChunk != nil {
+		oldChunk.RemoveEntity(instance)
 	}
 }
 
 // GetChunk returns the chunk this entity is currently in.
+// This is the loaded chunk at the position of the entity, unless the entity is waiting for that chunk to be loaded.
+// Returns nil if the entity is not in a loaded chunk.
 func (entity *Entity) GetChunk() interfaces.IChunk {
-	var x = int32(math2.Floor(float64(entity.Position.X))) >> 4
-	var z = int32(math2.Floor(float64(entity.Position.Z))) >> 4
-	return entity.GetDimension().GetChunk(x, z)
+	if entity.chunk != nil {
+		return entity.chunk
+	}
+	var dimension = entity.GetDimension()
+	if dimension == nil {
+		return nil
+	}
+	var x = int32(math2.Floor(entity.Position.X)) >> 4
+	var z = int32(math2.Floor(entity.Position.Z)) >> 4
+	return dimension.GetLoadedChunk(x, z)
 }
 
 // GetViewers returns all players that have the chunk loaded in which this entity is.
@@ -168,6 +225,7 @@ func (entity *Entity) GetDimension() interfaces.IDimension {
 // SetDimension sets the dimension of the entity.
 func (entity *Entity) SetDimension(v interfaces.IDimension) {
 	entity.Dimension = v
+	entity.chunk = nil
 }
 
 // GetRotation returns the current rotation of this entity.
@@ -265,7 +323,11 @@ func (entity *Entity) DespawnFromAll() {
 
 // SpawnToAll spawns this entity to all players.
 func (entity *Entity) SpawnToAll() {
-	for _, p := range entity.GetChunk().GetViewers() {
+	var chunk = entity.GetChunk()
+	if chunk == nil {
+		return
+	}
+	for _, p := range chunk.GetViewers() {
 		if p.GetRuntimeId() != entity.GetRuntimeId() {
 			if _, ok := entity.SpawnedTo[p.GetRuntimeId()]; !ok {
 				entity.SpawnTo(p)
@@ -274,11 +336,12 @@ func (entity *Entity) SpawnToAll() {
 	}
 }
 
-// Tick ticks the entity.
+// Tick ticks the entity, moving it according to its motion if it has physics.
 func (entity *Entity) Tick() {
 	for runtimeId, player := range entity.GetViewers() {
 		if player.IsClosed() {
 			delete(entity.SpawnedTo, runtimeId)
 		}
 	}
+	entity.tickPhysics()
 }
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/entities/math"
+	"github.com/irmine/gomine/interfaces"
+)
+
+// LightningLifetime is the amount of ticks a lightning bolt exists before it gets removed.
+const LightningLifetime = 20
+
+// Lightning is a lightning bolt striking at a position.
+// Lightning bolts only exist for a short while, after which they get despawned and closed.
+type Lightning struct {
+	*Entity
+	age int
+}
+
+func NewLightning(position r3.Vector, level interfaces.ILevel, dimension interfaces.IDimension) *Lightning {
+	var lightning = &Lightning{NewEntity(position, math.NewRotation(0, 0, 0), r3.Vector{}, level, dimension), 0}
+	lightning.SetDataFlag(AffectedByGravity, false)
+
+	return lightning
+}
+
+// GetEntityId returns the entity ID of lightning bolts.
+func (lightning *Lightning) GetEntityId() uint32 {
+	return LightningBolt
+}
+
+// SpawnTo spawns the lightning bolt to the given player.
+func (lightning *Lightning) SpawnTo(player interfaces.IPlayer) {
+	if !player.HasSpawned() {
+		return
+	}
+	lightning.AddViewer(player)
+	player.SendAddEntity(lightning)
+}
+
+// SpawnToAll spawns the lightning bolt to all players that have the chunk of the lightning bolt loaded.
+func (lightning *Lightning) SpawnToAll() {
+	var chunk = lightning.GetChunk()
+	if chunk == nil {
+		return
+	}
+	for _, player := range chunk.GetViewers() {
+		lightning.SpawnTo(player)
+	}
+}
+
+// IsExpired checks if the lightning bolt has existed for its full lifetime.
+func (lightning *Lightning) IsExpired() bool {
+	return lightning.age >= LightningLifetime
+}
+
+// Tick ticks the lightning bolt, despawning and closing it once it has expired.
+func (lightning *Lightning) Tick() {
+	lightning.Entity.Tick()
+	lightning.age++
+	if !lightning.IsExpired() {
+		return
+	}
+	for _, player := range lightning.GetViewers() {
+		lightning.DespawnFrom(player)
+	}
+	if chunk := lightning.GetChunk(); chunk != nil {
+		chunk.RemoveEntity(lightning)
+	}
+	lightning.Close()
+}
@@ -3,13 +3,40 @@ package entities
 import (
 	"github.com/irmine/gomine/entities/math"
 	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/pathfinding"
 	"github.com/golang/geo/r3"
 )
 
+// DefaultStepHeight is the height in blocks living entities walk up without jumping, which is the height of a slab.
+const DefaultStepHeight = 0.5
+
 type LivingEntity struct {
 	*Entity
+	navigator *pathfinding.Navigator
 }
 
 func NewLivingEntity(position r3.Vector, rotation *math.Rotation, motion r3.Vector, level interfaces.ILevel, dimension interfaces.IDimension) *LivingEntity {
-	return &LivingEntity{NewEntity(position, rotation, motion, level, dimension)}
+	var entity = &LivingEntity{Entity: NewEntity(position, rotation, motion, level, dimension)}
+	entity.SetStepHeight(DefaultStepHeight)
+	return entity
+}
+
+// GetNavigator returns the navigator that moves the living entity along paths, creating it if it does not exist yet.
+// The navigator searches paths for an entity of the size and step height of the living entity.
+func (entity *LivingEntity) GetNavigator() *pathfinding.Navigator {
+	if entity.navigator == nil {
+		var settings = pathfinding.DefaultSettings()
+		settings.Width, settings.Height = entity.GetWidth(), entity.GetHeight()
+		settings.StepHeight = entity.GetStepHeight()
+		entity.navigator = pathfinding.NewNavigator(entity, settings)
+	}
+	return entity.navigator
+}
+
+// Tick ticks the living entity, moving it along the path of its navigator before moving it according to its motion.
+func (entity *LivingEntity) Tick() {
+	if entity.navigator != nil {
+		entity.navigator.Tick()
+	}
+	entity.Entity.Tick()
 }
@@ -0,0 +1,211 @@
+package entities
+
+import (
+	math2 "math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/vectors"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// DefaultWidth and DefaultHeight are the size in blocks of the bounding box of entities that do not set their own size.
+const (
+	DefaultWidth  = 0.6
+	DefaultHeight = 1.8
+)
+
+// Gravity is the default amount of blocks per tick that entities affected by gravity accelerate downwards every tick.
+const Gravity = 0.08
+
+// Drag is the default fraction of their motion that entities lose every tick.
+const Drag = 0.02
+
+// GroundFriction is the fraction of their horizontal motion that entities keep every tick while on the ground.
+const GroundFriction = 0.6
+
+// MotionThreshold is the speed in blocks per tick below which motion on an axis stops entirely.
+const MotionThreshold = 0.003
+
+// GetWidth returns the width in blocks of the bounding box of the entity on the X and Z axis.
+func (entity *Entity) GetWidth() float64 {
+	return entity.width
+}
+
+// GetHeight returns the height in blocks of the bounding box of the entity.
+func (entity *Entity) GetHeight() float64 {
+	return entity.height
+}
+
+// SetSize sets the width and height in blocks of the bounding box of the entity.
+func (entity *Entity) SetSize(width, height float64) {
+	entity.width, entity.height = width, height
+}
+
+// GetBoundingBox returns the bounding box of the entity at its current position.
+// The position of the entity is in the center of the bottom of the box.
+func (entity *Entity) GetBoundingBox() *vectors.Cube {
+	var halfWidth = entity.width / 2
+	return vectors.NewCube(
+		entity.Position.X-halfWidth, entity.Position.Y, entity.Position.Z-halfWidth,
+		entity.Position.X+halfWidth, entity.Position.Y+entity.height, entity.Position.Z+halfWidth,
+	)
+}
+
+// SetGravity sets the amount of blocks per tick the entity accelerates downwards every tick, if it is affected by gravity.
+func (entity *Entity) SetGravity(gravity float64) {
+	entity.gravity = gravity
+}
+
+// SetDrag sets the fraction of its motion the entity loses every tick.
+func (entity *Entity) SetDrag(drag float64) {
+	entity.drag = drag
+}
+
+// GetStepHeight returns the height in blocks the entity walks up without jumping, such as the height of slabs.
+func (entity *Entity) GetStepHeight() float64 {
+	return entity.stepHeight
+}
+
+// SetStepHeight sets the height in blocks the entity walks up without jumping.
+func (entity *Entity) SetStepHeight(height float64) {
+	entity.stepHeight = height
+}
+
+// HasPhysics checks if the entity gets moved according to its motion every tick.
+func (entity *Entity) HasPhysics() bool {
+	return entity.physics
+}
+
+// SetPhysics sets if the entity gets moved according to its motion every tick.
+// Entities moved by something else, such as players moved by their client, should not have physics.
+func (entity *Entity) SetPhysics(value bool) {
+	entity.physics = value
+}
+
+// IsOnGround checks if the entity stood on a block after its last movement.
+func (entity *Entity) IsOnGround() bool {
+	return entity.onGround
+}
+
+// tickPhysics applies gravity and drag to the motion of the entity, and moves the entity by its motion.
+// Movement gets stopped by the collision boxes of blocks. The new position and motion are sent to the viewers of the entity.
+func (entity *Entity) tickPhysics() {
+	if !entity.physics || entity.Dimension == nil {
+		return
+	}
+	var previousMotion = entity.Motion
+	var motion = entity.Motion
+	if entity.GetDataFlag(AffectedByGravity) {
+		motion.Y -= entity.gravity
+	}
+	if motion == (r3.Vector{}) {
+		return
+	}
+
+	var offset = entity.clipMovement(motion)
+	entity.onGround = motion.Y < 0 && offset.Y != motion.Y
+	if offset.X != motion.X {
+		motion.X = 0
+	}
+	if offset.Y != motion.Y {
+		motion.Y = 0
+	}
+	if offset.Z != motion.Z {
+		motion.Z = 0
+	}
+
+	motion = motion.Mul(1 - entity.drag)
+	if entity.onGround {
+		motion.X *= GroundFriction
+		motion.Z *= GroundFriction
+	}
+	motion = r3.Vector{X: stopBelowThreshold(motion.X), Y: stopBelowThreshold(motion.Y), Z: stopBelowThreshold(motion.Z)}
+	entity.Motion = motion
+
+	if offset != (r3.Vector{}) {
+		entity.SetPosition(entity.Position.Add(offset))
+		for _, viewer := range entity.GetViewers() {
+			viewer.SendMoveEntity(entity, entity.Position, *entity.Rotation, entity.onGround, false)
+		}
+	}
+	if motion != previousMotion {
+		for _, viewer := range entity.GetViewers() {
+			viewer.SendSetEntityMotion(entity, motion)
+		}
+	}
+}
+
+// clipMovement returns the part of the motion the entity can move before its bounding box hits the collision boxes of blocks.
+// Entities on the ground that walk into a block no higher than their step height step up onto it.
+func (entity *Entity) clipMovement(motion r3.Vector) r3.Vector {
+	var box = entity.GetBoundingBox()
+	var offset = entity.clipBox(box, motion)
+	var grounded = entity.onGround || (motion.Y < 0 && offset.Y != motion.Y)
+	if entity.stepHeight <= 0 || !grounded || (offset.X == motion.X && offset.Z == motion.Z) {
+		return offset
+	}
+
+	// Move up by the step height, move horizontally and settle back down, and keep whichever movement went further.
+	var up = entity.clipBox(box, r3.Vector{Y: entity.stepHeight}).Y
+	var stepped = entity.clipBox(box.Offset(0, up, 0), r3.Vector{X: motion.X, Z: motion.Z})
+	stepped.Y = up + entity.clipBox(box.Offset(stepped.X, up, stepped.Z), r3.Vector{Y: -up}).Y
+	if stepped.X*stepped.X+stepped.Z*stepped.Z > offset.X*offset.X+offset.Z*offset.Z {
+		return stepped
+	}
+	return offset
+}
+
+// clipBox returns the part of the motion the box can move before it hits the collision boxes of blocks.
+// The movement is clipped on the Y axis first, and on the X and Z axis after.
+func (entity *Entity) clipBox(box *vectors.Cube, motion r3.Vector) r3.Vector {
+	var cubes = entity.getCollisionCubes(box.Stretch(motion))
+
+	var offset = motion
+	for _, cube := range cubes {
+		offset.Y = cube.ClipYOffset(box, offset.Y)
+	}
+	box = box.Offset(0, offset.Y, 0)
+	for _, cube := range cubes {
+		offset.X = cube.ClipXOffset(box, offset.X)
+	}
+	box = box.Offset(offset.X, 0, 0)
+	for _, cube := range cubes {
+		offset.Z = cube.ClipZOffset(box, offset.Z)
+	}
+	return offset
+}
+
+// getCollisionCubes returns the collision boxes of all blocks in the area, relative to the dimension.
+// Blocks in chunks that are not loaded are treated as full blocks, so that entities do not move into them.
+func (entity *Entity) getCollisionCubes(area *vectors.Cube) []*vectors.Cube {
+	var cubes []*vectors.Cube
+	// Blocks such as fences have collision boxes higher than a block, so the layer below the area is checked too.
+	var minY, maxY = int(math2.Max(math2.Floor(area.MinY)-1, 0)), int(math2.Min(math2.Floor(area.MaxY), 255))
+	for x := int(math2.Floor(area.MinX)); x <= int(math2.Floor(area.MaxX)); x++ {
+		for z := int(math2.Floor(area.MinZ)); z <= int(math2.Floor(area.MaxZ)); z++ {
+			var chunk = entity.Dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+			for y := minY; y <= maxY; y++ {
+				if chunk == nil {
+					cubes = append(cubes, vectors.NewCube(float64(x), float64(y), float64(z), float64(x+1), float64(y+1), float64(z+1)))
+					continue
+				}
+				var block = blocks.GetBlock(int(chunk.GetBlockId(x&15, y, z&15)), chunk.GetBlockData(x&15, y, z&15))
+				if !block.HasCollisionBox() {
+					continue
+				}
+				for _, cube := range block.GetCollisionBox().GetCubes() {
+					cubes = append(cubes, cube.Offset(float64(x), float64(y), float64(z)))
+				}
+			}
+		}
+	}
+	return cubes
+}
+
+// stopBelowThreshold returns 0 if the speed is below the motion threshold, or the speed otherwise.
+func stopBelowThreshold(speed float64) float64 {
+	if math2.Abs(speed) < MotionThreshold {
+		return 0
+	}
+	return speed
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	math2 "math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/entities/data"
+	"github.com/irmine/gomine/entities/math"
+	"github.com/irmine/gomine/interfaces"
+)
+
+// TntFuse is the amount of ticks TNT primed by a player burns before it explodes.
+const TntFuse = 80
+
+// TntPower is the power of the explosion of primed TNT.
+const TntPower = 4
+
+// PrimedTnt is a primed TNT block, which explodes in its dimension once its fuse has burnt.
+type PrimedTnt struct {
+	*Entity
+	fuse int
+}
+
+// NewTnt returns new primed TNT at the given position, which explodes after the fuse in ticks.
+func NewTnt(position r3.Vector, fuse int, level interfaces.ILevel, dimension interfaces.IDimension) *PrimedTnt {
+	var angle = rand.Float64() * math2.Pi * 2
+	var motion = r3.Vector{X: -math2.Sin(angle) * 0.02, Y: 0.2, Z: -math2.Cos(angle) * 0.02}
+	var tnt = &PrimedTnt{NewEntity(position, math.NewRotation(0, 0, 0), motion, level, dimension), fuse}
+	tnt.SetSize(0.98, 0.98)
+	tnt.SetGravity(0.04)
+	tnt.SetDataFlag(Ignited, true)
+	tnt.EntityData[DataFuseLength] = []interface{}{uint32(data.Int), int32(fuse)}
+
+	return tnt
+}
+
+// GetEntityId returns the entity ID of primed TNT.
+func (tnt *PrimedTnt) GetEntityId() uint32 {
+	return Tnt
+}
+
+// GetFuse returns the amount of ticks left until the TNT explodes.
+func (tnt *PrimedTnt) GetFuse() int {
+	return tnt.fuse
+}
+
+// SpawnTo spawns the primed TNT to the given player.
+func (tnt *PrimedTnt) SpawnTo(player interfaces.IPlayer) {
+	if !player.HasSpawned() {
+		return
+	}
+	tnt.AddViewer(player)
+	player.SendAddEntity(tnt)
+}
+
+// SpawnToAll spawns the primed TNT to all players that have the chunk of the TNT loaded.
+func (tnt *PrimedTnt) SpawnToAll() {
+	var chunk = tnt.GetChunk()
+	if chunk == nil {
+		return
+	}
+	for _, player := range chunk.GetViewers() {
+		tnt.SpawnTo(player)
+	}
+}
+
+// Tick ticks the primed TNT, burning its fuse. The TNT gets despawned, closed and exploded once the fuse has burnt.
+func (tnt *PrimedTnt) Tick() {
+	tnt.Entity.Tick()
+	tnt.fuse--
+	if tnt.fuse > 0 {
+		return
+	}
+	for _, player := range tnt.GetViewers() {
+		tnt.DespawnFrom(player)
+	}
+	var dimension, position = tnt.GetDimension(), tnt.GetPosition()
+	if chunk := tnt.GetChunk(); chunk != nil {
+		chunk.RemoveEntity(tnt)
+	}
+	tnt.Close()
+	dimension.Explode(position.Add(r3.Vector{Y: 0.0625}), TntPower, 0)
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"github.com/irmine/gomine/entities/data"
 	"github.com/irmine/gomine/entities/math"
+	"github.com/irmine/gomine/vectors"
 	"github.com/golang/geo/r3"
 )
 
@@ -37,4 +38,6 @@ type IEntity interface {
 	GetEntityId() uint32
 	GetEntityData() map[uint32][]interface{}
 	GetAttributeMap() *data.AttributeMap
+	GetBoundingBox() *vectors.Cube
+	IsOnGround() bool
 }
@@ -78,10 +78,16 @@ type IMinecraftSession interface {
 
 	SendAddEntity(IEntity)
 	SendAddPlayer(IPlayer)
+	SendBlockEntityData(ITile)
+	SendChangeDimension(int32, r3.Vector, bool)
 	SendChunkRadiusUpdated(int32)
 	SendCraftingData()
 	SendDisconnect(string, bool)
+	SendExplode(r3.Vector, float32, []r3.Vector)
 	SendFullChunkData(IChunk)
+	SendGameRulesChanged(map[string]IGameRule)
+	SendLevelEvent(int32, r3.Vector, int32)
+	SendMoveEntity(IEntity, r3.Vector, math.Rotation, bool, bool)
 	SendMovePlayer(IPlayer, r3.Vector, math.Rotation, byte, bool, uint64)
 	SendPlayerList(byte, map[string]IPlayer)
 	SendPlayStatus(int32)
@@ -92,10 +98,13 @@ type IMinecraftSession interface {
 	SendResourcePackStack(bool, []packs.Pack, []packs.Pack)
 	SendServerHandshake(string)
 	SendSetEntityData(IEntity, map[uint32][]interface{})
+	SendSetEntityMotion(IEntity, r3.Vector)
+	SendSetTime(int32)
 	SendStartGame(IPlayer)
 	SendText(types.Text)
 	Transfer(string, uint16)
 	SendUpdateAttributes(IEntity, *data.AttributeMap)
+	SendUpdateBlock(r3.Vector, uint32, uint32, uint32)
 }
 
 type IProtocol interface {
@@ -112,10 +121,16 @@ type IProtocol interface {
 
 	GetAddEntity(IEntity) IPacket
 	GetAddPlayer(IPlayer) IPacket
+	GetBlockEntityData(ITile) IPacket
+	GetChangeDimension(int32, r3.Vector, bool) IPacket
 	GetChunkRadiusUpdated(int32) IPacket
 	GetCraftingData() IPacket
 	GetDisconnect(string, bool) IPacket
+	GetExplode(r3.Vector, float32, []r3.Vector) IPacket
 	GetFullChunkData(IChunk) IPacket
+	GetGameRulesChanged(map[string]IGameRule) IPacket
+	GetLevelEvent(int32, r3.Vector, int32) IPacket
+	GetMoveEntity(uint64, r3.Vector, math.Rotation, bool, bool) IPacket
 	GetMovePlayer(uint64, r3.Vector, math.Rotation, byte, bool, uint64) IPacket
 	GetPlayerList(byte, map[string]IPlayer) IPacket
 	GetPlayStatus(int32) IPacket
@@ -126,10 +141,13 @@ type IProtocol interface {
 	GetResourcePackStack(bool, []packs.Pack, []packs.Pack) IPacket
 	GetServerHandshake(string) IPacket
 	GetSetEntityData(IEntity, map[uint32][]interface{}) IPacket
+	GetSetEntityMotion(uint64, r3.Vector) IPacket
+	GetSetTime(int32) IPacket
 	GetStartGame(IPlayer) IPacket
 	GetText(types.Text) IPacket
 	GetTransfer(string, uint16) IPacket
 	GetUpdateAttributes(IEntity, *data.AttributeMap) IPacket
+	GetUpdateBlock(r3.Vector, uint32, uint32, uint32) IPacket
 }
 
 type IProtocolPool interface {
@@ -49,6 +49,7 @@ type IPlayer interface {
 	SyncMove(float64, float64, float64, float32, float32, float32, bool)
 	SendMessage(...interface{})
 	PlaceInWorld(r3.Vector, *math.Rotation, ILevel, IDimension)
+	ChangeDimension(IDimension, r3.Vector)
 	HasChunkInUse(int) bool
 	HasAnyChunkInUse() bool
 	SpawnPlayerTo(IPlayer)
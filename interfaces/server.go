@@ -23,7 +23,14 @@ type IServer interface {
 	GetLoadedLevels() map[int]ILevel
 	IsLevelLoaded(string) bool
 	IsLevelGenerated(string) bool
+	GetLevelPath(string) string
 	LoadLevel(string) bool
+	GenerateLevel(string, resources.WorldConfig) error
+	UnloadLevel(string) error
+	GetWorldConfig(string) resources.WorldConfig
+	GetBackupPath() string
+	BackupLevel(string, string, func(string, error)) error
+	RestoreLevel(string, string) error
 	HasPermission(string) bool
 	SendMessage(...interface{})
 	GetName() string
@@ -32,6 +39,7 @@ type IServer interface {
 	GetMaximumPlayers() uint
 	GetMotd() string
 	Tick(int64)
+	ScheduleTask(func())
 	GetPermissionManager() *permissions.Manager
 	GetEngineName() string
 	GetMinecraftVersion() string
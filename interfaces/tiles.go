@@ -0,0 +1,20 @@
+package interfaces
+
+import "github.com/irmine/gomine/nbt"
+
+// ITile is a tile (block entity): extra state of a block, stored as NBT with the chunk the block is in.
+type ITile interface {
+	GetName() string
+	GetId() uint64
+	GetX() int
+	GetY() int
+	GetZ() int
+	SetPosition(int, int, int)
+	GetCustomName() string
+	SetCustomName(string)
+	IsClosed() bool
+	Close()
+	Load(nbt.Compound)
+	Save() nbt.Compound
+	GetSpawnCompound() nbt.Compound
+}
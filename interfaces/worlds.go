@@ -1,6 +1,12 @@
 package interfaces
 
-import "github.com/irmine/gomine/vectors"
+import (
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/vectors"
+)
 
 type IBlock interface {
 	GetId() int
@@ -28,10 +34,35 @@ type IBlock interface {
 }
 
 type IGenerator interface {
+	New(int64, string) IGenerator
 	GetName() string
+	GetSeed() int64
+	GetOptions() string
 	GetNewChunk(IChunk) IChunk
 	GenerateChunk(IChunk)
-	PopulateChunk(IChunk)
+	PopulateChunk(IChunkArea)
+	AddPopulator(IPopulator)
+	AddBiomePopulator(int, IPopulator)
+	GetPopulators(int) []IPopulator
+}
+
+// IPopulator places features such as ores and trees in a chunk after its terrain has been generated.
+type IPopulator interface {
+	Populate(IChunkArea, *rand.Rand)
+}
+
+// IChunkArea gives access to a chunk being populated and its eight neighbouring chunks.
+// Coordinates are world coordinates. Blocks outside of the area read as air, and setting them has no effect.
+type IChunkArea interface {
+	GetChunkX() int32
+	GetChunkZ() int32
+	GetChunk(int32, int32) IChunk
+	GetBlockId(int, int, int) byte
+	SetBlockId(int, int, int, byte)
+	GetBlockData(int, int, int) byte
+	SetBlockData(int, int, int, byte)
+	GetHighestBlock(int, int) int
+	GetBiome(int, int) int
 }
 
 type IChunk interface {
@@ -59,15 +90,30 @@ type IChunk interface {
 	SetSubChunk(int, ISubChunk) bool
 	GetSubChunk(int) (ISubChunk, error)
 	GetSubChunks() map[int]ISubChunk
+	PruneEmptySubChunks()
 	GetHighestBlockId(int, int) byte
 	GetHighestBlockData(int, int) byte
 	GetHighestBlock(int, int) int16
 	ToBinary() []byte
+	MarkDirty()
+	IsDirty() bool
+	GetCompressedBatch(int32, func() []byte) []byte
 	RecalculateHeightMap()
+	RecalculateHeightMapColumn(int, int)
 	GetEntities() map[uint64]IEntity
 	GetViewers() map[uint64]IPlayer
 	AddViewer(IPlayer)
 	RemoveViewer(IPlayer)
+	GetHeightMap(int, int) int16
+	SetHeightMap(int, int, int16)
+	GetEntityNBT() []byte
+	SetEntityNBT([]byte)
+	GetTileNBT() ([]byte, error)
+	SetTileNBT([]byte)
+	AddTile(ITile) bool
+	RemoveTile(ITile)
+	GetTile(int, int, int) ITile
+	GetTiles() map[int]ITile
 }
 
 type ISubChunk interface {
@@ -85,6 +131,8 @@ type ISubChunk interface {
 	GetHighestBlockId(int, int) byte
 	GetHighestBlockData(int, int) byte
 	GetHighestBlock(int, int) int
+	HasUniformLight(byte, byte) bool
+	Compact()
 	ToBinary() []byte
 }
 
@@ -102,6 +150,68 @@ type ILevel interface {
 	GetGameRule(string) IGameRule
 	AddGameRule(IGameRule)
 	GetRuntimeId() int
+	GetChunkProvider() IChunkProvider
+	GetSeed() int64
+	GetSpawnPosition() r3.Vector
+	SetSpawnPosition(r3.Vector)
+	GetGeneratorName() string
+	GetGeneratorOptions() string
+	GetDefaultGameMode() byte
+	SetDefaultGameMode(byte)
+	GetDifficulty() byte
+	SetDifficulty(byte)
+	GetTime() int64
+	SetTime(int64)
+	StopTime()
+	StartTime()
+	IsTimeStopped() bool
+	GetPlayers() map[string]IPlayer
+	GetWeather() int
+	GetWeatherDuration() int
+	SetWeather(int, int) bool
+	AddWeatherChangeHandler(func(IWeatherChange))
+	AddExplosionHandler(func(IExplosion))
+	GetExplosionHandlers() []func(IExplosion)
+	GetRainLevel() float32
+	GetLightningLevel() float32
+	SendWeather(IPlayer)
+	SendWeatherFrom(IPlayer, int)
+	StrikeLightning(IDimension, r3.Vector)
+	Save()
+	Close()
+}
+
+// IWeatherChange is a weather transition of a level, passed to weather change handlers before it happens.
+type IWeatherChange interface {
+	GetLevel() ILevel
+	GetFrom() int
+	GetTo() int
+	SetTo(int)
+	GetDuration() int
+	SetDuration(int)
+	Cancel()
+	IsCancelled() bool
+}
+
+// IExplosion is an explosion in a dimension, passed to explosion handlers before it destroys blocks and damages entities.
+type IExplosion interface {
+	GetDimension() IDimension
+	GetCenter() r3.Vector
+	GetPower() float64
+	GetBlocks() []r3.Vector
+	SetBlocks([]r3.Vector)
+	Cancel()
+	IsCancelled() bool
+}
+
+// IRayTraceResult is the block or entity hit by a ray traced through a dimension.
+type IRayTraceResult interface {
+	GetPosition() r3.Vector
+	GetDistance() float64
+	GetFace() int
+	GetBlockPosition() r3.Vector
+	GetBlock() IBlock
+	GetEntity() IEntity
 }
 
 type IGameRule interface {
@@ -110,6 +220,15 @@ type IGameRule interface {
 	SetValue(interface{}) bool
 }
 
+// IStructure is a cuboid of blocks with their tiles, which can be pasted into and captured from dimensions.
+type IStructure interface {
+	GetSize() (int, int, int)
+	GetBlock(int, int, int) (byte, byte)
+	SetBlock(int, int, int, byte, byte)
+	GetTile(int, int, int) nbt.Compound
+	SetTile(int, int, int, nbt.Compound)
+}
+
 type IDimension interface {
 	GetDimensionId() int
 	GetLevel() ILevel
@@ -117,8 +236,38 @@ type IDimension interface {
 	TickDimension()
 	SetChunk(int32, int32, IChunk)
 	GetChunk(int32, int32) IChunk
+	GetLoadedChunk(int32, int32) IChunk
+	RequestChunkAsync(int32, int32, func(IChunk))
+	SetBlock(r3.Vector, IBlock)
+	GetBlock(r3.Vector) IBlock
+	GetTile(r3.Vector) ITile
+	AddTile(ITile)
+	RemoveTile(ITile)
+	UpdateTile(ITile)
+	PasteStructure(r3.Vector, IStructure, int, int)
+	CaptureStructure(r3.Vector, r3.Vector) IStructure
+	RefreshChunks([]IChunk)
+	Explode(r3.Vector, float64, int) IExplosion
+	PrimeTnt(r3.Vector, int) IEntity
+	RayTraceBlocks(r3.Vector, r3.Vector, float64) IRayTraceResult
+	RayTraceEntities(r3.Vector, r3.Vector, float64, func(IEntity) bool) IRayTraceResult
+	ScheduleUpdate(r3.Vector, int64)
+	IsUpdateScheduled(r3.Vector) bool
 	RequestChunks(IPlayer, int32)
 	IsGenerated() bool
 	SetGenerator(IGenerator)
 	GetGenerator() IGenerator
+	IsChunkLoaded(int32, int32) bool
+	SetChunkUnloaded(int32, int32)
+	GetLoadedChunkCount() int
+	SaveChunks()
+}
+
+type IChunkProvider interface {
+	GetPath() string
+	ChunkExists(int32, int32, int) bool
+	LoadChunk(int32, int32, int) (IChunk, error)
+	SaveChunk(IChunk, int) error
+	Backup(string) error
+	Close() error
 }
@@ -0,0 +1,172 @@
+package nbt
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Encoding decides how the numeric values and lengths of NBT tags are encoded.
+type Encoding interface {
+	WriteShort(w io.Writer, v int16) error
+	WriteInt(w io.Writer, v int32) error
+	WriteLong(w io.Writer, v int64) error
+	WriteFloat(w io.Writer, v float32) error
+	WriteDouble(w io.Writer, v float64) error
+	WriteStringLength(w io.Writer, length int) error
+
+	ReadShort(r reader) (int16, error)
+	ReadInt(r reader) (int32, error)
+	ReadLong(r reader) (int64, error)
+	ReadFloat(r reader) (float32, error)
+	ReadDouble(r reader) (float64, error)
+	ReadStringLength(r reader) (int, error)
+}
+
+// reader is a reader that can also read single bytes.
+type reader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// LittleEndian is the encoding used by Minecraft Bedrock Edition for NBT stored on disk, such as level.dat and LevelDB entries.
+var LittleEndian Encoding = fixedEndian{binary.LittleEndian}
+
+// BigEndian is the encoding used by Minecraft Java Edition, and by files made for it such as MCEdit schematics.
+var BigEndian Encoding = fixedEndian{binary.BigEndian}
+
+// fixedEndian writes all values with their fixed size in the byte order, and string lengths as unsigned shorts.
+type fixedEndian struct {
+	order binary.ByteOrder
+}
+
+func (e fixedEndian) WriteShort(w io.Writer, v int16) error {
+	var b [2]byte
+	e.order.PutUint16(b[:], uint16(v))
+	var _, err = w.Write(b[:])
+	return err
+}
+
+func (e fixedEndian) WriteInt(w io.Writer, v int32) error {
+	var b [4]byte
+	e.order.PutUint32(b[:], uint32(v))
+	var _, err = w.Write(b[:])
+	return err
+}
+
+func (e fixedEndian) WriteLong(w io.Writer, v int64) error {
+	var b [8]byte
+	e.order.PutUint64(b[:], uint64(v))
+	var _, err = w.Write(b[:])
+	return err
+}
+
+func (e fixedEndian) WriteFloat(w io.Writer, v float32) error {
+	return e.WriteInt(w, int32(math.Float32bits(v)))
+}
+
+func (e fixedEndian) WriteDouble(w io.Writer, v float64) error {
+	return e.WriteLong(w, int64(math.Float64bits(v)))
+}
+
+func (e fixedEndian) WriteStringLength(w io.Writer, length int) error {
+	if length > math.MaxUint16 {
+		return InvalidLength
+	}
+	return e.WriteShort(w, int16(uint16(length)))
+}
+
+func (e fixedEndian) ReadShort(r reader) (int16, error) {
+	var b [2]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return int16(e.order.Uint16(b[:])), nil
+}
+
+func (e fixedEndian) ReadInt(r reader) (int32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return int32(e.order.Uint32(b[:])), nil
+}
+
+func (e fixedEndian) ReadLong(r reader) (int64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return int64(e.order.Uint64(b[:])), nil
+}
+
+func (e fixedEndian) ReadFloat(r reader) (float32, error) {
+	var v, err = e.ReadInt(r)
+	return math.Float32frombits(uint32(v)), err
+}
+
+func (e fixedEndian) ReadDouble(r reader) (float64, error) {
+	var v, err = e.ReadLong(r)
+	return math.Float64frombits(uint64(v)), err
+}
+
+func (e fixedEndian) ReadStringLength(r reader) (int, error) {
+	var v, err = e.ReadShort(r)
+	return int(uint16(v)), err
+}
+
+// NetworkLittleEndian is the encoding used by Minecraft Bedrock Edition for NBT sent over the network.
+// Ints and longs are written as zigzag encoded varints, and string lengths as unsigned varints.
+// All other values are written the same as with LittleEndian.
+var NetworkLittleEndian Encoding = networkLittleEndian{fixedEndian{binary.LittleEndian}}
+
+type networkLittleEndian struct {
+	fixedEndian
+}
+
+func (networkLittleEndian) WriteInt(w io.Writer, v int32) error {
+	var b [binary.MaxVarintLen32]byte
+	var _, err = w.Write(b[:binary.PutUvarint(b[:], uint64(uint32(v<<1)^uint32(v>>31)))])
+	return err
+}
+
+func (networkLittleEndian) WriteLong(w io.Writer, v int64) error {
+	var b [binary.MaxVarintLen64]byte
+	var _, err = w.Write(b[:binary.PutVarint(b[:], v)])
+	return err
+}
+
+func (networkLittleEndian) WriteStringLength(w io.Writer, length int) error {
+	if length > math.MaxInt16 {
+		return InvalidLength
+	}
+	var b [binary.MaxVarintLen32]byte
+	var _, err = w.Write(b[:binary.PutUvarint(b[:], uint64(length))])
+	return err
+}
+
+func (networkLittleEndian) ReadInt(r reader) (int32, error) {
+	var v, err = binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint32 {
+		return 0, InvalidLength
+	}
+	return int32(uint32(v)>>1) ^ -int32(v&1), nil
+}
+
+func (networkLittleEndian) ReadLong(r reader) (int64, error) {
+	return binary.ReadVarint(r)
+}
+
+func (networkLittleEndian) ReadStringLength(r reader) (int, error) {
+	var v, err = binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxInt16 {
+		return 0, InvalidLength
+	}
+	return int(v), nil
+}
@@ -0,0 +1,176 @@
+// Package nbt implements the Named Binary Tag format used by Minecraft to store structured data.
+// Tags are represented by plain Go values:
+//
+//	TAG_Byte       byte
+//	TAG_Short      int16
+//	TAG_Int        int32
+//	TAG_Long       int64
+//	TAG_Float      float32
+//	TAG_Double     float64
+//	TAG_Byte_Array []byte
+//	TAG_String     string
+//	TAG_List       []interface{}
+//	TAG_Compound   Compound
+//	TAG_Int_Array  []int32
+//	TAG_Long_Array []int64
+//
+// The byte order and integer encoding is decided by the Encoding used to read or write the data:
+// LittleEndian for Bedrock Edition files, NetworkLittleEndian for packets and BigEndian for Java Edition files.
+// Compounds are read and written with a Decoder and Encoder, or with Unmarshal and Marshal.
+// Structs can be converted to and from compounds using ToCompound and FromCompound.
+// Unsupported values and malformed data are reported as errors.
+package nbt
+
+import (
+	"errors"
+)
+
+const (
+	TagEnd byte = iota
+	TagByte
+	TagShort
+	TagInt
+	TagLong
+	TagFloat
+	TagDouble
+	TagByteArray
+	TagString
+	TagList
+	TagCompound
+	TagIntArray
+	TagLongArray
+)
+
+// MaximumDepth is the maximum depth of nested lists and compounds allowed when reading NBT.
+const MaximumDepth = 512
+
+var (
+	UnknownTag           = errors.New("unknown nbt tag type")
+	UnsupportedType      = errors.New("value type can not be represented as nbt tag")
+	InvalidRoot          = errors.New("root nbt tag is not a compound")
+	MaximumDepthExceeded = errors.New("maximum nbt depth exceeded")
+	InvalidLength        = errors.New("invalid nbt length")
+	MixedList            = errors.New("nbt list contains values of different types")
+)
+
+// Compound is a TAG_Compound, mapping names to tag values.
+type Compound map[string]interface{}
+
+// NewCompound returns a new empty compound.
+func NewCompound() Compound {
+	return Compound{}
+}
+
+// Has checks if the compound has a tag with the given name.
+func (compound Compound) Has(name string) bool {
+	var _, ok = compound[name]
+	return ok
+}
+
+// GetByte returns the byte tag with the given name, or 0 if it does not exist or is not a byte.
+func (compound Compound) GetByte(name string) byte {
+	var v, _ = compound[name].(byte)
+	return v
+}
+
+// GetBool returns the byte tag with the given name as bool.
+func (compound Compound) GetBool(name string) bool {
+	return compound.GetByte(name) != 0
+}
+
+// GetShort returns the short tag with the given name, or 0 if it does not exist or is not a short.
+func (compound Compound) GetShort(name string) int16 {
+	var v, _ = compound[name].(int16)
+	return v
+}
+
+// GetInt returns the int tag with the given name, or 0 if it does not exist or is not an int.
+func (compound Compound) GetInt(name string) int32 {
+	var v, _ = compound[name].(int32)
+	return v
+}
+
+// GetLong returns the long tag with the given name, or 0 if it does not exist or is not a long.
+func (compound Compound) GetLong(name string) int64 {
+	var v, _ = compound[name].(int64)
+	return v
+}
+
+// GetFloat returns the float tag with the given name, or 0 if it does not exist or is not a float.
+func (compound Compound) GetFloat(name string) float32 {
+	var v, _ = compound[name].(float32)
+	return v
+}
+
+// GetDouble returns the double tag with the given name, or 0 if it does not exist or is not a double.
+func (compound Compound) GetDouble(name string) float64 {
+	var v, _ = compound[name].(float64)
+	return v
+}
+
+// GetString returns the string tag with the given name, or an empty string if it does not exist or is not a string.
+func (compound Compound) GetString(name string) string {
+	var v, _ = compound[name].(string)
+	return v
+}
+
+// GetByteArray returns the byte array tag with the given name, or nil if it does not exist.
+func (compound Compound) GetByteArray(name string) []byte {
+	var v, _ = compound[name].([]byte)
+	return v
+}
+
+// GetIntArray returns the int array tag with the given name, or nil if it does not exist.
+func (compound Compound) GetIntArray(name string) []int32 {
+	var v, _ = compound[name].([]int32)
+	return v
+}
+
+// GetLongArray returns the long array tag with the given name, or nil if it does not exist.
+func (compound Compound) GetLongArray(name string) []int64 {
+	var v, _ = compound[name].([]int64)
+	return v
+}
+
+// GetList returns the list tag with the given name, or nil if it does not exist.
+func (compound Compound) GetList(name string) []interface{} {
+	var v, _ = compound[name].([]interface{})
+	return v
+}
+
+// GetCompound returns the compound tag with the given name, or nil if it does not exist.
+func (compound Compound) GetCompound(name string) Compound {
+	var v, _ = compound[name].(Compound)
+	return v
+}
+
+// tagType returns the tag type of the given value.
+func tagType(value interface{}) (byte, error) {
+	switch value.(type) {
+	case byte, int8, bool:
+		return TagByte, nil
+	case int16:
+		return TagShort, nil
+	case int32:
+		return TagInt, nil
+	case int64:
+		return TagLong, nil
+	case float32:
+		return TagFloat, nil
+	case float64:
+		return TagDouble, nil
+	case []byte:
+		return TagByteArray, nil
+	case string:
+		return TagString, nil
+	case []interface{}, []Compound:
+		return TagList, nil
+	case Compound, map[string]interface{}:
+		return TagCompound, nil
+	case []int32:
+		return TagIntArray, nil
+	case []int64:
+		return TagLongArray, nil
+	}
+	return TagEnd, UnsupportedType
+}
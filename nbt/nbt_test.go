@@ -0,0 +1,210 @@
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var encodings = []struct {
+	name     string
+	encoding Encoding
+}{
+	{"LittleEndian", LittleEndian},
+	{"BigEndian", BigEndian},
+	{"NetworkLittleEndian", NetworkLittleEndian},
+}
+
+// tagValues returns a value of every tag type, with the type it is read back as.
+func tagValues() map[string]interface{} {
+	return map[string]interface{}{
+		"byte":          byte(200),
+		"short":         int16(-12345),
+		"int":           int32(math.MinInt32),
+		"positiveInt":   int32(300),
+		"long":          int64(math.MaxInt64),
+		"negativeLong":  int64(-1),
+		"float":         float32(1.5),
+		"double":        -math.Pi,
+		"byteArray":     []byte{0, 1, 255},
+		"string":        "gomine ✓",
+		"emptyString":   "",
+		"list":          []interface{}{int32(1), int32(-2), int32(3)},
+		"emptyList":     []interface{}{},
+		"compoundList":  []interface{}{Compound{"a": byte(1)}, Compound{"b": "c"}},
+		"nestedList":    []interface{}{[]interface{}{int16(1)}, []interface{}{}},
+		"compound":      Compound{"nested": Compound{"value": int64(7)}, "empty": Compound{}},
+		"intArray":      []int32{math.MinInt32, 0, math.MaxInt32},
+		"emptyIntArray": []int32{},
+		"longArray":     []int64{math.MinInt64, 0, math.MaxInt64},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range encodings {
+		for name, value := range tagValues() {
+			var compound = Compound{name: value}
+			var data, err = Marshal(test.encoding, compound)
+			if err != nil {
+				t.Errorf("%v: marshalling %v: %v", test.name, name, err)
+				continue
+			}
+			decoded, err := Unmarshal(test.encoding, data)
+			if err != nil {
+				t.Errorf("%v: unmarshalling %v: %v", test.name, name, err)
+				continue
+			}
+			if !reflect.DeepEqual(decoded, compound) {
+				t.Errorf("%v: %v decoded as %#v, want %#v", test.name, name, decoded[name], value)
+			}
+		}
+	}
+}
+
+func TestRoundTripAllTags(t *testing.T) {
+	for _, test := range encodings {
+		var compound = Compound(tagValues())
+		var buffer = bytes.NewBuffer(nil)
+		var encoder = NewEncoder(buffer, test.encoding)
+		if err := encoder.EncodeNamed("root", compound); err != nil {
+			t.Fatalf("%v: encoding: %v", test.name, err)
+		}
+		if err := encoder.Encode(Compound{"second": byte(2)}); err != nil {
+			t.Fatalf("%v: encoding second compound: %v", test.name, err)
+		}
+
+		var decoder = NewDecoder(buffer, test.encoding)
+		var name, decoded, err = decoder.DecodeNamed()
+		if err != nil {
+			t.Fatalf("%v: decoding: %v", test.name, err)
+		}
+		if name != "root" || !reflect.DeepEqual(decoded, compound) {
+			t.Errorf("%v: decoded %q %v, want %q %v", test.name, name, decoded, "root", compound)
+		}
+		if second, err := decoder.Decode(); err != nil || second.GetByte("second") != 2 {
+			t.Errorf("%v: decoded second compound %v with error %v", test.name, second, err)
+		}
+		if _, err := decoder.Decode(); err != io.EOF {
+			t.Errorf("%v: decoding past the end returned %v, want io.EOF", test.name, err)
+		}
+	}
+}
+
+func TestWriteAliases(t *testing.T) {
+	var compound = Compound{
+		"bool":      true,
+		"int8":      int8(-1),
+		"compounds": []Compound{{"a": int32(1)}},
+		"map":       map[string]interface{}{"a": int32(1)},
+	}
+	var want = Compound{
+		"bool":      byte(1),
+		"int8":      byte(255),
+		"compounds": []interface{}{Compound{"a": int32(1)}},
+		"map":       Compound{"a": int32(1)},
+	}
+	for _, test := range encodings {
+		var data, err = Marshal(test.encoding, compound)
+		if err != nil {
+			t.Fatalf("%v: marshalling: %v", test.name, err)
+		}
+		decoded, err := Unmarshal(test.encoding, data)
+		if err != nil || !reflect.DeepEqual(decoded, want) {
+			t.Errorf("%v: decoded %v with error %v, want %v", test.name, decoded, err, want)
+		}
+	}
+}
+
+func TestEncodingBytes(t *testing.T) {
+	var compound = Compound{"i": int32(300), "s": int16(1)}
+	var tests = []struct {
+		name     string
+		encoding Encoding
+		want     []byte
+	}{
+		{"LittleEndian", LittleEndian, []byte{
+			TagCompound, 0, 0,
+			TagInt, 1, 0, 'i', 0x2c, 0x01, 0, 0,
+			TagShort, 1, 0, 's', 1, 0,
+			TagEnd,
+		}},
+		{"BigEndian", BigEndian, []byte{
+			TagCompound, 0, 0,
+			TagInt, 0, 1, 'i', 0, 0, 0x01, 0x2c,
+			TagShort, 0, 1, 's', 0, 1,
+			TagEnd,
+		}},
+		{"NetworkLittleEndian", NetworkLittleEndian, []byte{
+			TagCompound, 0,
+			TagInt, 1, 'i', 0xd8, 0x04,
+			TagShort, 1, 's', 1, 0,
+			TagEnd,
+		}},
+	}
+	for _, test := range tests {
+		var data, err = Marshal(test.encoding, compound)
+		if err != nil {
+			t.Fatalf("%v: marshalling: %v", test.name, err)
+		}
+		if !bytes.Equal(data, test.want) {
+			t.Errorf("%v: encoded as % x, want % x", test.name, data, test.want)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		compound Compound
+		err      error
+	}{
+		{"unsupported", Compound{"a": struct{}{}}, UnsupportedType},
+		{"mixed list", Compound{"a": []interface{}{int32(1), int64(1)}}, MixedList},
+		{"unsupported list", Compound{"a": []interface{}{uint(1)}}, UnsupportedType},
+	}
+	for _, test := range tests {
+		for _, encoding := range encodings {
+			if _, err := Marshal(encoding.encoding, test.compound); err != test.err {
+				t.Errorf("%v: %v: marshalling returned %v, want %v", encoding.name, test.name, err, test.err)
+			}
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var valid, _ = Marshal(LittleEndian, Compound{"a": "value", "b": []int32{1, 2}})
+	for length := 0; length < len(valid); length++ {
+		var _, err = Unmarshal(LittleEndian, valid[:length])
+		if length == 0 && err != io.EOF {
+			t.Errorf("decoding no data returned %v, want io.EOF", err)
+		}
+		if length > 0 && err != io.ErrUnexpectedEOF {
+			t.Errorf("decoding %v of %v bytes returned %v, want io.ErrUnexpectedEOF", length, len(valid), err)
+		}
+	}
+
+	var tests = []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"invalid root", []byte{TagInt, 0, 0, 0, 0, 0, 0}, InvalidRoot},
+		{"unknown tag", []byte{TagCompound, 0, 0, 13, 1, 0, 'a', TagEnd}, UnknownTag},
+		{"negative length", []byte{TagCompound, 0, 0, TagByteArray, 0, 0, 0xff, 0xff, 0xff, 0xff, TagEnd}, InvalidLength},
+	}
+	for _, test := range tests {
+		if _, err := Unmarshal(LittleEndian, test.data); err != test.err {
+			t.Errorf("%v: decoding returned %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	var deep = []byte{TagCompound, 0, 0}
+	for i := 0; i < MaximumDepth; i++ {
+		deep = append(deep, TagCompound, 0, 0)
+	}
+	if _, err := Unmarshal(LittleEndian, deep); err != MaximumDepthExceeded {
+		t.Errorf("decoding nested compounds returned %v, want %v", err, MaximumDepthExceeded)
+	}
+}
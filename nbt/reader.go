@@ -0,0 +1,208 @@
+package nbt
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Decoder reads NBT compounds from an input stream.
+type Decoder struct {
+	r        reader
+	encoding Encoding
+}
+
+// NewDecoder returns a new decoder reading from r, using the given encoding.
+// If r does not implement io.ByteReader, it gets buffered, and the decoder may read more data from r than needed.
+func NewDecoder(r io.Reader, encoding Encoding) *Decoder {
+	if byteReader, ok := r.(reader); ok {
+		return &Decoder{byteReader, encoding}
+	}
+	return &Decoder{bufio.NewReader(r), encoding}
+}
+
+// Unmarshal decodes the root compound in data using the given encoding.
+func Unmarshal(encoding Encoding, data []byte) (Compound, error) {
+	return NewDecoder(bytes.NewReader(data), encoding).Decode()
+}
+
+// Decode reads the next root compound from the stream.
+// Returns io.EOF if the stream has no more data.
+func (decoder *Decoder) Decode() (Compound, error) {
+	var _, compound, err = decoder.DecodeNamed()
+	return compound, err
+}
+
+// DecodeNamed reads the next root compound from the stream, and returns its name and the compound.
+// Returns io.EOF if the stream has no more data.
+func (decoder *Decoder) DecodeNamed() (string, Compound, error) {
+	var tag, err = decoder.r.ReadByte()
+	if err != nil {
+		return "", nil, err
+	}
+	if tag != TagCompound {
+		return "", nil, InvalidRoot
+	}
+	name, err := decoder.readString()
+	if err != nil {
+		return "", nil, unexpected(err)
+	}
+	compound, err := decoder.readCompound(0)
+	return name, compound, unexpected(err)
+}
+
+// readCompound reads the tags of a compound up to and including TAG_End.
+func (decoder *Decoder) readCompound(depth int) (Compound, error) {
+	if depth >= MaximumDepth {
+		return nil, MaximumDepthExceeded
+	}
+	var compound = Compound{}
+	for {
+		var tag, err = decoder.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if tag == TagEnd {
+			return compound, nil
+		}
+		name, err := decoder.readString()
+		if err != nil {
+			return nil, err
+		}
+		value, err := decoder.readValue(tag, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		compound[name] = value
+	}
+}
+
+// readValue reads the payload of a tag with the given type.
+func (decoder *Decoder) readValue(tag byte, depth int) (interface{}, error) {
+	var e = decoder.encoding
+	switch tag {
+	case TagByte:
+		return decoder.r.ReadByte()
+	case TagShort:
+		return e.ReadShort(decoder.r)
+	case TagInt:
+		return e.ReadInt(decoder.r)
+	case TagLong:
+		return e.ReadLong(decoder.r)
+	case TagFloat:
+		return e.ReadFloat(decoder.r)
+	case TagDouble:
+		return e.ReadDouble(decoder.r)
+	case TagByteArray:
+		var length, err = decoder.readLength()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.readBytes(length)
+	case TagString:
+		return decoder.readString()
+	case TagList:
+		if depth >= MaximumDepth {
+			return nil, MaximumDepthExceeded
+		}
+		var elementType, err = decoder.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		length, err := decoder.readLength()
+		if err != nil {
+			return nil, err
+		}
+		var list = make([]interface{}, 0, minLength(length))
+		for i := 0; i < length; i++ {
+			var value, err = decoder.readValue(elementType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case TagCompound:
+		return decoder.readCompound(depth)
+	case TagIntArray:
+		var length, err = decoder.readLength()
+		if err != nil {
+			return nil, err
+		}
+		var array = make([]int32, 0, minLength(length))
+		for i := 0; i < length; i++ {
+			var v, err = e.ReadInt(decoder.r)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, nil
+	case TagLongArray:
+		var length, err = decoder.readLength()
+		if err != nil {
+			return nil, err
+		}
+		var array = make([]int64, 0, minLength(length))
+		for i := 0; i < length; i++ {
+			var v, err = e.ReadLong(decoder.r)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, nil
+	}
+	return nil, UnknownTag
+}
+
+// readLength reads the length of an array or list.
+func (decoder *Decoder) readLength() (int, error) {
+	var length, err = decoder.encoding.ReadInt(decoder.r)
+	if err != nil {
+		return 0, err
+	}
+	if length < 0 {
+		return 0, InvalidLength
+	}
+	return int(length), nil
+}
+
+// readString reads a length prefixed string.
+func (decoder *Decoder) readString() (string, error) {
+	var length, err = decoder.encoding.ReadStringLength(decoder.r)
+	if err != nil {
+		return "", err
+	}
+	var data, readErr = decoder.readBytes(length)
+	return string(data), readErr
+}
+
+// readBytes reads the given amount of bytes.
+// The buffer grows as data is read, so that corrupted lengths can not allocate huge amounts of memory.
+func (decoder *Decoder) readBytes(length int) ([]byte, error) {
+	var buffer = bytes.NewBuffer(make([]byte, 0, minLength(length)))
+	var n, err = io.CopyN(buffer, decoder.r, int64(length))
+	if n == int64(length) {
+		err = nil
+	} else if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return buffer.Bytes(), err
+}
+
+// minLength limits the capacity preallocated for arrays, so that corrupted lengths can not allocate huge amounts of memory.
+func minLength(length int) int {
+	if length > 1024 {
+		return 1024
+	}
+	return length
+}
+
+// unexpected turns io.EOF into io.ErrUnexpectedEOF, as the stream ending halfway through a compound is an error.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,313 @@
+package nbt
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var (
+	NotAStruct   = errors.New("value is not a struct or pointer to a struct")
+	TypeMismatch = errors.New("nbt tag type does not match struct field type")
+)
+
+// MarshalStruct encodes the struct v as unnamed root compound using the given encoding.
+// See ToCompound for how struct fields are converted to tags.
+func MarshalStruct(encoding Encoding, v interface{}) ([]byte, error) {
+	var compound, err = ToCompound(v)
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(encoding, compound)
+}
+
+// UnmarshalStruct decodes the root compound in data using the given encoding into the struct pointed to by v.
+// See FromCompound for how tags are converted to struct fields.
+func UnmarshalStruct(encoding Encoding, data []byte, v interface{}) error {
+	var compound, err = Unmarshal(encoding, data)
+	if err != nil {
+		return err
+	}
+	return FromCompound(compound, v)
+}
+
+// ToCompound converts the struct v, or the struct v points to, to a compound.
+// Exported fields are converted to tags named after the field, or after the name in the `nbt` struct tag of the field.
+// Fields tagged `nbt:"-"` are skipped, and fields tagged with the omitempty option are skipped if they have their zero value.
+//
+// Fields of type bool, uint8 and int8 become TAG_Byte, int16 and uint16 TAG_Short, int, int32 and uint32 TAG_Int,
+// int64 and uint64 TAG_Long. Nested structs become compounds, and slices of any other type than byte, int32 and int64 lists.
+func ToCompound(v interface{}) (Compound, error) {
+	var value = reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, NotAStruct
+	}
+	return structToCompound(value)
+}
+
+// FromCompound sets the fields of the struct pointed to by v to the tags in the compound.
+// Fields are matched to tags in the same way as ToCompound. Tags without matching field are ignored,
+// and fields without matching tag are left unchanged.
+// Returns TypeMismatch if a tag can not be converted to the type of its field.
+func FromCompound(compound Compound, v interface{}) error {
+	var value = reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return NotAStruct
+	}
+	return compoundToStruct(compound, value.Elem())
+}
+
+// fieldName returns the tag name of a struct field, and whether the field should be omitted if empty.
+// Returns an empty name if the field should be skipped.
+func fieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	var tag = field.Tag.Get("nbt")
+	if tag == "-" {
+		return "", false
+	}
+	var parts = strings.Split(tag, ",")
+	var name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	var omitEmpty = false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty
+}
+
+// structToCompound converts a struct value to a compound.
+func structToCompound(value reflect.Value) (Compound, error) {
+	var compound = Compound{}
+	var structType = value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		var name, omitEmpty = fieldName(structType.Field(i))
+		if name == "" {
+			continue
+		}
+		var field = value.Field(i)
+		if omitEmpty && isEmpty(field) {
+			continue
+		}
+		var tag, err = toTag(field)
+		if err != nil {
+			return nil, err
+		}
+		compound[name] = tag
+	}
+	return compound, nil
+}
+
+// toTag converts a value to the tag value it is represented by.
+func toTag(value reflect.Value) (interface{}, error) {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return byte(1), nil
+		}
+		return byte(0), nil
+	case reflect.Uint8:
+		return byte(value.Uint()), nil
+	case reflect.Int8:
+		return byte(value.Int()), nil
+	case reflect.Int16:
+		return int16(value.Int()), nil
+	case reflect.Uint16:
+		return int16(value.Uint()), nil
+	case reflect.Int, reflect.Int32:
+		return int32(value.Int()), nil
+	case reflect.Uint32:
+		return int32(value.Uint()), nil
+	case reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint64:
+		return int64(value.Uint()), nil
+	case reflect.Float32:
+		return float32(value.Float()), nil
+	case reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Struct:
+		return structToCompound(value)
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil, UnsupportedType
+		}
+		if value.Kind() == reflect.Interface {
+			if _, err := tagType(value.Interface()); err == nil {
+				return value.Interface(), nil
+			}
+		}
+		return toTag(value.Elem())
+	case reflect.Map:
+		if compound, ok := value.Interface().(Compound); ok {
+			return compound, nil
+		}
+		if compound, ok := value.Interface().(map[string]interface{}); ok {
+			return Compound(compound), nil
+		}
+	case reflect.Slice, reflect.Array:
+		switch v := value.Interface().(type) {
+		case []byte:
+			return v, nil
+		case []int32:
+			return v, nil
+		case []int64:
+			return v, nil
+		}
+		var list = make([]interface{}, value.Len())
+		for i := range list {
+			var tag, err = toTag(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = tag
+		}
+		return list, nil
+	}
+	return nil, UnsupportedType
+}
+
+// compoundToStruct sets the fields of a struct value to the tags in a compound.
+func compoundToStruct(compound Compound, value reflect.Value) error {
+	var structType = value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		var name, _ = fieldName(structType.Field(i))
+		if name == "" {
+			continue
+		}
+		var tag, ok = compound[name]
+		if !ok {
+			continue
+		}
+		if err := fromTag(tag, value.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fromTag sets a value to the given tag value.
+func fromTag(tag interface{}, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		var b, ok = tag.(byte)
+		if !ok {
+			return TypeMismatch
+		}
+		value.SetBool(b != 0)
+		return nil
+	case reflect.Int8, reflect.Int16, reflect.Int, reflect.Int32, reflect.Int64:
+		var i, ok = integer(tag)
+		if !ok {
+			return TypeMismatch
+		}
+		value.SetInt(i)
+		return nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var i, ok = integer(tag)
+		if !ok {
+			return TypeMismatch
+		}
+		value.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch f := tag.(type) {
+		case float32:
+			value.SetFloat(float64(f))
+		case float64:
+			value.SetFloat(f)
+		default:
+			return TypeMismatch
+		}
+		return nil
+	case reflect.String:
+		var s, ok = tag.(string)
+		if !ok {
+			return TypeMismatch
+		}
+		value.SetString(s)
+		return nil
+	case reflect.Struct:
+		var compound, ok = tag.(Compound)
+		if !ok {
+			return TypeMismatch
+		}
+		return compoundToStruct(compound, value)
+	case reflect.Ptr:
+		var elem = reflect.New(value.Type().Elem())
+		if err := fromTag(tag, elem.Elem()); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	case reflect.Interface, reflect.Map:
+		var tagValue = reflect.ValueOf(tag)
+		if !tagValue.Type().AssignableTo(value.Type()) {
+			if !tagValue.Type().ConvertibleTo(value.Type()) {
+				return TypeMismatch
+			}
+			tagValue = tagValue.Convert(value.Type())
+		}
+		value.Set(tagValue)
+		return nil
+	case reflect.Slice:
+		switch tag.(type) {
+		case []byte, []int32, []int64:
+			var tagValue = reflect.ValueOf(tag)
+			if tagValue.Type() != value.Type() {
+				return TypeMismatch
+			}
+			value.Set(tagValue)
+			return nil
+		}
+		var list, ok = tag.([]interface{})
+		if !ok {
+			return TypeMismatch
+		}
+		var slice = reflect.MakeSlice(value.Type(), len(list), len(list))
+		for i, element := range list {
+			if err := fromTag(element, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	}
+	return TypeMismatch
+}
+
+// integer returns the value of an integer tag as int64.
+func integer(tag interface{}) (int64, bool) {
+	switch i := tag.(type) {
+	case byte:
+		return int64(i), true
+	case int16:
+		return int64(i), true
+	case int32:
+		return int64(i), true
+	case int64:
+		return i, true
+	}
+	return 0, false
+}
+
+// isEmpty checks if a value is the zero value of its type, or an empty slice or map.
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+	return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+}
@@ -0,0 +1,113 @@
+package nbt
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testPosition struct {
+	X, Y, Z int32
+}
+
+type testStruct struct {
+	Bool       bool
+	Uint8      uint8
+	Int8       int8
+	Int16      int16
+	Uint16     uint16
+	Int        int
+	Int32      int32
+	Uint32     uint32
+	Int64      int64
+	Uint64     uint64
+	Float32    float32
+	Float64    float64
+	String     string `nbt:"Name"`
+	Bytes      []byte
+	Ints       []int32
+	Longs      []int64
+	Strings    []string
+	Position   testPosition
+	Positions  []testPosition
+	Pointer    *testPosition
+	Compound   Compound
+	Value      interface{}
+	Skipped    string `nbt:"-"`
+	Omitted    int32  `nbt:",omitempty"`
+	unexported int32
+}
+
+func TestStructRoundTrip(t *testing.T) {
+	var value = testStruct{
+		Bool: true, Uint8: 200, Int8: -100, Int16: -300, Uint16: 60000, Int: -70000, Int32: 70000, Uint32: 4000000000,
+		Int64: -1 << 40, Uint64: 1 << 63, Float32: 0.25, Float64: -2.5, String: "name",
+		Bytes: []byte{1, 2}, Ints: []int32{3, 4}, Longs: []int64{5, 6}, Strings: []string{"a", "b"},
+		Position: testPosition{1, 2, 3}, Positions: []testPosition{{4, 5, 6}}, Pointer: &testPosition{7, 8, 9},
+		Compound: Compound{"a": int16(1)}, Value: "value", Skipped: "skipped", unexported: 1,
+	}
+	for _, test := range encodings {
+		var data, err = MarshalStruct(test.encoding, value)
+		if err != nil {
+			t.Fatalf("%v: marshalling: %v", test.name, err)
+		}
+		var decoded testStruct
+		if err := UnmarshalStruct(test.encoding, data, &decoded); err != nil {
+			t.Fatalf("%v: unmarshalling: %v", test.name, err)
+		}
+		var want = value
+		want.Skipped, want.unexported = "", 0
+		if !reflect.DeepEqual(decoded, want) {
+			t.Errorf("%v: decoded %+v, want %+v", test.name, decoded, want)
+		}
+	}
+}
+
+func TestToCompound(t *testing.T) {
+	var compound, err = ToCompound(&testStruct{String: "name", Pointer: &testPosition{}, Value: int32(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compound.GetString("Name") != "name" {
+		t.Errorf("field tagged Name was converted to %#v", compound["Name"])
+	}
+	if compound.GetInt("Value") != 1 {
+		t.Errorf("interface field was converted to %#v", compound["Value"])
+	}
+	for _, name := range []string{"String", "Skipped", "Omitted", "unexported"} {
+		if compound.Has(name) {
+			t.Errorf("compound has tag %v", name)
+		}
+	}
+	if _, err := ToCompound(testStruct{}); err != UnsupportedType {
+		t.Errorf("converting a struct with nil pointer returned %v, want %v", err, UnsupportedType)
+	}
+
+	compound, err = ToCompound(struct {
+		Omitted int32 `nbt:",omitempty"`
+	}{1})
+	if err != nil || compound.GetInt("Omitted") != 1 {
+		t.Errorf("non-zero omitempty field converted to %v with error %v", compound, err)
+	}
+	if _, err := ToCompound(1); err != NotAStruct {
+		t.Errorf("converting an int returned %v, want %v", err, NotAStruct)
+	}
+}
+
+func TestFromCompound(t *testing.T) {
+	var value = testStruct{Int32: 5, String: "unchanged"}
+	if err := FromCompound(Compound{"Int32": byte(7), "Unknown": "ignored"}, &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Int32 != 7 || value.String != "unchanged" {
+		t.Errorf("struct set to %+v", value)
+	}
+	if err := FromCompound(Compound{"Int32": "string"}, &value); err != TypeMismatch {
+		t.Errorf("setting an int field to a string returned %v, want %v", err, TypeMismatch)
+	}
+	if err := FromCompound(Compound{"Ints": []int64{1}}, &value); err != TypeMismatch {
+		t.Errorf("setting an int array field to a long array returned %v, want %v", err, TypeMismatch)
+	}
+	if err := FromCompound(Compound{}, value); err != NotAStruct {
+		t.Errorf("setting a struct value returned %v, want %v", err, NotAStruct)
+	}
+}
@@ -0,0 +1,180 @@
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"sort"
+)
+
+// Encoder writes NBT compounds to an output stream.
+type Encoder struct {
+	w        io.Writer
+	encoding Encoding
+}
+
+// NewEncoder returns a new encoder writing to w, using the given encoding.
+func NewEncoder(w io.Writer, encoding Encoding) *Encoder {
+	return &Encoder{w, encoding}
+}
+
+// Marshal encodes the compound as unnamed root tag using the given encoding.
+func Marshal(encoding Encoding, compound Compound) ([]byte, error) {
+	var buffer = bytes.NewBuffer(nil)
+	var err = NewEncoder(buffer, encoding).Encode(compound)
+	return buffer.Bytes(), err
+}
+
+// Encode writes the compound as unnamed root tag.
+func (encoder *Encoder) Encode(compound Compound) error {
+	return encoder.EncodeNamed("", compound)
+}
+
+// EncodeNamed writes the compound as root tag with the given name.
+func (encoder *Encoder) EncodeNamed(name string, compound Compound) error {
+	if err := encoder.writeByte(TagCompound); err != nil {
+		return err
+	}
+	if err := encoder.writeString(name); err != nil {
+		return err
+	}
+	return encoder.writeCompound(compound)
+}
+
+// writeCompound writes the tags of a compound followed by TAG_End.
+// Tags are written sorted by name, so that equal compounds always produce equal output.
+func (encoder *Encoder) writeCompound(compound map[string]interface{}) error {
+	var names = make([]string, 0, len(compound))
+	for name := range compound {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var value = compound[name]
+		var tag, err = tagType(value)
+		if err != nil {
+			return err
+		}
+		if err := encoder.writeByte(tag); err != nil {
+			return err
+		}
+		if err := encoder.writeString(name); err != nil {
+			return err
+		}
+		if err := encoder.writeValue(value); err != nil {
+			return err
+		}
+	}
+	return encoder.writeByte(TagEnd)
+}
+
+// writeValue writes the payload of a tag value.
+func (encoder *Encoder) writeValue(value interface{}) error {
+	var e = encoder.encoding
+	switch v := value.(type) {
+	case byte:
+		return encoder.writeByte(v)
+	case int8:
+		return encoder.writeByte(byte(v))
+	case bool:
+		if v {
+			return encoder.writeByte(1)
+		}
+		return encoder.writeByte(0)
+	case int16:
+		return e.WriteShort(encoder.w, v)
+	case int32:
+		return e.WriteInt(encoder.w, v)
+	case int64:
+		return e.WriteLong(encoder.w, v)
+	case float32:
+		return e.WriteFloat(encoder.w, v)
+	case float64:
+		return e.WriteDouble(encoder.w, v)
+	case []byte:
+		if err := e.WriteInt(encoder.w, int32(len(v))); err != nil {
+			return err
+		}
+		var _, err = encoder.w.Write(v)
+		return err
+	case string:
+		return encoder.writeString(v)
+	case []Compound:
+		var list = make([]interface{}, len(v))
+		for i, compound := range v {
+			list[i] = compound
+		}
+		return encoder.writeList(list)
+	case []interface{}:
+		return encoder.writeList(v)
+	case Compound:
+		return encoder.writeCompound(v)
+	case map[string]interface{}:
+		return encoder.writeCompound(v)
+	case []int32:
+		if err := e.WriteInt(encoder.w, int32(len(v))); err != nil {
+			return err
+		}
+		for _, i := range v {
+			if err := e.WriteInt(encoder.w, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []int64:
+		if err := e.WriteInt(encoder.w, int32(len(v))); err != nil {
+			return err
+		}
+		for _, i := range v {
+			if err := e.WriteLong(encoder.w, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return UnsupportedType
+}
+
+// writeList writes a list tag. All values of the list must have the same tag type.
+// Empty lists are written with TAG_End as element type.
+func (encoder *Encoder) writeList(list []interface{}) error {
+	var elementType = TagEnd
+	if len(list) > 0 {
+		var err error
+		if elementType, err = tagType(list[0]); err != nil {
+			return err
+		}
+	}
+	for _, value := range list {
+		if tag, err := tagType(value); err != nil || tag != elementType {
+			return MixedList
+		}
+	}
+	if err := encoder.writeByte(elementType); err != nil {
+		return err
+	}
+	if err := encoder.encoding.WriteInt(encoder.w, int32(len(list))); err != nil {
+		return err
+	}
+	for _, value := range list {
+		if err := encoder.writeValue(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeByte writes a single byte.
+func (encoder *Encoder) writeByte(b byte) error {
+	var _, err = encoder.w.Write([]byte{b})
+	return err
+}
+
+// writeString writes a length prefixed string.
+func (encoder *Encoder) writeString(s string) error {
+	if err := encoder.encoding.WriteStringLength(encoder.w, len(s)); err != nil {
+		return err
+	}
+	var _, err = io.WriteString(encoder.w, s)
+	return err
+}
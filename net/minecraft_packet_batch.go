@@ -22,6 +22,8 @@ type MinecraftPacketBatch struct {
 
 	packets []interfaces.IPacket
 
+	compressed []byte
+
 	session         interfaces.IMinecraftSession
 	needsEncryption bool
 	logger          *utils.Logger
@@ -48,6 +50,23 @@ func NewMinecraftPacketBatch(session interfaces.IMinecraftSession, logger *utils
 	return batch
 }
 
+// NewCompressedMinecraftPacketBatch returns a new Minecraft Packet Batch holding packets that were already compressed with CompressPackets.
+// The compressed data is only encrypted when encoding, so that the same data can be sent to many sessions.
+func NewCompressedMinecraftPacketBatch(session interfaces.IMinecraftSession, logger *utils.Logger, compressed []byte) *MinecraftPacketBatch {
+	var batch = NewMinecraftPacketBatch(session, logger)
+	batch.compressed = compressed
+	return batch
+}
+
+// CompressPackets encodes the packets and zlib compresses them, the way they are written in a batch.
+func CompressPackets(packets ...interfaces.IPacket) []byte {
+	var batch = &MinecraftPacketBatch{packets: packets}
+	var stream = binutils.NewStream()
+	batch.putPackets(stream)
+
+	return batch.compress(stream)
+}
+
 // Decode decodes the batch and separates packets. This does not decode the packets.
 func (batch *MinecraftPacketBatch) Decode() {
 	defer func() {
@@ -88,11 +107,15 @@ func (batch *MinecraftPacketBatch) Encode() {
 	batch.ResetStream()
 	batch.PutByte(McpeFlag)
 
-	var stream = binutils.NewStream()
-	batch.putPackets(stream)
-
-	var zlibData = batch.compress(stream)
-	var data = zlibData
+	var data []byte
+	if batch.compressed != nil {
+		// Encryption modifies the data in place, so the shared compressed data gets copied.
+		data = append([]byte(nil), batch.compressed...)
+	} else {
+		var stream = binutils.NewStream()
+		batch.putPackets(stream)
+		data = batch.compress(stream)
+	}
 	if batch.needsEncryption {
 		data = batch.encrypt(data)
 	}
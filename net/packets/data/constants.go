@@ -48,3 +48,13 @@ const (
 	ListTypeAdd    = iota
 	ListTypeRemove 
 )
+
+const (
+	UpdateBlockNone        = 0
+	UpdateBlockNeighbors   = 1
+	UpdateBlockNetwork     = 2
+	UpdateBlockNoGraphic   = 4
+	UpdateBlockPriority    = 8
+	UpdateBlockAll         = UpdateBlockNeighbors | UpdateBlockNetwork
+	UpdateBlockAllPriority = UpdateBlockAll | UpdateBlockPriority
+)
@@ -0,0 +1,38 @@
+package p200
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type BlockEntityDataPacket struct {
+	*packets.Packet
+	X, Z     int32
+	Y        uint32
+	Compound nbt.Compound
+}
+
+func NewBlockEntityDataPacket() *BlockEntityDataPacket {
+	return &BlockEntityDataPacket{packets.NewPacket(info.PacketIds200[info.BlockEntityDataPacket]), 0, 0, 0, nil}
+}
+
+func (pk *BlockEntityDataPacket) Encode() {
+	pk.PutBlockPos(r3.Vector{X: float64(pk.X), Y: float64(pk.Y), Z: float64(pk.Z)})
+	if err := pk.PutNBT(pk.Compound); err != nil {
+		// A compound that can not be encoded is sent as an empty compound, so that the packet itself stays valid.
+		pk.PutNBT(nbt.NewCompound())
+	}
+}
+
+func (pk *BlockEntityDataPacket) Decode() {
+	pk.X = pk.GetVarInt()
+	pk.Y = pk.GetUnsignedVarInt()
+	pk.Z = pk.GetVarInt()
+	var compound, err = pk.GetNBT()
+	if err != nil {
+		compound = nil
+	}
+	pk.Compound = compound
+}
@@ -0,0 +1,39 @@
+package p200
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type ExplodePacket struct {
+	*packets.Packet
+	Position r3.Vector
+	Radius   float32
+	Records  []r3.Vector
+}
+
+func NewExplodePacket() *ExplodePacket {
+	return &ExplodePacket{packets.NewPacket(info.PacketIds200[info.ExplodePacket]), r3.Vector{}, 0, []r3.Vector{}}
+}
+
+func (pk *ExplodePacket) Encode() {
+	pk.PutVector(pk.Position)
+	pk.PutVarInt(int32(pk.Radius * 32))
+	pk.PutUnsignedVarInt(uint32(len(pk.Records)))
+	for _, record := range pk.Records {
+		pk.PutVarInt(int32(record.X))
+		pk.PutVarInt(int32(record.Y))
+		pk.PutVarInt(int32(record.Z))
+	}
+}
+
+func (pk *ExplodePacket) Decode() {
+	pk.Position = pk.GetVector()
+	pk.Radius = float32(pk.GetVarInt()) / 32
+	var count = pk.GetUnsignedVarInt()
+	pk.Records = []r3.Vector{}
+	for i := uint32(0); i < count; i++ {
+		pk.Records = append(pk.Records, r3.Vector{X: float64(pk.GetVarInt()), Y: float64(pk.GetVarInt()), Z: float64(pk.GetVarInt())})
+	}
+}
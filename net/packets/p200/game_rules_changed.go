@@ -0,0 +1,24 @@
+package p200
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type GameRulesChangedPacket struct {
+	*packets.Packet
+	GameRules map[string]types.GameRuleEntry
+}
+
+func NewGameRulesChangedPacket() *GameRulesChangedPacket {
+	return &GameRulesChangedPacket{packets.NewPacket(info.PacketIds200[info.GameRulesChangedPacket]), make(map[string]types.GameRuleEntry)}
+}
+
+func (pk *GameRulesChangedPacket) Encode() {
+	pk.PutGameRules(pk.GameRules)
+}
+
+func (pk *GameRulesChangedPacket) Decode() {
+	pk.GameRules = pk.GetGameRules()
+}
@@ -0,0 +1,41 @@
+package p200
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/entities/math"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type MoveEntityPacket struct {
+	*packets.Packet
+	RuntimeId  uint64
+	Position   r3.Vector
+	Rotation   math.Rotation
+	OnGround   bool
+	Teleported bool
+}
+
+func NewMoveEntityPacket() *MoveEntityPacket {
+	return &MoveEntityPacket{Packet: packets.NewPacket(info.PacketIds200[info.MoveEntityPacket]), Position: r3.Vector{}, Rotation: *math.NewRotation(0, 0, 0)}
+}
+
+func (pk *MoveEntityPacket) Encode() {
+	pk.PutRuntimeId(pk.RuntimeId)
+	pk.PutVector(pk.Position)
+	pk.PutByte(byte(pk.Rotation.Pitch / 360 * 256))
+	pk.PutByte(byte(pk.Rotation.HeadYaw / 360 * 256))
+	pk.PutByte(byte(pk.Rotation.Yaw / 360 * 256))
+	pk.PutBool(pk.OnGround)
+	pk.PutBool(pk.Teleported)
+}
+
+func (pk *MoveEntityPacket) Decode() {
+	pk.RuntimeId = pk.GetRuntimeId()
+	pk.Position = pk.GetVector()
+	pk.Rotation.Pitch = float32(pk.GetByte()) / 256 * 360
+	pk.Rotation.HeadYaw = float32(pk.GetByte()) / 256 * 360
+	pk.Rotation.Yaw = float32(pk.GetByte()) / 256 * 360
+	pk.OnGround = pk.GetBool()
+	pk.Teleported = pk.GetBool()
+}
@@ -0,0 +1,27 @@
+package p200
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SetEntityMotionPacket struct {
+	*packets.Packet
+	RuntimeId uint64
+	Motion    r3.Vector
+}
+
+func NewSetEntityMotionPacket() *SetEntityMotionPacket {
+	return &SetEntityMotionPacket{packets.NewPacket(info.PacketIds200[info.SetEntityMotionPacket]), 0, r3.Vector{}}
+}
+
+func (pk *SetEntityMotionPacket) Encode() {
+	pk.PutRuntimeId(pk.RuntimeId)
+	pk.PutVector(pk.Motion)
+}
+
+func (pk *SetEntityMotionPacket) Decode() {
+	pk.RuntimeId = pk.GetRuntimeId()
+	pk.Motion = pk.GetVector()
+}
@@ -29,6 +29,6 @@ func (pk *UpdateBlockPacket) Decode() {
 	pk.Z = pk.GetVarInt()
 	pk.BlockId = pk.GetUnsignedVarInt()
 	v := pk.GetUnsignedVarInt()
-	pk.BlockMetadata = v & 240
+	pk.BlockMetadata = v & 15
 	pk.Flags = v >> 4
 }
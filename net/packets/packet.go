@@ -1,8 +1,11 @@
 package packets
 
 import (
+	"bytes"
+
 	"github.com/irmine/gomine/entities/data"
 	"github.com/irmine/gomine/entities/math"
+	"github.com/irmine/gomine/nbt"
 	"github.com/irmine/gomine/net/packets/types"
 	"github.com/irmine/gomine/utils"
 	"github.com/irmine/binutils"
@@ -231,6 +234,24 @@ func (pk *Packet) PutGameRules(gameRules map[string]types.GameRuleEntry) {
 	}
 }
 
+func (pk *Packet) GetGameRules() map[string]types.GameRuleEntry {
+	var gameRules = make(map[string]types.GameRuleEntry)
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		var gameRule = types.GameRuleEntry{Name: pk.GetString()}
+		switch pk.GetByte() {
+		case 1:
+			gameRule.Value = pk.GetBool()
+		case 2:
+			gameRule.Value = pk.GetUnsignedVarInt()
+		case 3:
+			gameRule.Value = pk.GetLittleFloat()
+		}
+		gameRules[gameRule.Name] = gameRule
+	}
+	return gameRules
+}
+
 func (pk *Packet) PutBlockPos(vector r3.Vector) {
 	pk.PutVarInt(int32(vector.X))
 	pk.PutUnsignedVarInt(uint32(vector.Y))
@@ -270,3 +291,23 @@ func (pk *Packet) GetUUID() utils.UUID {
 	var parts = [4]int32{unorderedParts[1], unorderedParts[0], unorderedParts[3], unorderedParts[2]}
 	return utils.NewUUID(parts)
 }
+
+// PutNBT writes the compound as network little endian NBT.
+// Nothing is written if the compound can not be encoded, and the error is returned.
+func (pk *Packet) PutNBT(compound nbt.Compound) error {
+	var data, err = nbt.Marshal(nbt.NetworkLittleEndian, compound)
+	if err != nil {
+		return err
+	}
+	pk.PutBytes(data)
+	return nil
+}
+
+// GetNBT reads a network little endian NBT compound.
+// Returns an error if the packet does not contain a valid compound at the current offset.
+func (pk *Packet) GetNBT() (nbt.Compound, error) {
+	var reader = bytes.NewReader(pk.Buffer[pk.Offset:])
+	var compound, err = nbt.NewDecoder(reader, nbt.NetworkLittleEndian).Decode()
+	pk.Offset = len(pk.Buffer) - reader.Len()
+	return compound, err
+}
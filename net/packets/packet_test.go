@@ -0,0 +1,49 @@
+package packets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/irmine/gomine/nbt"
+)
+
+func TestNBTRoundTrip(t *testing.T) {
+	var compound = nbt.Compound{
+		"id":    "Chest",
+		"x":     int32(-100000),
+		"y":     int32(64),
+		"Items": []interface{}{nbt.Compound{"Count": byte(64), "Damage": int16(3), "id": int16(1)}},
+		"Seed":  int64(-1234567890123),
+	}
+	var pk = NewPacket(0)
+	if err := pk.PutNBT(compound); err != nil {
+		t.Fatal(err)
+	}
+	pk.PutVarInt(42)
+
+	var decoded, err = pk.GetNBT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, compound) {
+		t.Errorf("decoded %v, want %v", decoded, compound)
+	}
+	if v := pk.GetVarInt(); v != 42 {
+		t.Errorf("value after the compound read as %v, want 42", v)
+	}
+}
+
+func TestNBTErrors(t *testing.T) {
+	var pk = NewPacket(0)
+	if err := pk.PutNBT(nbt.Compound{"a": []interface{}{int32(1), "b"}}); err != nbt.MixedList {
+		t.Errorf("writing a mixed list returned %v, want %v", err, nbt.MixedList)
+	}
+	if len(pk.Buffer) != 0 {
+		t.Errorf("%v bytes were written for a compound that can not be encoded", len(pk.Buffer))
+	}
+
+	pk.PutBytes([]byte{nbt.TagCompound, 0, nbt.TagString, 1, 'a', 10})
+	if _, err := pk.GetNBT(); err == nil {
+		t.Error("reading a truncated compound did not return an error")
+	}
+}
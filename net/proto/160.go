@@ -7,7 +7,6 @@ import (
 	"github.com/irmine/gomine/net/packets/types"
 	"github.com/irmine/gomine/permissions"
 	p160handler "github.com/irmine/gomine/players/handlers/p160"
-	"github.com/golang/geo/r3"
 )
 
 type Protocol160 struct {
@@ -38,28 +37,33 @@ func (protocol *Protocol160) GetAddPlayer(player interfaces.IPlayer) interfaces.
 
 func (protocol *Protocol160) GetStartGame(player interfaces.IPlayer) interfaces.IPacket {
 	var pk = p160.NewStartGamePacket()
-	pk.Generator = 1
-	pk.LevelSeed = 312402
+	var level = player.GetLevel()
+	pk.Generator = getGeneratorType(level)
+	pk.Dimension = int32(player.GetDimension().GetDimensionId())
+	pk.LevelSeed = int32(level.GetSeed())
+	pk.Difficulty = int32(level.GetDifficulty())
 	pk.TrustPlayers = true
 	pk.DefaultPermissionLevel = permissions.LevelMember
 	pk.EntityRuntimeId = player.GetRuntimeId()
 	pk.EntityUniqueId = player.GetUniqueId()
-	pk.PlayerGameMode = 1
-	pk.PlayerPosition = r3.Vector{0, 40, 0}
-	pk.LevelGameMode = 1
-	pk.LevelSpawnPosition = r3.Vector{0, 40, 0}
+	pk.PlayerGameMode = int32(level.GetDefaultGameMode())
+	pk.PlayerPosition = player.GetPosition()
+	pk.LevelGameMode = int32(level.GetDefaultGameMode())
+	pk.LevelSpawnPosition = level.GetSpawnPosition()
 	pk.CommandsEnabled = true
 
-	var gameRules = player.GetServer().GetDefaultLevel().GetGameRules()
+	var gameRules = level.GetGameRules()
 	var gameRuleEntries = map[string]types.GameRuleEntry{}
 	for name, gameRule := range gameRules {
 		gameRuleEntries[name] = types.GameRuleEntry{Name: gameRule.GetName(), Value: gameRule.GetValue()}
 	}
 
 	pk.GameRules = gameRuleEntries
-	pk.LevelName = player.GetServer().GetDefaultLevel().GetName()
+	pk.LevelName = level.GetName()
 	pk.CurrentTick = player.GetServer().GetCurrentTick()
-	pk.Time = 0
+	pk.Time = int32(level.GetTime())
+	pk.RainLevel = level.GetRainLevel()
+	pk.LightningLevel = level.GetLightningLevel()
 	pk.AchievementsDisabled = true
 	pk.BroadcastToXbox = true
 	pk.BroadcastToLan = true
@@ -32,6 +32,7 @@ func NewProtocol200() *Protocol200 {
 		ids[info.ResourcePackChunkRequestPacket]:   func() interfaces.IPacket { return p200.NewResourcePackChunkRequestPacket() },
 		ids[info.TextPacket]:                       func() interfaces.IPacket { return p200.NewTextPacket() },
 		ids[info.PlayerListPacket]:                 func() interfaces.IPacket { return p200.NewPlayerListPacket() },
+		ids[info.BlockEntityDataPacket]:            func() interfaces.IPacket { return p200.NewBlockEntityDataPacket() },
 	}, map[int][][]interfaces.IPacketHandler{})}
 	proto.initHandlers()
 
@@ -47,6 +48,7 @@ func (protocol *Protocol200) initHandlers() {
 	protocol.RegisterHandler(info.CommandRequestPacket, p200handlers.NewCommandRequestHandler(), 8)
 	protocol.RegisterHandler(info.ResourcePackChunkRequestPacket, p200handlers.NewResourcePackChunkRequestHandler(), 8)
 	protocol.RegisterHandler(info.TextPacket, p200handlers.NewTextHandler(), 8)
+	protocol.RegisterHandler(info.BlockEntityDataPacket, p200handlers.NewBlockEntityDataHandler(), 8)
 }
 
 func (protocol *Protocol200) GetAddEntity(entity interfaces.IEntity) interfaces.IPacket {
@@ -77,6 +79,25 @@ func (protocol *Protocol200) GetAddPlayer(player interfaces.IPlayer) interfaces.
 	return pk
 }
 
+func (protocol *Protocol200) GetBlockEntityData(tile interfaces.ITile) interfaces.IPacket {
+	var pk = p200.NewBlockEntityDataPacket()
+	pk.X = int32(tile.GetX())
+	pk.Y = uint32(tile.GetY())
+	pk.Z = int32(tile.GetZ())
+	pk.Compound = tile.GetSpawnCompound()
+
+	return pk
+}
+
+func (protocol *Protocol200) GetChangeDimension(dimension int32, position r3.Vector, respawn bool) interfaces.IPacket {
+	var pk = p200.NewChangeDimensionPacket()
+	pk.Dimension = dimension
+	pk.Position = position
+	pk.Respawn = respawn
+
+	return pk
+}
+
 func (protocol *Protocol200) GetChunkRadiusUpdated(radius int32) interfaces.IPacket {
 	var pk = p200.NewChunkRadiusUpdatedPacket()
 	pk.Radius = radius
@@ -107,6 +128,44 @@ func (protocol *Protocol200) GetFullChunkData(chunk interfaces.IChunk) interface
 	return pk
 }
 
+func (protocol *Protocol200) GetExplode(position r3.Vector, radius float32, records []r3.Vector) interfaces.IPacket {
+	var pk = p200.NewExplodePacket()
+	pk.Position = position
+	pk.Radius = radius
+	pk.Records = records
+
+	return pk
+}
+
+func (protocol *Protocol200) GetGameRulesChanged(gameRules map[string]interfaces.IGameRule) interfaces.IPacket {
+	var pk = p200.NewGameRulesChangedPacket()
+	for name, gameRule := range gameRules {
+		pk.GameRules[name] = types.GameRuleEntry{Name: gameRule.GetName(), Value: gameRule.GetValue()}
+	}
+
+	return pk
+}
+
+func (protocol *Protocol200) GetLevelEvent(eventId int32, position r3.Vector, data int32) interfaces.IPacket {
+	var pk = p200.NewLevelEventPacket()
+	pk.EventId = eventId
+	pk.Position = position
+	pk.Data = data
+
+	return pk
+}
+
+func (protocol *Protocol200) GetMoveEntity(runtimeId uint64, position r3.Vector, rotation math.Rotation, onGround bool, teleported bool) interfaces.IPacket {
+	var pk = p200.NewMoveEntityPacket()
+	pk.RuntimeId = runtimeId
+	pk.Position = position
+	pk.Rotation = rotation
+	pk.OnGround = onGround
+	pk.Teleported = teleported
+
+	return pk
+}
+
 func (protocol *Protocol200) GetMovePlayer(runtimeId uint64, position r3.Vector, rotation math.Rotation, mode byte, onGround bool, ridingRuntimeId uint64) interfaces.IPacket {
 	var pk = p200.NewMovePlayerPacket()
 	pk.RuntimeId = runtimeId
@@ -244,30 +303,50 @@ func (protocol *Protocol200) GetSetEntityData(entity interfaces.IEntity, data ma
 	return pk
 }
 
+func (protocol *Protocol200) GetSetEntityMotion(runtimeId uint64, motion r3.Vector) interfaces.IPacket {
+	var pk = p200.NewSetEntityMotionPacket()
+	pk.RuntimeId = runtimeId
+	pk.Motion = motion
+
+	return pk
+}
+
+func (protocol *Protocol200) GetSetTime(time int32) interfaces.IPacket {
+	var pk = p200.NewSetTimePacket()
+	pk.Time = time
+
+	return pk
+}
+
 func (protocol *Protocol200) GetStartGame(player interfaces.IPlayer) interfaces.IPacket {
 	var pk = p200.NewStartGamePacket()
-	pk.Generator = 1
-	pk.LevelSeed = 312402
+	var level = player.GetLevel()
+	pk.Generator = getGeneratorType(level)
+	pk.Dimension = int32(player.GetDimension().GetDimensionId())
+	pk.LevelSeed = int32(level.GetSeed())
+	pk.Difficulty = int32(level.GetDifficulty())
 	pk.TrustPlayers = true
 	pk.DefaultPermissionLevel = permissions.LevelMember
 	pk.EntityRuntimeId = player.GetRuntimeId()
 	pk.EntityUniqueId = player.GetUniqueId()
-	pk.PlayerGameMode = 1
-	pk.PlayerPosition = r3.Vector{0, 40, 0}
-	pk.LevelGameMode = 1
-	pk.LevelSpawnPosition = r3.Vector{0, 40, 0}
+	pk.PlayerGameMode = int32(level.GetDefaultGameMode())
+	pk.PlayerPosition = player.GetPosition()
+	pk.LevelGameMode = int32(level.GetDefaultGameMode())
+	pk.LevelSpawnPosition = level.GetSpawnPosition()
 	pk.CommandsEnabled = true
 
-	var gameRules = player.GetServer().GetDefaultLevel().GetGameRules()
+	var gameRules = level.GetGameRules()
 	var gameRuleEntries = map[string]types.GameRuleEntry{}
 	for name, gameRule := range gameRules {
 		gameRuleEntries[name] = types.GameRuleEntry{Name: gameRule.GetName(), Value: gameRule.GetValue()}
 	}
 
 	pk.GameRules = gameRuleEntries
-	pk.LevelName = player.GetServer().GetDefaultLevel().GetName()
+	pk.LevelName = level.GetName()
 	pk.CurrentTick = player.GetServer().GetCurrentTick()
-	pk.Time = 0
+	pk.Time = int32(level.GetTime())
+	pk.RainLevel = level.GetRainLevel()
+	pk.LightningLevel = level.GetLightningLevel()
 	pk.AchievementsDisabled = true
 	pk.BroadcastToXbox = true
 	pk.BroadcastToLan = true
@@ -297,6 +376,18 @@ func (protocol *Protocol200) GetTransfer(address string, port uint16) interfaces
 	return pk
 }
 
+func (protocol *Protocol200) GetUpdateBlock(position r3.Vector, blockId, blockMetadata, flags uint32) interfaces.IPacket {
+	var pk = p200.NewUpdateBlockPacket()
+	pk.X = int32(position.X)
+	pk.Y = uint32(position.Y)
+	pk.Z = int32(position.Z)
+	pk.BlockId = blockId
+	pk.BlockMetadata = blockMetadata
+	pk.Flags = flags
+
+	return pk
+}
+
 func (protocol *Protocol200) GetUpdateAttributes(entity interfaces.IEntity, attributeMap *data2.AttributeMap) interfaces.IPacket {
 	var pk = p200.NewUpdateAttributesPacket()
 	pk.RuntimeId = entity.GetRuntimeId()
@@ -304,3 +395,12 @@ func (protocol *Protocol200) GetUpdateAttributes(entity interfaces.IEntity, attr
 
 	return pk
 }
+
+// getGeneratorType returns the generator type sent to the client for the generator of the level.
+// The client only distinguishes between flat and infinite worlds.
+func getGeneratorType(level interfaces.ILevel) int32 {
+	if level.GetGeneratorName() == "Flat" {
+		return 2
+	}
+	return 1
+}
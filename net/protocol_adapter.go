@@ -17,6 +17,14 @@ func (session *MinecraftSession) SendAddPlayer(player interfaces.IPlayer) {
 	session.SendPacket(session.protocol.GetAddPlayer(player))
 }
 
+func (session *MinecraftSession) SendBlockEntityData(tile interfaces.ITile) {
+	session.SendPacket(session.protocol.GetBlockEntityData(tile))
+}
+
+func (session *MinecraftSession) SendChangeDimension(dimension int32, position r3.Vector, respawn bool) {
+	session.SendPacket(session.protocol.GetChangeDimension(dimension, position, respawn))
+}
+
 func (session *MinecraftSession) SendChunkRadiusUpdated(radius int32) {
 	session.SendPacket(session.protocol.GetChunkRadiusUpdated(radius))
 }
@@ -29,8 +37,30 @@ func (session *MinecraftSession) SendDisconnect(message string, hideDisconnect b
 	session.SendPacket(session.protocol.GetDisconnect(message, hideDisconnect))
 }
 
+func (session *MinecraftSession) SendExplode(position r3.Vector, radius float32, records []r3.Vector) {
+	session.SendPacket(session.protocol.GetExplode(position, radius, records))
+}
+
 func (session *MinecraftSession) SendFullChunkData(chunk interfaces.IChunk) {
-	session.SendPacket(session.protocol.GetFullChunkData(chunk))
+	if session.session == nil {
+		return
+	}
+	var data = chunk.GetCompressedBatch(session.protocol.GetProtocolNumber(), func() []byte {
+		return CompressPackets(session.protocol.GetFullChunkData(chunk))
+	})
+	session.SendBatch(NewCompressedMinecraftPacketBatch(session, session.server.GetLogger(), data))
+}
+
+func (session *MinecraftSession) SendGameRulesChanged(gameRules map[string]interfaces.IGameRule) {
+	session.SendPacket(session.protocol.GetGameRulesChanged(gameRules))
+}
+
+func (session *MinecraftSession) SendLevelEvent(eventId int32, position r3.Vector, data int32) {
+	session.SendPacket(session.protocol.GetLevelEvent(eventId, position, data))
+}
+
+func (session *MinecraftSession) SendMoveEntity(entity interfaces.IEntity, position r3.Vector, rotation math.Rotation, onGround bool, teleported bool) {
+	session.SendPacket(session.protocol.GetMoveEntity(entity.GetRuntimeId(), position, rotation, onGround, teleported))
 }
 
 func (session *MinecraftSession) SendMovePlayer(player interfaces.IPlayer, position r3.Vector, rotation math.Rotation, mode byte, onGround bool, ridingRuntimeId uint64) {
@@ -73,6 +103,14 @@ func (session *MinecraftSession) SendSetEntityData(entity interfaces.IEntity, da
 	session.SendPacket(session.protocol.GetSetEntityData(entity, data))
 }
 
+func (session *MinecraftSession) SendSetEntityMotion(entity interfaces.IEntity, motion r3.Vector) {
+	session.SendPacket(session.protocol.GetSetEntityMotion(entity.GetRuntimeId(), motion))
+}
+
+func (session *MinecraftSession) SendSetTime(time int32) {
+	session.SendPacket(session.protocol.GetSetTime(time))
+}
+
 func (session *MinecraftSession) SendStartGame(player interfaces.IPlayer) {
 	session.SendPacket(session.protocol.GetStartGame(player))
 }
@@ -88,3 +126,7 @@ func (session *MinecraftSession) Transfer(address string, port uint16) {
 func (session *MinecraftSession) SendUpdateAttributes(entity interfaces.IEntity, attributes *data.AttributeMap) {
 	session.SendPacket(session.protocol.GetUpdateAttributes(entity, attributes))
 }
+
+func (session *MinecraftSession) SendUpdateBlock(position r3.Vector, blockId, blockMetadata, flags uint32) {
+	session.SendPacket(session.protocol.GetUpdateBlock(position, blockId, blockMetadata, flags))
+}
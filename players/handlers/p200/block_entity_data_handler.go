@@ -0,0 +1,50 @@
+package p200
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/net/packets/p200"
+	"github.com/irmine/gomine/players/handlers"
+	"github.com/irmine/gomine/tiles"
+	"github.com/irmine/goraklib/server"
+)
+
+// MaximumSignTextLength is the maximum length of the text players can write on a sign.
+const MaximumSignTextLength = 256
+
+type BlockEntityDataHandler struct {
+	*handlers.PacketHandler
+}
+
+func NewBlockEntityDataHandler() BlockEntityDataHandler {
+	return BlockEntityDataHandler{handlers.NewPacketHandler()}
+}
+
+// Handle handles players editing the text of signs.
+func (handler BlockEntityDataHandler) Handle(packet interfaces.IPacket, player interfaces.IPlayer, session *server.Session, server interfaces.IServer) bool {
+	if pk, ok := packet.(*p200.BlockEntityDataPacket); ok {
+		if !player.HasSpawned() || pk.Compound == nil {
+			return false
+		}
+
+		var dimension = player.GetDimension()
+		var position = r3.Vector{X: float64(pk.X), Y: float64(pk.Y), Z: float64(pk.Z)}
+		if position.Sub(player.GetPosition()).Norm() > 8 {
+			return false
+		}
+
+		var sign, isSign = dimension.GetTile(position).(*tiles.Sign)
+		if !isSign || pk.Compound.GetString("id") != tiles.SignId {
+			return false
+		}
+		var text = pk.Compound.GetString("Text")
+		if len(text) > MaximumSignTextLength {
+			text = text[:MaximumSignTextLength]
+		}
+		sign.Text = text
+		dimension.UpdateTile(sign)
+
+		return true
+	}
+	return false
+}
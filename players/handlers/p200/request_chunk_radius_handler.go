@@ -1,6 +1,8 @@
 package p200
 
 import (
+	"math"
+
 	"github.com/irmine/gomine/interfaces"
 	"github.com/irmine/gomine/net/packets/data"
 	"github.com/irmine/gomine/net/packets/p200"
@@ -25,43 +27,56 @@ func (handler RequestChunkRadiusHandler) Handle(packet interfaces.IPacket, playe
 
 		player.SendChunkRadiusUpdated(player.GetViewDistance())
 
-		var hasChunksInUse = player.HasAnyChunkInUse()
-
-		server.GetDefaultLevel().GetDefaultDimension().RequestChunks(player, 10)
-
-		if !hasChunksInUse {
-			player.SetSpawned(true)
+		var dimension = player.GetDimension()
+		dimension.RequestChunks(player, 10)
 
-			var players = server.GetPlayerFactory().GetPlayers()
-			for name, pl := range players {
-				if !pl.HasSpawned() {
-					delete(players, name)
+		if !player.HasSpawned() {
+			// Callbacks run in order, so the chunk the player is in has been sent once this callback runs.
+			var chunkX, chunkZ = int32(math.Floor(player.GetPosition().X)) >> 4, int32(math.Floor(player.GetPosition().Z)) >> 4
+			dimension.RequestChunkAsync(chunkX, chunkZ, func(chunk interfaces.IChunk) {
+				if player.HasSpawned() || player.GetDimension() == nil {
+					return
 				}
-			}
-			player.SendPlayerList(data.ListTypeAdd, players)
-
-			for _, receiver := range server.GetPlayerFactory().GetPlayers() {
-				if player != receiver {
-					receiver.SendPlayerList(data.ListTypeAdd, map[string]interfaces.IPlayer{player.GetName(): player})
+				handler.spawn(player, server)
+			})
+		}
 
-					receiver.SpawnTo(player)
-					receiver.SpawnPlayerTo(player)
-				}
-			}
+		return true
+	}
 
-			player.SpawnToAll()
-			player.SpawnPlayerToAll()
+	return false
+}
 
-			player.UpdateAttributes()
-			player.SendSetEntityData(player, player.GetEntityData())
+// spawn spawns the player to all other players and sends the spawn status.
+func (handler RequestChunkRadiusHandler) spawn(player interfaces.IPlayer, server interfaces.IServer) {
+	player.SetSpawned(true)
 
-			server.BroadcastMessage(utils.Yellow + player.GetDisplayName() + " has joined the server")
+	var players = server.GetPlayerFactory().GetPlayers()
+	for name, pl := range players {
+		if !pl.HasSpawned() {
+			delete(players, name)
 		}
+	}
+	player.SendPlayerList(data.ListTypeAdd, players)
 
-		player.SendPlayStatus(data.StatusSpawn)
+	for _, receiver := range server.GetPlayerFactory().GetPlayers() {
+		if player != receiver {
+			receiver.SendPlayerList(data.ListTypeAdd, map[string]interfaces.IPlayer{player.GetName(): player})
 
-		return true
+			receiver.SpawnTo(player)
+			receiver.SpawnPlayerTo(player)
+		}
 	}
 
-	return false
+	player.SpawnToAll()
+	player.SpawnPlayerToAll()
+
+	player.UpdateAttributes()
+	player.SendSetEntityData(player, player.GetEntityData())
+	player.SendSetTime(int32(player.GetLevel().GetTime()))
+	player.GetLevel().SendWeather(player)
+
+	server.BroadcastMessage(utils.Yellow + player.GetDisplayName() + " has joined the server")
+
+	player.SendPlayStatus(data.StatusSpawn)
 }
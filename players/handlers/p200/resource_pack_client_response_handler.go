@@ -41,7 +41,8 @@ func (handler ResourcePackClientResponseHandler) Handle(packet interfaces.IPacke
 			player.SendResourcePackStack(server.GetConfiguration().ForceResourcePacks, server.GetPackManager().GetResourceStack().GetPacks(), server.GetPackManager().GetBehaviorStack().GetPacks())
 
 		case data.StatusCompleted:
-			player.PlaceInWorld(r3.Vector{0, 40, 0}, math.NewRotation(0, 0, 0), server.GetDefaultLevel(), server.GetDefaultLevel().GetDefaultDimension())
+			var level = server.GetDefaultLevel()
+			player.PlaceInWorld(level.GetSpawnPosition().Add(r3.Vector{X: 0.5, Z: 0.5}), math.NewRotation(0, 0, 0), level, level.GetDefaultDimension())
 			player.SetFinalized()
 
 			player.SendStartGame(player)
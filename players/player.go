@@ -80,6 +80,8 @@ func (player *Player) IsInWorld() bool {
 // PlaceInWorld places this player inside of a level and dimension.
 func (player *Player) PlaceInWorld(position r3.Vector, rotation *math.Rotation, level interfaces.ILevel, dimension interfaces.IDimension) {
 	player.Human = entities.NewHuman(player.GetDisplayName(), position, rotation, r3.Vector{0, 0, 0}, level, dimension)
+	// Players are moved by their client, so their motion must not move them on the server.
+	player.SetPhysics(false)
 }
 
 // IsFinalized checks if this player is finalized.
@@ -193,6 +195,66 @@ func (player *Player) Teleport(v r3.Vector, rot *math.Rotation) {
 	player.SendMovePlayer(player, v, *rot, data.MoveTeleport, player.onGround, 0)
 }
 
+// ChangeDimension moves the player to the given position in another dimension, which may be of another level.
+// The player gets despawned from its viewers, and the client gets told to switch dimensions if the dimension ID changes.
+// Chunks of the new dimension are sent afterwards, and the player respawns once the chunk it is in has been sent.
+// If the dimension is the current dimension of the player, the player simply gets teleported.
+func (player *Player) ChangeDimension(dimension interfaces.IDimension, position r3.Vector) {
+	if dimension == player.GetDimension() {
+		player.Teleport(position, player.GetRotation())
+		return
+	}
+	var previous = player.GetDimension()
+
+	for _, viewer := range player.GetViewers() {
+		player.DespawnFrom(viewer)
+	}
+	if chunk := player.GetChunk(); chunk != nil {
+		chunk.RemoveEntity(player)
+	}
+
+	player.mux.Lock()
+	for index, chunk := range player.usedChunks {
+		chunk.RemoveViewer(player)
+		delete(player.usedChunks, index)
+
+		for _, entity := range chunk.GetEntities() {
+			entity.DespawnFrom(player)
+		}
+	}
+	player.mux.Unlock()
+
+	player.SetLevel(dimension.GetLevel())
+	player.SetDimension(dimension)
+	player.Position = position
+
+	if previous.GetDimensionId() != dimension.GetDimensionId() {
+		player.SendChangeDimension(int32(dimension.GetDimensionId()), position, false)
+	}
+	if previous.GetLevel() != dimension.GetLevel() {
+		player.SendSetTime(int32(dimension.GetLevel().GetTime()))
+		dimension.GetLevel().SendWeatherFrom(player, previous.GetLevel().GetWeather())
+	}
+	dimension.RequestChunks(player, player.GetViewDistance())
+
+	// Callbacks run in order, so the chunk the player is in has been sent once this callback runs.
+	var chunkX, chunkZ = int32(math2.Floor(position.X)) >> 4, int32(math2.Floor(position.Z)) >> 4
+	dimension.RequestChunkAsync(chunkX, chunkZ, func(chunk interfaces.IChunk) {
+		if player.IsClosed() || player.GetDimension() != dimension {
+			return
+		}
+		chunk.AddEntity(player)
+		player.SendMovePlayer(player, player.GetPosition(), *player.GetRotation(), data.MoveTeleport, player.onGround, 0)
+		player.SendPlayStatus(data.StatusSpawn)
+
+		for _, viewer := range chunk.GetViewers() {
+			if viewer != interfaces.IPlayer(player) {
+				player.SpawnPlayerTo(viewer)
+			}
+		}
+	})
+}
+
 // SetSkinId sets the skin ID/name of the player.
 func (player *Player) SetSkinId(id string) {
 	player.skinId = id
@@ -269,7 +331,7 @@ func (player *Player) SyncMove(x, y, z float64, pitch, yaw, headYaw float32, onG
 		xDist := chunkX - chunk.GetX()
 		zDist := chunkZ - chunk.GetZ()
 
-		if xDist*xDist+zDist*zDist > rs {
+		if xDist*xDist+zDist*zDist > rs*rs {
 			chunk.RemoveViewer(player)
 			delete(player.usedChunks, index)
 
@@ -281,6 +343,17 @@ func (player *Player) SyncMove(x, y, z float64, pitch, yaw, headYaw float32, onG
 	player.mux.Unlock()
 }
 
+// Close closes the player, removing it as viewer from all chunks it has in use.
+func (player *Player) Close() {
+	player.mux.Lock()
+	for index, chunk := range player.usedChunks {
+		chunk.RemoveViewer(player)
+		delete(player.usedChunks, index)
+	}
+	player.mux.Unlock()
+	player.Human.Close()
+}
+
 // HasChunkInUse checks if the player has a chunk with the given index in use.
 func (player *Player) HasChunkInUse(index int) bool {
 	player.mux.Lock()
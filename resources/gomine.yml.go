@@ -21,6 +21,14 @@ type GoMineConfig struct {
 	DefaultLevel     string `yaml:"Default Level"`
 	DefaultGenerator string `yaml:"Default Generator"`
 
+	Worlds map[string]WorldConfig `yaml:"Worlds"`
+
+	BackupRetention int `yaml:"Backup Retention"`
+
+	ChunkUnloadDelay       int64 `yaml:"Chunk Unload Delay"`
+	MaximumLoadedChunks    int   `yaml:"Maximum Loaded Chunks"`
+	ChunkGenerationWorkers int   `yaml:"Chunk Generation Workers"`
+
 	ForceResourcePacks   bool   `yaml:"Forced Resource Packs"`
 	SelectedResourcePack string `yaml:"Selected Resource Pack"`
 
@@ -31,6 +39,15 @@ type GoMineConfig struct {
 	AllowPluginQuery bool `yaml:"Allow Plugin Query"`
 }
 
+// WorldConfig holds the options of a world in the configuration.
+// The generator, generator options and seed are only used when the world gets generated.
+type WorldConfig struct {
+	Generator        string `yaml:"Generator"`
+	GeneratorOptions string `yaml:"Generator Options"`
+	Seed             int64  `yaml:"Seed"`
+	AutoLoad         bool   `yaml:"Auto Load"`
+}
+
 // NewGoMineConfig returns a new configuration struct.
 // Creates the file if it does not yet exist.
 func NewGoMineConfig(serverPath string) *GoMineConfig {
@@ -58,6 +75,16 @@ func initializeConfig(serverPath string) {
 			DefaultLevel:     "world",
 			DefaultGenerator: "Flat",
 
+			Worlds: map[string]WorldConfig{
+				"world": {Generator: "Flat", AutoLoad: true},
+			},
+
+			BackupRetention: 10,
+
+			ChunkUnloadDelay:       30,
+			MaximumLoadedChunks:    4096,
+			ChunkGenerationWorkers: 4,
+
 			ForceResourcePacks:   false,
 			SelectedResourcePack: "",
 
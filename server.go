@@ -5,7 +5,11 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
+	"io/ioutil"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/irmine/goraklib/server"
 
@@ -22,10 +26,11 @@ import (
 	"github.com/irmine/gomine/resources"
 	"github.com/irmine/gomine/utils"
 	"github.com/irmine/gomine/worlds"
+	"github.com/irmine/gomine/worlds/backups"
+	"github.com/irmine/gomine/worlds/generation"
+	"github.com/irmine/gomine/worlds/providers"
 )
 
-var levelId = 0
-
 const (
 	GoMineName    = "GoMine"
 	GoMineVersion = "0.0.1"
@@ -43,7 +48,12 @@ type Server struct {
 	commandHolder     *commands.Manager
 	packManager       *packs.Manager
 	permissionManager *permissions.Manager
+	levelsMutex       sync.RWMutex
 	levels            map[int]interfaces.ILevel
+	unloadingLevels   map[string]bool
+	nextLevelId       int
+	tasksMutex        sync.Mutex
+	tasks             []func()
 	playerFactory     *players.PlayerFactory
 	networkAdapter    *net.NetworkAdapter
 	pluginManager     *plugins.PluginManager
@@ -58,6 +68,7 @@ func NewServer(serverPath string) *Server {
 	s.config = resources.NewGoMineConfig(serverPath)
 	s.logger = utils.NewLogger(GoMineName, serverPath, s.GetConfiguration().DebugMode)
 	s.levels = make(map[int]interfaces.ILevel)
+	s.unloadingLevels = make(map[string]bool)
 	s.consoleReader = NewConsoleReader(s)
 	s.commandHolder = commands.NewManager()
 	s.networkAdapter = net.NewNetworkAdapter(s)
@@ -96,6 +107,10 @@ func (server *Server) RegisterDefaultCommands() {
 	server.commandHolder.RegisterCommand(defaults.NewList(server))
 	server.commandHolder.RegisterCommand(defaults.NewTest())
 	server.commandHolder.RegisterCommand(defaults.NewPing())
+	server.commandHolder.RegisterCommand(defaults.NewTime(server))
+	server.commandHolder.RegisterCommand(defaults.NewWeather(server))
+	server.commandHolder.RegisterCommand(defaults.NewWorld(server))
+	server.commandHolder.RegisterCommand(defaults.NewBackup(server))
 }
 
 // IsRunning checks if the server is running.
@@ -123,15 +138,25 @@ func (server *Server) Start() {
 }
 
 // Shutdown shuts down the server, saving and disabling everything.
+// The levels get saved and closed on the tick goroutine at the start of the next tick, after which the server
+// stops running. Levels are therefore never closed while they are being ticked, and are saved before the tick loop exits.
 func (server *Server) Shutdown() {
 	if !server.isRunning {
 		return
 	}
 	server.GetLogger().Info("Server is shutting down.")
 
-	server.isRunning = false
+	server.ScheduleTask(func() {
+		if !server.isRunning {
+			return
+		}
+		for _, level := range server.GetLoadedLevels() {
+			level.Close()
+		}
+		server.isRunning = false
 
-	server.GetLogger().Notice("Server stopped.")
+		server.GetLogger().Notice("Server stopped.")
+	})
 }
 
 // GetMinecraftVersion returns the latest Minecraft game version.
@@ -163,16 +188,40 @@ func (server *Server) GetConfiguration() *resources.GoMineConfig {
 }
 
 // GetLoadedLevels returns all loaded levels of the server.
+// The returned map is a copy, so it can be ranged over while levels get loaded and unloaded.
 func (server *Server) GetLoadedLevels() map[int]interfaces.ILevel {
-	return server.levels
+	server.levelsMutex.RLock()
+	defer server.levelsMutex.RUnlock()
+	var levels = make(map[int]interfaces.ILevel, len(server.levels))
+	for id, level := range server.levels {
+		levels[id] = level
+	}
+	return levels
 }
 
+// LoadLevels loads the default level and all worlds in the configuration that are set to load automatically.
 func (server *Server) LoadLevels() {
 	server.LoadLevel(server.config.DefaultLevel)
+	for name, world := range server.config.Worlds {
+		if world.AutoLoad {
+			server.LoadLevel(name)
+		}
+	}
+}
+
+// GetWorldConfig returns the configuration of the world with the given name.
+// Worlds that are not in the configuration use the default generator and a random seed.
+func (server *Server) GetWorldConfig(levelName string) resources.WorldConfig {
+	if world, ok := server.config.Worlds[levelName]; ok {
+		return world
+	}
+	return resources.WorldConfig{Generator: server.config.DefaultGenerator}
 }
 
 // IsLevelLoaded returns whether a level is loaded or not.
 func (server *Server) IsLevelLoaded(levelName string) bool {
+	server.levelsMutex.RLock()
+	defer server.levelsMutex.RUnlock()
 	for _, level := range server.levels {
 		if level.GetName() == levelName {
 			return true
@@ -186,27 +235,225 @@ func (server *Server) IsLevelGenerated(levelName string) bool {
 	if server.IsLevelLoaded(levelName) {
 		return true
 	}
-	var path = server.GetServerPath() + "worlds/" + levelName
-	var _, err = os.Stat(path)
+	var _, err = os.Stat(server.GetLevelPath(levelName) + "db")
 	if err != nil {
 		return false
 	}
 	return true
 }
 
-// LoadLevel loads a generated level. Returns true if the level was loaded successfully.
+// GetLevelPath returns the path of the folder of the level with the given name.
+func (server *Server) GetLevelPath(levelName string) string {
+	return server.GetServerPath() + "worlds/" + levelName + "/"
+}
+
+// LoadLevel loads a level, generating it with its world configuration if it does not exist yet.
+// Returns true if the level was loaded successfully.
 func (server *Server) LoadLevel(levelName string) bool {
-	if !server.IsLevelGenerated(levelName) {
-		// server.GenerateLevel(level) We need file writing for this. TODO.
+	if server.IsLevelLoaded(levelName) || !isValidLevelName(levelName) {
+		return false
 	}
-	if server.IsLevelLoaded(levelName) {
+	if err := server.openLevel(levelName, server.GetWorldConfig(levelName)); err != nil {
+		server.GetLogger().LogError(err)
 		return false
 	}
-	server.levels[levelId] = worlds.NewLevel(levelName, levelId, server, make(map[int]interfaces.IChunk))
-	levelId++
 	return true
 }
 
+// GenerateLevel generates and loads a new level with the given options.
+// Returns an error if the level already exists, or if the generator of the options does not exist.
+func (server *Server) GenerateLevel(levelName string, options resources.WorldConfig) error {
+	if !isValidLevelName(levelName) {
+		return errors.New("invalid level name")
+	}
+	if server.IsLevelGenerated(levelName) {
+		return errors.New("level with given name is already generated")
+	}
+	if options.Generator != "" && !generation.GeneratorNameExists(options.Generator) {
+		return errors.New("generator with given name does not exist")
+	}
+	return server.openLevel(levelName, options)
+}
+
+// UnloadLevel saves and unloads a loaded level.
+// Players in the level get moved to the spawn of the default level. The default level can not be unloaded.
+// The level stops ticking immediately, but gets closed on the tick goroutine at the start of the next tick,
+// so that it is never closed while it is being ticked. It can not be loaded again until it is closed.
+func (server *Server) UnloadLevel(levelName string) error {
+	if levelName == server.config.DefaultLevel {
+		return errors.New("the default level can not be unloaded")
+	}
+	server.levelsMutex.Lock()
+	var level interfaces.ILevel
+	for _, loaded := range server.levels {
+		if loaded.GetName() == levelName {
+			level = loaded
+		}
+	}
+	if level == nil {
+		server.levelsMutex.Unlock()
+		return errors.New("level with given name is not loaded")
+	}
+	delete(server.levels, level.GetRuntimeId())
+	server.unloadingLevels[levelName] = true
+	server.levelsMutex.Unlock()
+
+	server.ScheduleTask(func() {
+		var defaultLevel = server.GetDefaultLevel()
+		for _, player := range level.GetPlayers() {
+			player.ChangeDimension(defaultLevel.GetDefaultDimension(), defaultLevel.GetSpawnPosition())
+		}
+		level.Close()
+
+		server.levelsMutex.Lock()
+		delete(server.unloadingLevels, levelName)
+		server.levelsMutex.Unlock()
+	})
+	return nil
+}
+
+// GetBackupPath returns the path of the folder backups of levels are written to by default.
+func (server *Server) GetBackupPath() string {
+	return server.GetServerPath() + "backups/"
+}
+
+// BackupLevel writes a backup of the level with the given name to a timestamped zip archive in the given folder.
+// The backup folder of the server is used if the folder is empty. Returns an error right away if the level can not be
+// backed up, for example because it is still being unloaded, and writes the backup in the background otherwise.
+// Loaded levels get saved on the tick goroutine first, and are then backed up from a snapshot while they keep running.
+// Backups of the level beyond the configured backup retention get removed afterwards, oldest first.
+// The done function is called with the path of the archive once the backup is written, from the goroutine writing it.
+func (server *Server) BackupLevel(levelName string, folder string, done func(string, error)) error {
+	if !isValidLevelName(levelName) {
+		return errors.New("invalid level name")
+	}
+	if server.isLevelUnloading(levelName) {
+		return errors.New("level is still being unloaded")
+	}
+	if !server.IsLevelGenerated(levelName) {
+		return errors.New("level with given name is not generated")
+	}
+	if folder == "" {
+		folder = server.GetBackupPath()
+	}
+	var archive = folder + backups.GetArchiveName(levelName, time.Now())
+
+	var level, err = server.GetLevelByName(levelName)
+	if err != nil {
+		go func() {
+			done(archive, server.writeBackup(levelName, folder, archive, nil))
+		}()
+		return nil
+	}
+	if level.GetChunkProvider() == nil {
+		return errors.New("level has no chunk provider to back up")
+	}
+	server.ScheduleTask(func() {
+		if loaded, _ := server.GetLevelByName(levelName); loaded != level {
+			go done(archive, errors.New("level was unloaded before it could be backed up"))
+			return
+		}
+		level.Save()
+		go func() {
+			done(archive, server.writeBackup(levelName, folder, archive, level))
+		}()
+	})
+	return nil
+}
+
+// writeBackup writes the backup archive of the level with the given name, and prunes old backups in the folder.
+// Loaded levels are copied from a snapshot of their chunk provider first, and other levels are archived from disk.
+func (server *Server) writeBackup(levelName string, folder string, archive string, level interfaces.ILevel) error {
+	var source = server.GetLevelPath(levelName)
+	if level != nil {
+		var temp, err = ioutil.TempDir("", "gomine-backup")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(temp)
+		source = temp + "/"
+		if err := level.GetChunkProvider().Backup(source); err != nil {
+			return err
+		}
+	}
+
+	if err := backups.ZipFolder(source, archive); err != nil {
+		return err
+	}
+	if err := backups.Prune(folder, levelName, server.config.BackupRetention); err != nil {
+		server.GetLogger().LogError(err)
+	}
+	return nil
+}
+
+// RestoreLevel replaces the level with the given name with the level in a backup archive.
+// The level can not be restored while it is loaded or still being unloaded. The level folder only gets replaced once the archive has been
+// extracted successfully.
+func (server *Server) RestoreLevel(levelName string, archive string) error {
+	if !isValidLevelName(levelName) {
+		return errors.New("invalid level name")
+	}
+	if server.IsLevelLoaded(levelName) {
+		return errors.New("level must be unloaded before restoring it")
+	}
+	if server.isLevelUnloading(levelName) {
+		return errors.New("level is still being unloaded")
+	}
+	var path = strings.TrimSuffix(server.GetLevelPath(levelName), "/")
+	var restored = path + ".restore"
+	os.RemoveAll(restored)
+	if err := backups.Extract(archive, restored); err != nil {
+		os.RemoveAll(restored)
+		return err
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	return os.Rename(restored, path)
+}
+
+// openLevel opens the level with the given name, creating it with the given options if it does not exist yet.
+func (server *Server) openLevel(levelName string, options resources.WorldConfig) error {
+	if server.isLevelUnloading(levelName) {
+		return errors.New("level is still being unloaded")
+	}
+	var generated = server.IsLevelGenerated(levelName)
+	var path = server.GetLevelPath(levelName)
+	var provider, err = providers.NewLevelDBProvider(path)
+	if err != nil {
+		return err
+	}
+	if !generated {
+		ioutil.WriteFile(path+"levelname.txt", []byte(levelName), 0644)
+	}
+	server.levelsMutex.Lock()
+	var id = server.nextLevelId
+	server.nextLevelId++
+	server.levelsMutex.Unlock()
+
+	level, err := worlds.NewLevel(levelName, id, server, provider, options)
+	if err != nil {
+		provider.Close()
+		return err
+	}
+	server.levelsMutex.Lock()
+	server.levels[id] = level
+	server.levelsMutex.Unlock()
+	return nil
+}
+
+// isLevelUnloading checks if the level with the given name was unloaded, but is not closed yet.
+func (server *Server) isLevelUnloading(levelName string) bool {
+	server.levelsMutex.RLock()
+	defer server.levelsMutex.RUnlock()
+	return server.unloadingLevels[levelName]
+}
+
+// isValidLevelName checks if a level name can be used as the name of the folder of the level.
+func isValidLevelName(levelName string) bool {
+	return levelName != "" && levelName != "." && levelName != ".." && !strings.ContainsAny(levelName, "/\\")
+}
+
 // GetDefaultLevel returns the default level and loads/generates it if needed.
 func (server *Server) GetDefaultLevel() interfaces.ILevel {
 	if !server.IsLevelLoaded(server.config.DefaultLevel) {
@@ -218,6 +465,8 @@ func (server *Server) GetDefaultLevel() interfaces.ILevel {
 
 // GetLevelById returns a level by its ID. Returns an error if a level with the ID is not loaded.
 func (server *Server) GetLevelById(id int) (interfaces.ILevel, error) {
+	server.levelsMutex.RLock()
+	defer server.levelsMutex.RUnlock()
 	var level interfaces.ILevel
 	if level, ok := server.levels[id]; ok {
 		return level, nil
@@ -239,7 +488,7 @@ func (server *Server) GetLevelByName(name string) (interfaces.ILevel, error) {
 			return level, nil
 		}
 	}
-	return level, nil
+	return level, errors.New("level with given name is not loaded")
 }
 
 // GetConsoleReader returns the console command reader.
@@ -400,6 +649,27 @@ func (server *Server) HandleRaw(packet server.RawPacket) {
 	}
 }
 
+// ScheduleTask queues the task to be run on the tick goroutine at the start of the next tick.
+// Levels and everything in them are ticked on the tick goroutine, so other goroutines, such as those executing
+// commands, should use this to change them.
+func (server *Server) ScheduleTask(task func()) {
+	server.tasksMutex.Lock()
+	server.tasks = append(server.tasks, task)
+	server.tasksMutex.Unlock()
+}
+
+// runTasks runs all tasks scheduled since the previous tick. Tasks scheduled by these tasks run on the next tick.
+func (server *Server) runTasks() {
+	server.tasksMutex.Lock()
+	var tasks = server.tasks
+	server.tasks = nil
+	server.tasksMutex.Unlock()
+
+	for _, task := range tasks {
+		task()
+	}
+}
+
 // Tick ticks the entire server. (Levels, scheduler, GoRakLib server etc.)
 // Internal. Not to be used by plugins.
 func (server *Server) Tick(currentTick int64) {
@@ -408,11 +678,16 @@ func (server *Server) Tick(currentTick int64) {
 		return
 	}
 
+	server.runTasks()
+	if !server.isRunning {
+		return
+	}
+
 	if currentTick%20 == 0 {
 		server.queryManager.SetQueryResult(server.GenerateQueryResult())
 	}
 
-	for _, level := range server.levels {
+	for _, level := range server.GetLoadedLevels() {
 		level.TickLevel()
 	}
 
@@ -0,0 +1,42 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Banner is the tile of standing and wall banners, holding the base color and patterns of the banner.
+// Patterns are kept as the pattern compounds stored in the NBT of the banner.
+type Banner struct {
+	*Tile
+	Base     int32
+	Patterns []interface{}
+}
+
+func NewBanner(x, y, z int) *Banner {
+	return &Banner{NewTile(BannerId, x, y, z), 0, []interface{}{}}
+}
+
+// AddPattern adds a pattern with the given pattern ID and color to the banner.
+func (banner *Banner) AddPattern(pattern string, color int32) {
+	banner.Patterns = append(banner.Patterns, nbt.Compound{"Pattern": pattern, "Color": color})
+}
+
+// Load loads the banner from the given compound.
+func (banner *Banner) Load(compound nbt.Compound) {
+	banner.Tile.Load(compound)
+	banner.Base = compound.GetInt("Base")
+	if patterns := compound.GetList("Patterns"); patterns != nil {
+		banner.Patterns = patterns
+	}
+}
+
+// Save returns a compound holding the banner.
+func (banner *Banner) Save() nbt.Compound {
+	var compound = banner.Tile.Save()
+	compound["Base"] = banner.Base
+	compound["Patterns"] = banner.Patterns
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the color and patterns of the banner.
+func (banner *Banner) GetSpawnCompound() nbt.Compound {
+	return banner.Save()
+}
@@ -0,0 +1,31 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Bed is the tile of beds, holding the color of the bed.
+type Bed struct {
+	*Tile
+	Color byte
+}
+
+func NewBed(x, y, z int) *Bed {
+	return &Bed{NewTile(BedId, x, y, z), 14}
+}
+
+// Load loads the bed from the given compound.
+func (bed *Bed) Load(compound nbt.Compound) {
+	bed.Tile.Load(compound)
+	bed.Color = compound.GetByte("color")
+}
+
+// Save returns a compound holding the bed.
+func (bed *Bed) Save() nbt.Compound {
+	var compound = bed.Tile.Save()
+	compound["color"] = bed.Color
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the color of the bed.
+func (bed *Bed) GetSpawnCompound() nbt.Compound {
+	return bed.Save()
+}
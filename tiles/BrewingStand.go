@@ -0,0 +1,32 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// BrewingStand is the tile of brewing stands.
+type BrewingStand struct {
+	*Container
+	CookTime   int16
+	FuelAmount int16
+	FuelTotal  int16
+}
+
+func NewBrewingStand(x, y, z int) *BrewingStand {
+	return &BrewingStand{Container: NewContainer(BrewingStandId, x, y, z)}
+}
+
+// Load loads the brewing stand from the given compound.
+func (stand *BrewingStand) Load(compound nbt.Compound) {
+	stand.Container.Load(compound)
+	stand.CookTime = compound.GetShort("CookTime")
+	stand.FuelAmount = compound.GetShort("FuelAmount")
+	stand.FuelTotal = compound.GetShort("FuelTotal")
+}
+
+// Save returns a compound holding the brewing stand.
+func (stand *BrewingStand) Save() nbt.Compound {
+	var compound = stand.Container.Save()
+	compound["CookTime"] = stand.CookTime
+	compound["FuelAmount"] = stand.FuelAmount
+	compound["FuelTotal"] = stand.FuelTotal
+	return compound
+}
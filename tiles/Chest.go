@@ -0,0 +1,61 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Chest is the tile of chests and trapped chests.
+// Two chests next to each other may be paired to form a double chest.
+type Chest struct {
+	*Container
+	paired       bool
+	pairX, pairZ int
+}
+
+func NewChest(x, y, z int) *Chest {
+	return &Chest{Container: NewContainer(ChestId, x, y, z)}
+}
+
+// IsPaired checks if the chest is paired with another chest.
+func (chest *Chest) IsPaired() bool {
+	return chest.paired
+}
+
+// GetPair returns the X and Z coordinates of the chest this chest is paired with.
+func (chest *Chest) GetPair() (int, int) {
+	return chest.pairX, chest.pairZ
+}
+
+// SetPair pairs the chest with the chest at the given X and Z coordinates.
+func (chest *Chest) SetPair(x, z int) {
+	chest.paired, chest.pairX, chest.pairZ = true, x, z
+}
+
+// Unpair removes the pairing of the chest.
+func (chest *Chest) Unpair() {
+	chest.paired = false
+}
+
+// Load loads the chest from the given compound.
+func (chest *Chest) Load(compound nbt.Compound) {
+	chest.Container.Load(compound)
+	chest.paired = compound.Has("pairx") && compound.Has("pairz")
+	chest.pairX, chest.pairZ = int(compound.GetInt("pairx")), int(compound.GetInt("pairz"))
+}
+
+// Save returns a compound holding the chest.
+func (chest *Chest) Save() nbt.Compound {
+	return chest.addPair(chest.Container.Save())
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the pairing of the chest.
+func (chest *Chest) GetSpawnCompound() nbt.Compound {
+	return chest.addPair(chest.Tile.GetSpawnCompound())
+}
+
+// addPair adds the pairing of the chest to the given compound.
+func (chest *Chest) addPair(compound nbt.Compound) nbt.Compound {
+	if chest.paired {
+		compound["pairx"] = int32(chest.pairX)
+		compound["pairz"] = int32(chest.pairZ)
+	}
+	return compound
+}
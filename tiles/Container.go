@@ -0,0 +1,29 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Container is the base of tiles holding items, such as chests and furnaces.
+// Items are kept as the item compounds stored in the NBT of the tile.
+type Container struct {
+	*Tile
+	Items []interface{}
+}
+
+func NewContainer(name string, x, y, z int) *Container {
+	return &Container{NewTile(name, x, y, z), []interface{}{}}
+}
+
+// Load loads the container and its items from the given compound.
+func (container *Container) Load(compound nbt.Compound) {
+	container.Tile.Load(compound)
+	if items := compound.GetList("Items"); items != nil {
+		container.Items = items
+	}
+}
+
+// Save returns a compound holding the container and its items.
+func (container *Container) Save() nbt.Compound {
+	var compound = container.Tile.Save()
+	compound["Items"] = container.Items
+	return compound
+}
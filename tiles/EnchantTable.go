@@ -0,0 +1,10 @@
+package tiles
+
+// EnchantTable is the tile of enchanting tables. It only holds a custom name.
+type EnchantTable struct {
+	*Tile
+}
+
+func NewEnchantTable(x, y, z int) *EnchantTable {
+	return &EnchantTable{NewTile(EnchantTableId, x, y, z)}
+}
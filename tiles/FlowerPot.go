@@ -0,0 +1,39 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// FlowerPot is the tile of flower pots, holding the plant in the pot.
+type FlowerPot struct {
+	*Tile
+	Item     int16
+	ItemData int32
+}
+
+func NewFlowerPot(x, y, z int) *FlowerPot {
+	return &FlowerPot{NewTile(FlowerPotId, x, y, z), 0, 0}
+}
+
+// IsEmpty checks if the flower pot has no plant in it.
+func (pot *FlowerPot) IsEmpty() bool {
+	return pot.Item == 0
+}
+
+// Load loads the flower pot from the given compound.
+func (pot *FlowerPot) Load(compound nbt.Compound) {
+	pot.Tile.Load(compound)
+	pot.Item = compound.GetShort("item")
+	pot.ItemData = compound.GetInt("mData")
+}
+
+// Save returns a compound holding the flower pot.
+func (pot *FlowerPot) Save() nbt.Compound {
+	var compound = pot.Tile.Save()
+	compound["item"] = pot.Item
+	compound["mData"] = pot.ItemData
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the plant in the pot.
+func (pot *FlowerPot) GetSpawnCompound() nbt.Compound {
+	return pot.Save()
+}
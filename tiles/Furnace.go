@@ -0,0 +1,32 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Furnace is the tile of furnaces.
+type Furnace struct {
+	*Container
+	BurnTime     int16
+	CookTime     int16
+	BurnDuration int16
+}
+
+func NewFurnace(x, y, z int) *Furnace {
+	return &Furnace{Container: NewContainer(FurnaceId, x, y, z)}
+}
+
+// Load loads the furnace from the given compound.
+func (furnace *Furnace) Load(compound nbt.Compound) {
+	furnace.Container.Load(compound)
+	furnace.BurnTime = compound.GetShort("BurnTime")
+	furnace.CookTime = compound.GetShort("CookTime")
+	furnace.BurnDuration = compound.GetShort("BurnDuration")
+}
+
+// Save returns a compound holding the furnace.
+func (furnace *Furnace) Save() nbt.Compound {
+	var compound = furnace.Container.Save()
+	compound["BurnTime"] = furnace.BurnTime
+	compound["CookTime"] = furnace.CookTime
+	compound["BurnDuration"] = furnace.BurnDuration
+	return compound
+}
@@ -0,0 +1,40 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// ItemFrame is the tile of item frames, holding the item in the frame.
+// The item is kept as the item compound stored in the NBT of the item frame, and is nil if the frame is empty.
+type ItemFrame struct {
+	*Tile
+	Item           nbt.Compound
+	ItemRotation   byte
+	ItemDropChance float32
+}
+
+func NewItemFrame(x, y, z int) *ItemFrame {
+	return &ItemFrame{NewTile(ItemFrameId, x, y, z), nil, 0, 1}
+}
+
+// Load loads the item frame from the given compound.
+func (frame *ItemFrame) Load(compound nbt.Compound) {
+	frame.Tile.Load(compound)
+	frame.Item = compound.GetCompound("Item")
+	frame.ItemRotation = compound.GetByte("ItemRotation")
+	frame.ItemDropChance = compound.GetFloat("ItemDropChance")
+}
+
+// Save returns a compound holding the item frame.
+func (frame *ItemFrame) Save() nbt.Compound {
+	var compound = frame.Tile.Save()
+	if frame.Item != nil {
+		compound["Item"] = frame.Item
+	}
+	compound["ItemRotation"] = frame.ItemRotation
+	compound["ItemDropChance"] = frame.ItemDropChance
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the item in the frame.
+func (frame *ItemFrame) GetSpawnCompound() nbt.Compound {
+	return frame.Save()
+}
@@ -0,0 +1,63 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// MobSpawner is the tile of monster spawners.
+type MobSpawner struct {
+	*Tile
+	EntityId            int32
+	Delay               int16
+	MinSpawnDelay       int16
+	MaxSpawnDelay       int16
+	SpawnCount          int16
+	SpawnRange          int16
+	MaxNearbyEntities   int16
+	RequiredPlayerRange int16
+}
+
+func NewMobSpawner(x, y, z int) *MobSpawner {
+	return &MobSpawner{
+		Tile:                NewTile(MobSpawnerId, x, y, z),
+		Delay:               20,
+		MinSpawnDelay:       200,
+		MaxSpawnDelay:       800,
+		SpawnCount:          4,
+		SpawnRange:          4,
+		MaxNearbyEntities:   6,
+		RequiredPlayerRange: 16,
+	}
+}
+
+// Load loads the mob spawner from the given compound.
+func (spawner *MobSpawner) Load(compound nbt.Compound) {
+	spawner.Tile.Load(compound)
+	spawner.EntityId = compound.GetInt("EntityId")
+	spawner.Delay = compound.GetShort("Delay")
+	spawner.MinSpawnDelay = compound.GetShort("MinSpawnDelay")
+	spawner.MaxSpawnDelay = compound.GetShort("MaxSpawnDelay")
+	spawner.SpawnCount = compound.GetShort("SpawnCount")
+	spawner.SpawnRange = compound.GetShort("SpawnRange")
+	spawner.MaxNearbyEntities = compound.GetShort("MaxNearbyEntities")
+	spawner.RequiredPlayerRange = compound.GetShort("RequiredPlayerRange")
+}
+
+// Save returns a compound holding the mob spawner.
+func (spawner *MobSpawner) Save() nbt.Compound {
+	var compound = spawner.Tile.Save()
+	compound["EntityId"] = spawner.EntityId
+	compound["Delay"] = spawner.Delay
+	compound["MinSpawnDelay"] = spawner.MinSpawnDelay
+	compound["MaxSpawnDelay"] = spawner.MaxSpawnDelay
+	compound["SpawnCount"] = spawner.SpawnCount
+	compound["SpawnRange"] = spawner.SpawnRange
+	compound["MaxNearbyEntities"] = spawner.MaxNearbyEntities
+	compound["RequiredPlayerRange"] = spawner.RequiredPlayerRange
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the entity shown in the spawner.
+func (spawner *MobSpawner) GetSpawnCompound() nbt.Compound {
+	var compound = spawner.Tile.GetSpawnCompound()
+	compound["EntityId"] = spawner.EntityId
+	return compound
+}
@@ -0,0 +1,50 @@
+package tiles
+
+import (
+	"strings"
+
+	"github.com/irmine/gomine/nbt"
+)
+
+// SignLines is the amount of lines of text on a sign.
+const SignLines = 4
+
+// Sign is the tile of standing and wall signs.
+type Sign struct {
+	*Tile
+	Text string
+}
+
+func NewSign(x, y, z int) *Sign {
+	return &Sign{NewTile(SignId, x, y, z), ""}
+}
+
+// GetLines returns the lines of text on the sign.
+func (sign *Sign) GetLines() [SignLines]string {
+	var lines [SignLines]string
+	copy(lines[:], strings.SplitN(sign.Text, "\n", SignLines))
+	return lines
+}
+
+// SetLines sets the lines of text on the sign.
+func (sign *Sign) SetLines(lines [SignLines]string) {
+	sign.Text = strings.Join(lines[:], "\n")
+}
+
+// Load loads the sign from the given compound.
+func (sign *Sign) Load(compound nbt.Compound) {
+	sign.Tile.Load(compound)
+	sign.Text = compound.GetString("Text")
+}
+
+// Save returns a compound holding the sign.
+func (sign *Sign) Save() nbt.Compound {
+	var compound = sign.Tile.Save()
+	compound["Text"] = sign.Text
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the text of the sign.
+func (sign *Sign) GetSpawnCompound() nbt.Compound {
+	return sign.Save()
+}
@@ -0,0 +1,44 @@
+package tiles
+
+import "github.com/irmine/gomine/nbt"
+
+// Types of skulls.
+const (
+	SkullSkeleton = iota
+	SkullWitherSkeleton
+	SkullZombie
+	SkullHuman
+	SkullCreeper
+	SkullDragon
+)
+
+// Skull is the tile of mob heads.
+type Skull struct {
+	*Tile
+	SkullType byte
+	Rotation  byte
+}
+
+func NewSkull(x, y, z int) *Skull {
+	return &Skull{NewTile(SkullId, x, y, z), SkullSkeleton, 0}
+}
+
+// Load loads the skull from the given compound.
+func (skull *Skull) Load(compound nbt.Compound) {
+	skull.Tile.Load(compound)
+	skull.SkullType = compound.GetByte("SkullType")
+	skull.Rotation = compound.GetByte("Rot")
+}
+
+// Save returns a compound holding the skull.
+func (skull *Skull) Save() nbt.Compound {
+	var compound = skull.Tile.Save()
+	compound["SkullType"] = skull.SkullType
+	compound["Rot"] = skull.Rotation
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients, holding the type and rotation of the skull.
+func (skull *Skull) GetSpawnCompound() nbt.Compound {
+	return skull.Save()
+}
@@ -1,35 +1,103 @@
 package tiles
 
+import (
+	"sync/atomic"
+
+	"github.com/irmine/gomine/nbt"
+)
+
 var TId uint64 = 0
 
+// Tile is the base of all tiles. It holds the type name, position and custom name of a tile.
 type Tile struct {
-	Name   string
-	closed bool
-	tId    uint64
+	Name       string
+	closed     bool
+	tId        uint64
+	x, y, z    int
+	customName string
 }
 
-func NewTile(Name string) Tile {
-	TId++
-	return Tile{Name, false, TId}
+func NewTile(name string, x, y, z int) *Tile {
+	return &Tile{Name: name, tId: atomic.AddUint64(&TId, 1), x: x, y: y, z: z}
 }
 
+// GetName returns the type name of the tile, which is the ID saved in its NBT.
 func (tile *Tile) GetName() string {
 	return tile.Name
 }
 
+// SetName sets the type name of the tile.
 func (tile *Tile) SetName(name string) {
 	tile.Name = name
 }
 
+// Close closes the tile, making it unable to be used.
 func (tile *Tile) Close() {
 	tile.closed = true
-	//todo
 }
 
+// IsClosed checks if the tile is closed and not to be used anymore.
 func (tile *Tile) IsClosed() bool {
 	return tile.closed
 }
 
+// GetId returns the runtime ID of the tile.
 func (tile *Tile) GetId() uint64 {
 	return tile.tId
 }
+
+// GetX returns the X coordinate of the block of the tile.
+func (tile *Tile) GetX() int {
+	return tile.x
+}
+
+// GetY returns the Y coordinate of the block of the tile.
+func (tile *Tile) GetY() int {
+	return tile.y
+}
+
+// GetZ returns the Z coordinate of the block of the tile.
+func (tile *Tile) GetZ() int {
+	return tile.z
+}
+
+// SetPosition sets the coordinates of the block of the tile.
+func (tile *Tile) SetPosition(x, y, z int) {
+	tile.x, tile.y, tile.z = x, y, z
+}
+
+// GetCustomName returns the custom name of the tile, or an empty string if it has none.
+func (tile *Tile) GetCustomName() string {
+	return tile.customName
+}
+
+// SetCustomName sets the custom name of the tile.
+func (tile *Tile) SetCustomName(name string) {
+	tile.customName = name
+}
+
+// Load loads the position and custom name of the tile from the given compound.
+func (tile *Tile) Load(compound nbt.Compound) {
+	tile.x, tile.y, tile.z = int(compound.GetInt("x")), int(compound.GetInt("y")), int(compound.GetInt("z"))
+	tile.customName = compound.GetString("CustomName")
+}
+
+// Save returns a compound holding the ID, position and custom name of the tile.
+func (tile *Tile) Save() nbt.Compound {
+	var compound = nbt.Compound{
+		"id": tile.Name,
+		"x":  int32(tile.x),
+		"y":  int32(tile.y),
+		"z":  int32(tile.z),
+	}
+	if tile.customName != "" {
+		compound["CustomName"] = tile.customName
+	}
+	return compound
+}
+
+// GetSpawnCompound returns the compound sent to clients to display the tile.
+// By default only the ID, position and custom name of the tile are sent.
+func (tile *Tile) GetSpawnCompound() nbt.Compound {
+	return tile.Save()
+}
@@ -1,15 +1,16 @@
 package tiles
 
+// IDs of the tiles, saved as "id" in the NBT of a tile.
 const (
-	BrewingStand = "BrewingStand"
-	Chest        = "Chest"
-	EnchantTable = "EnchantTable"
-	FlowerPot    = "FlowerPot"
-	Furnace      = "Furnace"
-	ItemFrame    = "ItemFrame"
-	MobSpawner   = "MobSpawner"
-	Sign         = "Sign"
-	Skull        = "Skull"
-	Bed          = "Bed"
-	Banner       = "Banner"
+	BrewingStandId = "BrewingStand"
+	ChestId        = "Chest"
+	EnchantTableId = "EnchantTable"
+	FlowerPotId    = "FlowerPot"
+	FurnaceId      = "Furnace"
+	ItemFrameId    = "ItemFrame"
+	MobSpawnerId   = "MobSpawner"
+	SignId         = "Sign"
+	SkullId        = "Skull"
+	BedId          = "Bed"
+	BannerId       = "Banner"
 )
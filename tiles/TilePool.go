@@ -0,0 +1,52 @@
+package tiles
+
+import (
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/nbt"
+)
+
+var tiles = map[string]func(x, y, z int) interfaces.ITile{}
+
+func init() {
+	RegisterTile(BrewingStandId, func(x, y, z int) interfaces.ITile { return NewBrewingStand(x, y, z) })
+	RegisterTile(ChestId, func(x, y, z int) interfaces.ITile { return NewChest(x, y, z) })
+	RegisterTile(EnchantTableId, func(x, y, z int) interfaces.ITile { return NewEnchantTable(x, y, z) })
+	RegisterTile(FlowerPotId, func(x, y, z int) interfaces.ITile { return NewFlowerPot(x, y, z) })
+	RegisterTile(FurnaceId, func(x, y, z int) interfaces.ITile { return NewFurnace(x, y, z) })
+	RegisterTile(ItemFrameId, func(x, y, z int) interfaces.ITile { return NewItemFrame(x, y, z) })
+	RegisterTile(MobSpawnerId, func(x, y, z int) interfaces.ITile { return NewMobSpawner(x, y, z) })
+	RegisterTile(SignId, func(x, y, z int) interfaces.ITile { return NewSign(x, y, z) })
+	RegisterTile(SkullId, func(x, y, z int) interfaces.ITile { return NewSkull(x, y, z) })
+	RegisterTile(BedId, func(x, y, z int) interfaces.ITile { return NewBed(x, y, z) })
+	RegisterTile(BannerId, func(x, y, z int) interfaces.ITile { return NewBanner(x, y, z) })
+}
+
+// RegisterTile registers a function creating a tile with the given name at a position.
+func RegisterTile(name string, tile func(x, y, z int) interfaces.ITile) {
+	tiles[name] = tile
+}
+
+// IsTileRegistered checks if a tile with the given name is registered.
+func IsTileRegistered(name string) bool {
+	var _, ok = tiles[name]
+	return ok
+}
+
+// NewTileByName returns a new tile with the given name at the given position.
+// Returns nil if no tile with the name is registered.
+func NewTileByName(name string, x, y, z int) interfaces.ITile {
+	if tile, ok := tiles[name]; ok {
+		return tile(x, y, z)
+	}
+	return nil
+}
+
+// NewTileFromNBT returns a new tile loaded from the given compound, of the type named by its ID.
+// Returns nil if no tile with the ID of the compound is registered.
+func NewTileFromNBT(compound nbt.Compound) interfaces.ITile {
+	var tile = NewTileByName(compound.GetString("id"), 0, 0, 0)
+	if tile != nil {
+		tile.Load(compound)
+	}
+	return tile
+}
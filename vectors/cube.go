@@ -1,6 +1,10 @@
 package vectors
 
-import "github.com/golang/geo/r3"
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+)
 
 type Cube struct {
 	MaxX, MaxY, MaxZ float64
@@ -130,3 +134,88 @@ func (cube *Cube) IsInside(vector r3.Vector) bool {
 func (cube *Cube) IsNil() bool {
 	return ((cube.MaxX - cube.MinX) * (cube.MaxY - cube.MinY) * (cube.MaxZ - cube.MinZ)) == float64(0)
 }
+
+// Returns a copy of this cube moved by the given offset.
+
+func (cube *Cube) Offset(x, y, z float64) *Cube {
+	return NewCube(cube.MinX+x, cube.MinY+y, cube.MinZ+z, cube.MaxX+x, cube.MaxY+y, cube.MaxZ+z)
+}
+
+// Returns a copy of this cube stretched in the direction of the given vector,
+// covering all space the cube passes when moving by the vector.
+
+func (cube *Cube) Stretch(vector r3.Vector) *Cube {
+	var stretched = NewCube(cube.MinX, cube.MinY, cube.MinZ, cube.MaxX, cube.MaxY, cube.MaxZ)
+	if vector.X < 0 {
+		stretched.MinX += vector.X
+	} else {
+		stretched.MaxX += vector.X
+	}
+	if vector.Y < 0 {
+		stretched.MinY += vector.Y
+	} else {
+		stretched.MaxY += vector.Y
+	}
+	if vector.Z < 0 {
+		stretched.MinZ += vector.Z
+	} else {
+		stretched.MaxZ += vector.Z
+	}
+	return stretched
+}
+
+// Checks if this cube and the given cube overlap. Cubes that only touch do not overlap.
+
+func (cube *Cube) Intersects(other *Cube) bool {
+	return other.MaxX > cube.MinX && other.MinX < cube.MaxX &&
+		other.MaxY > cube.MinY && other.MinY < cube.MaxY &&
+		other.MaxZ > cube.MinZ && other.MinZ < cube.MaxZ
+}
+
+// Returns the part of the given offset on the X axis the other cube can move before it hits this cube.
+// The offset is returned unchanged if the cubes do not overlap on the Y and Z axis.
+
+func (cube *Cube) ClipXOffset(other *Cube, offset float64) float64 {
+	if other.MaxY <= cube.MinY || other.MinY >= cube.MaxY || other.MaxZ <= cube.MinZ || other.MinZ >= cube.MaxZ {
+		return offset
+	}
+	if offset > 0 && other.MaxX <= cube.MinX {
+		return math.Min(offset, cube.MinX-other.MaxX)
+	}
+	if offset < 0 && other.MinX >= cube.MaxX {
+		return math.Max(offset, cube.MaxX-other.MinX)
+	}
+	return offset
+}
+
+// Returns the part of the given offset on the Y axis the other cube can move before it hits this cube.
+// The offset is returned unchanged if the cubes do not overlap on the X and Z axis.
+
+func (cube *Cube) ClipYOffset(other *Cube, offset float64) float64 {
+	if other.MaxX <= cube.MinX || other.MinX >= cube.MaxX || other.MaxZ <= cube.MinZ || other.MinZ >= cube.MaxZ {
+		return offset
+	}
+	if offset > 0 && other.MaxY <= cube.MinY {
+		return math.Min(offset, cube.MinY-other.MaxY)
+	}
+	if offset < 0 && other.MinY >= cube.MaxY {
+		return math.Max(offset, cube.MaxY-other.MinY)
+	}
+	return offset
+}
+
+// Returns the part of the given offset on the Z axis the other cube can move before it hits this cube.
+// The offset is returned unchanged if the cubes do not overlap on the X and Y axis.
+
+func (cube *Cube) ClipZOffset(other *Cube, offset float64) float64 {
+	if other.MaxX <= cube.MinX || other.MinX >= cube.MaxX || other.MaxY <= cube.MinY || other.MinY >= cube.MaxY {
+		return offset
+	}
+	if offset > 0 && other.MaxZ <= cube.MinZ {
+		return math.Min(offset, cube.MinZ-other.MaxZ)
+	}
+	if offset < 0 && other.MinZ >= cube.MaxZ {
+		return math.Max(offset, cube.MaxZ-other.MinZ)
+	}
+	return offset
+}
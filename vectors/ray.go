@@ -0,0 +1,83 @@
+package vectors
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+)
+
+// Faces of blocks and cubes, in the order used by the protocol.
+const (
+	FaceNone = iota - 1
+	FaceDown
+	FaceUp
+	FaceNorth
+	FaceSouth
+	FaceWest
+	FaceEast
+)
+
+// Returns the distance along the ray from the origin in the direction at which the ray enters this cube,
+// and the face of the cube it enters through. The direction must be normalized for the distance to be in blocks.
+// Returns false if the ray does not hit the cube within the maximum distance.
+// If the origin is inside of the cube, the distance is 0 and the face is FaceNone.
+
+func (cube *Cube) IntersectRay(origin, direction r3.Vector, maxDistance float64) (float64, int, bool) {
+	var near, far = math.Inf(-1), math.Inf(1)
+	var face = FaceNone
+
+	var axes = [3]struct {
+		origin, direction, min, max float64
+		minFace, maxFace            int
+	}{
+		{origin.X, direction.X, cube.MinX, cube.MaxX, FaceWest, FaceEast},
+		{origin.Y, direction.Y, cube.MinY, cube.MaxY, FaceDown, FaceUp},
+		{origin.Z, direction.Z, cube.MinZ, cube.MaxZ, FaceNorth, FaceSouth},
+	}
+	for _, axis := range axes {
+		if axis.direction == 0 {
+			if axis.origin < axis.min || axis.origin > axis.max {
+				return 0, FaceNone, false
+			}
+			continue
+		}
+		var enter, exit = (axis.min - axis.origin) / axis.direction, (axis.max - axis.origin) / axis.direction
+		var enterFace = axis.minFace
+		if enter > exit {
+			enter, exit = exit, enter
+			enterFace = axis.maxFace
+		}
+		if enter > near {
+			near, face = enter, enterFace
+		}
+		far = math.Min(far, exit)
+	}
+
+	if near > far || far < 0 || near > maxDistance {
+		return 0, FaceNone, false
+	}
+	if near < 0 {
+		return 0, FaceNone, true
+	}
+	return near, face, true
+}
+
+// Returns the offset of the block next to a block at the given face.
+
+func GetFaceOffset(face int) r3.Vector {
+	switch face {
+	case FaceDown:
+		return r3.Vector{Y: -1}
+	case FaceUp:
+		return r3.Vector{Y: 1}
+	case FaceNorth:
+		return r3.Vector{Z: -1}
+	case FaceSouth:
+		return r3.Vector{Z: 1}
+	case FaceWest:
+		return r3.Vector{X: -1}
+	case FaceEast:
+		return r3.Vector{X: 1}
+	}
+	return r3.Vector{}
+}
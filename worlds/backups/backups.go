@@ -0,0 +1,170 @@
+// Package backups writes level folders to timestamped zip archives, and restores level folders from them.
+package backups
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeFormat is the format of the time in the file names of backup archives.
+const TimeFormat = "2006-01-02_15-04-05"
+
+var (
+	InvalidArchive = errors.New("backup archive contains files outside of the level folder")
+)
+
+// GetArchiveName returns the file name of the archive of a backup of the level made at the given time.
+func GetArchiveName(levelName string, t time.Time) string {
+	return levelName + "_" + t.Format(TimeFormat) + ".zip"
+}
+
+// GetBackups returns the paths of all backup archives of the level in the folder, oldest first.
+func GetBackups(folder string, levelName string) []string {
+	var files, err = ioutil.ReadDir(folder)
+	if err != nil {
+		return nil
+	}
+	var backups []string
+	for _, file := range files {
+		var name = file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, levelName+"_") || !strings.HasSuffix(name, ".zip") {
+			continue
+		}
+		// Levels named like the level followed by an underscore would match the prefix, so the time is checked too.
+		if _, err := time.Parse(TimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, levelName+"_"), ".zip")); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(folder, name))
+	}
+	sort.Strings(backups)
+	return backups
+}
+
+// Prune removes the oldest backup archives of the level in the folder, keeping the given amount of newest archives.
+// No archives are removed if keep is 0 or less.
+func Prune(folder string, levelName string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	var backups = GetBackups(folder, levelName)
+	for len(backups) > keep {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// ZipFolder writes all files in the folder to a zip archive at the given path, with paths relative to the folder.
+// The archive is written to a temporary file first, so that an archive at the path is always complete.
+func ZipFolder(folder string, archive string) error {
+	if err := os.MkdirAll(filepath.Dir(archive), 0700); err != nil {
+		return err
+	}
+	var file, err = os.Create(archive + ".tmp")
+	if err != nil {
+		return err
+	}
+	var writer = zip.NewWriter(file)
+	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		var name, relErr = filepath.Rel(folder, path)
+		if relErr != nil {
+			return relErr
+		}
+		var header, headerErr = zip.FileInfoHeader(info)
+		if headerErr != nil {
+			return headerErr
+		}
+		header.Name = filepath.ToSlash(name)
+		header.Method = zip.Deflate
+
+		var entry, entryErr = writer.CreateHeader(header)
+		if entryErr != nil {
+			return entryErr
+		}
+		return copyFile(path, entry)
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archive + ".tmp")
+		return err
+	}
+	return os.Rename(archive+".tmp", archive)
+}
+
+// Extract extracts all files in the zip archive into the folder.
+// Returns InvalidArchive if the archive holds paths that lead outside of the folder.
+func Extract(archive string, folder string) error {
+	var reader, err = zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var root = filepath.Clean(folder) + string(filepath.Separator)
+	for _, file := range reader.File {
+		var path = filepath.Join(folder, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(path, root) {
+			return InvalidArchive
+		}
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0700); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractFile(file, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractFile writes a file in a zip archive to the path.
+func extractFile(file *zip.File, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	var reader, err = file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var output, createErr = os.Create(path)
+	if createErr != nil {
+		return createErr
+	}
+	if _, err := io.Copy(output, reader); err != nil {
+		output.Close()
+		return err
+	}
+	return output.Close()
+}
+
+// copyFile copies the contents of the file at the path to the writer.
+func copyFile(path string, writer io.Writer) error {
+	var file, err = os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(writer, file)
+	return err
+}
@@ -0,0 +1,138 @@
+package worlds
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// MaxScheduledUpdatesPerTick is the maximum amount of scheduled block updates that fire in a dimension in one tick.
+// Updates exceeding the limit fire on the next ticks.
+const MaxScheduledUpdatesPerTick = 65536
+
+// scheduledUpdate is a block update scheduled to fire at a tick.
+type scheduledUpdate struct {
+	x, y, z int
+	tick    int64
+	order   uint64
+}
+
+// updateQueue is a priority queue of scheduled block updates, ordered by the tick they fire at.
+// Updates firing at the same tick are ordered by the order in which they were scheduled.
+type updateQueue []*scheduledUpdate
+
+func (queue updateQueue) Len() int {
+	return len(queue)
+}
+
+func (queue updateQueue) Less(i, j int) bool {
+	if queue[i].tick == queue[j].tick {
+		return queue[i].order < queue[j].order
+	}
+	return queue[i].tick < queue[j].tick
+}
+
+func (queue updateQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *updateQueue) Push(update interface{}) {
+	*queue = append(*queue, update.(*scheduledUpdate))
+}
+
+func (queue *updateQueue) Pop() interface{} {
+	var old = *queue
+	var update = old[len(old)-1]
+	old[len(old)-1] = nil
+	*queue = old[:len(old)-1]
+	return update
+}
+
+// Schedules an update of the block at the given position to fire after the given amount of ticks.
+// The update gets dispatched to the update handler registered for the block at the position when it fires.
+// Scheduling an update for a block that already has an update scheduled has no effect.
+
+func (dimension *Dimension) ScheduleUpdate(position r3.Vector, delay int64) {
+	var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+	if y < 0 || y > 255 {
+		return
+	}
+	var index = GetBlockIndex(x, y, z)
+	if dimension.scheduledBlocks[index] {
+		return
+	}
+	if delay < 1 {
+		delay = 1
+	}
+	dimension.scheduledBlocks[index] = true
+	dimension.updateOrder++
+	heap.Push(&dimension.scheduledUpdates, &scheduledUpdate{x, y, z, dimension.level.GetServer().GetCurrentTick() + delay, dimension.updateOrder})
+}
+
+// Checks if the block at the given position has an update scheduled.
+
+func (dimension *Dimension) IsUpdateScheduled(position r3.Vector) bool {
+	return dimension.scheduledBlocks[GetBlockIndex(int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z)))]
+}
+
+// Fires all scheduled updates that are due.
+// Updates of blocks in chunks that are no longer loaded get dropped.
+
+func (dimension *Dimension) tickScheduledUpdates() {
+	var currentTick = dimension.level.GetServer().GetCurrentTick()
+	for i := 0; i < MaxScheduledUpdatesPerTick && len(dimension.scheduledUpdates) > 0; i++ {
+		if dimension.scheduledUpdates[0].tick > currentTick {
+			return
+		}
+		var update = heap.Pop(&dimension.scheduledUpdates).(*scheduledUpdate)
+		delete(dimension.scheduledBlocks, GetBlockIndex(update.x, update.y, update.z))
+
+		var chunk = dimension.GetLoadedChunk(int32(update.x>>4), int32(update.z>>4))
+		if chunk == nil {
+			continue
+		}
+		var id = chunk.GetBlockId(update.x&15, update.y, update.z&15)
+		if handler := blocks.GetUpdateHandler(id); handler != nil {
+			var block = blocks.GetBlock(int(id), chunk.GetBlockData(update.x&15, update.y, update.z&15))
+			handler(dimension, r3.Vector{X: float64(update.x), Y: float64(update.y), Z: float64(update.z)}, block)
+		}
+	}
+}
+
+// Randomly ticks blocks in all loaded chunks.
+// The random tick speed game rule decides the amount of random blocks ticked per sub chunk every tick.
+// Random ticks get dispatched to the random tick handler registered for the ticked block.
+
+func (dimension *Dimension) tickRandomBlocks() {
+	var speed = int(dimension.level.GetGameRule(GameRuleRandomTickSpeed).GetValue().(uint32))
+	if speed == 0 {
+		return
+	}
+
+	dimension.mux.Lock()
+	var loaded = make([]interfaces.IChunk, 0, len(dimension.chunks))
+	for _, chunk := range dimension.chunks {
+		loaded = append(loaded, chunk)
+	}
+	dimension.mux.Unlock()
+
+	for _, chunk := range loaded {
+		var baseX, baseZ = int(chunk.GetX()) << 4, int(chunk.GetZ()) << 4
+		for y, subChunk := range chunk.GetSubChunks() {
+			for i := 0; i < speed; i++ {
+				var value = dimension.random.Int()
+				var x, blockY, z = value & 15, (value >> 4) & 15, (value >> 8) & 15
+				var id = subChunk.GetBlockId(x, blockY, z)
+				var handler = blocks.GetRandomTickHandler(id)
+				if handler == nil {
+					continue
+				}
+				var block = blocks.GetBlock(int(id), subChunk.GetBlockData(x, blockY, z))
+				handler(dimension, r3.Vector{X: float64(baseX + x), Y: float64(y<<4 + blockY), Z: float64(baseZ + z)}, block)
+			}
+		}
+	}
+}
@@ -66,7 +66,7 @@ func (block *Block) GetName() string {
 // Blocks such as flowers do not have collision boxes.
 
 func (block *Block) HasCollisionBox() bool {
-	return block.hasCollisionBox || block.CollisionBox.IsNil()
+	return block.hasCollisionBox && !block.CollisionBox.IsNil()
 }
 
 // Returns the collision box of this block.
@@ -1,6 +1,260 @@
 package blocks
 
 const (
-	AIR   = iota
+	AIR = iota
 	STONE
+	GRASS
+	DIRT
+	COBBLESTONE
+	PLANKS
+	SAPLING
+	BEDROCK
+	FLOWING_WATER
+	STILL_WATER
+	FLOWING_LAVA
+	STILL_LAVA
+	SAND
+	GRAVEL
+	GOLD_ORE
+	IRON_ORE
+	COAL_ORE
+	LOG
+	LEAVES
+	SPONGE
+	GLASS
+	LAPIS_ORE
+	LAPIS_BLOCK
+	DISPENSER
+	SANDSTONE
+	NOTE_BLOCK
+	BED
+	POWERED_RAIL
+	DETECTOR_RAIL
+	STICKY_PISTON
+	COBWEB
+	TALL_GRASS
+	DEAD_BUSH
+	PISTON
+	PISTON_ARM_COLLISION
+	WOOL
+	ELEMENT_0
+	DANDELION
+	RED_FLOWER
+	BROWN_MUSHROOM
+	RED_MUSHROOM
+	GOLD_BLOCK
+	IRON_BLOCK
+	DOUBLE_STONE_SLAB
+	STONE_SLAB
+	BRICK_BLOCK
+	TNT
+	BOOKSHELF
+	MOSSY_COBBLESTONE
+	OBSIDIAN
+	TORCH
+	FIRE
+	MOB_SPAWNER
+	OAK_STAIRS
+	CHEST
+	REDSTONE_WIRE
+	DIAMOND_ORE
+	DIAMOND_BLOCK
+	CRAFTING_TABLE
+	WHEAT
+	FARMLAND
+	FURNACE
+	BURNING_FURNACE
+	STANDING_SIGN
+	OAK_DOOR
+	LADDER
+	RAIL
+	COBBLESTONE_STAIRS
+	WALL_SIGN
+	LEVER
+	STONE_PRESSURE_PLATE
+	IRON_DOOR
+	WOODEN_PRESSURE_PLATE
+	REDSTONE_ORE
+	GLOWING_REDSTONE_ORE
+	UNLIT_REDSTONE_TORCH
+	REDSTONE_TORCH
+	STONE_BUTTON
+	SNOW_LAYER
+	ICE
+	SNOW
+	CACTUS
+	CLAY
+	SUGARCANE
+	JUKEBOX
+	FENCE
+	PUMPKIN
+	NETHERRACK
+	SOUL_SAND
+	GLOWSTONE
+	PORTAL
+	LIT_PUMPKIN
+	CAKE
+	UNPOWERED_REPEATER
+	POWERED_REPEATER
+	INVISIBLE_BEDROCK
+	TRAPDOOR
+	MONSTER_EGG
+	STONE_BRICK
+	BROWN_MUSHROOM_BLOCK
+	RED_MUSHROOM_BLOCK
+	IRON_BARS
+	GLASS_PANE
+	MELON_BLOCK
+	PUMPKIN_STEM
+	MELON_STEM
+	VINES
+	FENCE_GATE
+	BRICK_STAIRS
+	STONE_BRICK_STAIRS
+	MYCELIUM
+	LILY_PAD
+	NETHER_BRICK
+	NETHER_BRICK_FENCE
+	NETHER_BRICK_STAIRS
+	NETHER_WART
+	ENCHANTING_TABLE
+	BREWING_STAND
+	CAULDRON
+	END_PORTAL
+	END_PORTAL_FRAME
+	END_STONE
+	DRAGON_EGG
+	REDSTONE_LAMP
+	LIT_REDSTONE_LAMP
+	DROPPER
+	ACTIVATOR_RAIL
+	COCOA
+	SANDSTONE_STAIRS
+	EMERALD_ORE
+	ENDER_CHEST
+	TRIPWIRE_HOOK
+	TRIPWIRE
+	EMERALD_BLOCK
+	SPRUCE_STAIRS
+	BIRCH_STAIRS
+	JUNGLE_STAIRS
+	COMMAND_BLOCK
+	BEACON
+	COBBLESTONE_WALL
+	FLOWER_POT
+	CARROTS
+	POTATOES
+	WOODEN_BUTTON
+	SKULL
+	ANVIL
+	TRAPPED_CHEST
+	LIGHT_WEIGHTED_PRESSURE_PLATE
+	HEAVY_WEIGHTED_PRESSURE_PLATE
+	UNPOWERED_COMPARATOR
+	POWERED_COMPARATOR
+	DAYLIGHT_SENSOR
+	REDSTONE_BLOCK
+	NETHER_QUARTZ_ORE
+	HOPPER
+	QUARTZ_BLOCK
+	QUARTZ_STAIRS
+	DOUBLE_WOODEN_SLAB
+	WOODEN_SLAB
+	STAINED_CLAY
+	STAINED_GLASS_PANE
+	LEAVES2
+	LOG2
+	ACACIA_STAIRS
+	DARK_OAK_STAIRS
+	SLIME_BLOCK
+	_
+	IRON_TRAPDOOR
+	PRISMARINE
+	SEA_LANTERN
+	HAY_BALE
+	CARPET
+	HARDENED_CLAY
+	COAL_BLOCK
+	PACKED_ICE
+	DOUBLE_PLANT
+	STANDING_BANNER
+	WALL_BANNER
+	DAYLIGHT_SENSOR_INVERTED
+	RED_SANDSTONE
+	RED_SANDSTONE_STAIRS
+	DOUBLE_STONE_SLAB2
+	STONE_SLAB2
+	SPRUCE_FENCE_GATE
+	BIRCH_FENCE_GATE
+	JUNGLE_FENCE_GATE
+	DARK_OAK_FENCE_GATE
+	ACACIA_FENCE_GATE
+	REPEATING_COMMAND_BLOCK
+	CHAIN_COMMAND_BLOCK
+	_
+	_
+	_
+	SPRUCE_DOOR
+	BIRCH_DOOR
+	JUNGLE_DOOR
+	ACACIA_DOOR
+	DARK_OAK_DOOR
+	GRASS_PATH
+	ITEM_FRAME
+	CHORUS_FLOWER
+	PURPUR_BLOCK
+	_
+	PURPUR_STAIRS
+	_
+	UNDYED_SHULKER_BOX
+	END_BRICKS
+	FROSTED_ICE
+	END_ROD
+	END_GATEWAY
+	_
+	_
+	_
+	MAGMA
+	NETHER_WART_BLOCK
+	RED_NETHER_BRICK
+	BONE_BLOCK
+	STRUCTURE_VOID
+	SHULKER_BOX
+	PURPLE_GLAZED_TERRACOTTA
+	WHITE_GLAZED_TERRACOTTA
+	ORANGE_GLAZED_TERRACOTTA
+	MAGENTA_GLAZED_TERRACOTTA
+	LIGHT_BLUE_GLAZED_TERRACOTTA
+	YELLOW_GLAZED_TERRACOTTA
+	LIME_GLAZED_TERRACOTTA
+	PINK_GLAZED_TERRACOTTA
+	GRAY_GLAZED_TERRACOTTA
+	SILVER_GLAZED_TERRACOTTA
+	CYAN_GLAZED_TERRACOTTA
+	_
+	BLUE_GLAZED_TERRACOTTA
+	BROWN_GLAZED_TERRACOTTA
+	GREEN_GLAZED_TERRACOTTA
+	RED_GLAZED_TERRACOTTA
+	BLACK_GLAZED_TERRACOTTA
+	CONCRETE
+	CONCRETE_POWDER
+	_
+	_
+	CHORUS_PLANT
+	STAINED_GLASS
+	_
+	PODZOL
+	BEETROOT
+	STONECUTTER
+	GLOWING_OBSIDIAN
+	NETHER_REACTOR
+	INFO_UPDATE
+	INFO_UPDATE2
+	MOVING_BLOCK
+	OBSERVER
+	STRUCTURE_BLOCK
+	_
+	_
+	RESERVED6
 )
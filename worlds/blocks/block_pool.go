@@ -1,24 +1,106 @@
 package blocks
 
 import (
+	"github.com/golang/geo/r3"
 	"github.com/irmine/gomine/interfaces"
 )
 
 var blocks = map[int]func(byte) interfaces.IBlock{}
 
+// Light properties of all block IDs, cached so that light calculation does not need to create blocks.
+// Unregistered IDs have the properties of a generic block, which fully filters light.
+var lightFilters, lightEmissions = newLightFilters(), [256]byte{}
+
+// Blast resistances of all block IDs, cached so that explosions do not need to create blocks.
+var blastResistances = [256]int{}
+
+// UpdateHandler handles a random tick or scheduled update of the block at a position in a dimension.
+type UpdateHandler func(dimension interfaces.IDimension, position r3.Vector, block interfaces.IBlock)
+
+// Handlers of random ticks and scheduled updates of all block IDs.
+var randomTickHandlers, updateHandlers = [256]UpdateHandler{}, [256]UpdateHandler{}
+
 func init() {
 	RegisterBlock(AIR, func(data byte) interfaces.IBlock { return NewAir(data) })
 	RegisterBlock(STONE, func(data byte) interfaces.IBlock { return NewStone(data) })
+	for _, props := range vanillaBlocks {
+		var props = props
+		RegisterBlock(props.id, func(data byte) interfaces.IBlock { return newVanillaBlock(props, data) })
+	}
 }
 
 // Registers a new block with a function that creates it.
 
 func RegisterBlock(id int, block func(byte) interfaces.IBlock) {
 	blocks[id] = block
+	if id >= 0 && id < 256 {
+		var instance = block(0)
+		lightFilters[id] = instance.GetLightFilterLevel()
+		lightEmissions[id] = instance.GetLightEmissionLevel()
+		blastResistances[id] = instance.GetBlastResistance()
+	}
+}
+
+// Returns the amount of light levels the block with the given ID filters.
+
+func GetLightFilter(id byte) byte {
+	return lightFilters[id]
+}
+
+// Returns the light level the block with the given ID emits.
+
+func GetLightEmission(id byte) byte {
+	return lightEmissions[id]
+}
+
+// Returns the blast resistance of the block with the given ID.
+
+func GetBlastResistance(id byte) int {
+	return blastResistances[id]
+}
+
+// Registers the handler called when a block with the given ID gets randomly ticked.
+// Random ticks drive slow processes such as crop growth and leaf decay.
+
+func RegisterRandomTickHandler(id byte, handler UpdateHandler) {
+	randomTickHandlers[id] = handler
+}
+
+// Returns the random tick handler of the block with the given ID, or nil if the block has none.
+
+func GetRandomTickHandler(id byte) UpdateHandler {
+	return randomTickHandlers[id]
+}
+
+// Registers the handler called when a scheduled update of a block with the given ID fires.
+// Scheduled updates drive processes that happen after a fixed delay, such as fluid flow.
+
+func RegisterUpdateHandler(id byte, handler UpdateHandler) {
+	updateHandlers[id] = handler
+}
+
+// Returns the scheduled update handler of the block with the given ID, or nil if the block has none.
+
+func GetUpdateHandler(id byte) UpdateHandler {
+	return updateHandlers[id]
+}
+
+// Returns the light filters of unregistered blocks.
+
+func newLightFilters() [256]byte {
+	var filters [256]byte
+	for i := range filters {
+		filters[i] = 15
+	}
+	return filters
 }
 
 // Returns a new block with the given ID.
+// A generic block is returned if no block with the ID is registered.
 
 func GetBlock(id int, data byte) interfaces.IBlock {
-	return blocks[id](data)
+	if block, ok := blocks[id]; ok {
+		return block(data)
+	}
+	return NewBlock(id, data, "Unknown")
 }
@@ -0,0 +1,287 @@
+package blocks
+
+import (
+	"strings"
+)
+
+// IdentifierNamespace is the namespace of the string identifiers of vanilla blocks.
+const IdentifierNamespace = "minecraft:"
+
+// identifiers are the string identifiers of all vanilla block IDs, as used by Minecraft Bedrock Edition
+// in structure files and block palettes. IDs without block have an empty identifier.
+var identifiers = [256]string{
+	AIR:                           "air",
+	STONE:                         "stone",
+	GRASS:                         "grass",
+	DIRT:                          "dirt",
+	COBBLESTONE:                   "cobblestone",
+	PLANKS:                        "planks",
+	SAPLING:                       "sapling",
+	BEDROCK:                       "bedrock",
+	FLOWING_WATER:                 "flowing_water",
+	STILL_WATER:                   "water",
+	FLOWING_LAVA:                  "flowing_lava",
+	STILL_LAVA:                    "lava",
+	SAND:                          "sand",
+	GRAVEL:                        "gravel",
+	GOLD_ORE:                      "gold_ore",
+	IRON_ORE:                      "iron_ore",
+	COAL_ORE:                      "coal_ore",
+	LOG:                           "log",
+	LEAVES:                        "leaves",
+	SPONGE:                        "sponge",
+	GLASS:                         "glass",
+	LAPIS_ORE:                     "lapis_ore",
+	LAPIS_BLOCK:                   "lapis_block",
+	DISPENSER:                     "dispenser",
+	SANDSTONE:                     "sandstone",
+	NOTE_BLOCK:                    "noteblock",
+	BED:                           "bed",
+	POWERED_RAIL:                  "golden_rail",
+	DETECTOR_RAIL:                 "detector_rail",
+	STICKY_PISTON:                 "sticky_piston",
+	COBWEB:                        "web",
+	TALL_GRASS:                    "tallgrass",
+	DEAD_BUSH:                     "deadbush",
+	PISTON:                        "piston",
+	PISTON_ARM_COLLISION:          "pistonArmCollision",
+	WOOL:                          "wool",
+	ELEMENT_0:                     "element_0",
+	DANDELION:                     "yellow_flower",
+	RED_FLOWER:                    "red_flower",
+	BROWN_MUSHROOM:                "brown_mushroom",
+	RED_MUSHROOM:                  "red_mushroom",
+	GOLD_BLOCK:                    "gold_block",
+	IRON_BLOCK:                    "iron_block",
+	DOUBLE_STONE_SLAB:             "double_stone_slab",
+	STONE_SLAB:                    "stone_slab",
+	BRICK_BLOCK:                   "brick_block",
+	TNT:                           "tnt",
+	BOOKSHELF:                     "bookshelf",
+	MOSSY_COBBLESTONE:             "mossy_cobblestone",
+	OBSIDIAN:                      "obsidian",
+	TORCH:                         "torch",
+	FIRE:                          "fire",
+	MOB_SPAWNER:                   "mob_spawner",
+	OAK_STAIRS:                    "oak_stairs",
+	CHEST:                         "chest",
+	REDSTONE_WIRE:                 "redstone_wire",
+	DIAMOND_ORE:                   "diamond_ore",
+	DIAMOND_BLOCK:                 "diamond_block",
+	CRAFTING_TABLE:                "crafting_table",
+	WHEAT:                         "wheat",
+	FARMLAND:                      "farmland",
+	FURNACE:                       "furnace",
+	BURNING_FURNACE:               "lit_furnace",
+	STANDING_SIGN:                 "standing_sign",
+	OAK_DOOR:                      "wooden_door",
+	LADDER:                        "ladder",
+	RAIL:                          "rail",
+	COBBLESTONE_STAIRS:            "stone_stairs",
+	WALL_SIGN:                     "wall_sign",
+	LEVER:                         "lever",
+	STONE_PRESSURE_PLATE:          "stone_pressure_plate",
+	IRON_DOOR:                     "iron_door",
+	WOODEN_PRESSURE_PLATE:         "wooden_pressure_plate",
+	REDSTONE_ORE:                  "redstone_ore",
+	GLOWING_REDSTONE_ORE:          "lit_redstone_ore",
+	UNLIT_REDSTONE_TORCH:          "unlit_redstone_torch",
+	REDSTONE_TORCH:                "redstone_torch",
+	STONE_BUTTON:                  "stone_button",
+	SNOW_LAYER:                    "snow_layer",
+	ICE:                           "ice",
+	SNOW:                          "snow",
+	CACTUS:                        "cactus",
+	CLAY:                          "clay",
+	SUGARCANE:                     "reeds",
+	JUKEBOX:                       "jukebox",
+	FENCE:                         "fence",
+	PUMPKIN:                       "pumpkin",
+	NETHERRACK:                    "netherrack",
+	SOUL_SAND:                     "soul_sand",
+	GLOWSTONE:                     "glowstone",
+	PORTAL:                        "portal",
+	LIT_PUMPKIN:                   "lit_pumpkin",
+	CAKE:                          "cake",
+	UNPOWERED_REPEATER:            "unpowered_repeater",
+	POWERED_REPEATER:              "powered_repeater",
+	INVISIBLE_BEDROCK:             "invisibleBedrock",
+	TRAPDOOR:                      "trapdoor",
+	MONSTER_EGG:                   "monster_egg",
+	STONE_BRICK:                   "stonebrick",
+	BROWN_MUSHROOM_BLOCK:          "brown_mushroom_block",
+	RED_MUSHROOM_BLOCK:            "red_mushroom_block",
+	IRON_BARS:                     "iron_bars",
+	GLASS_PANE:                    "glass_pane",
+	MELON_BLOCK:                   "melon_block",
+	PUMPKIN_STEM:                  "pumpkin_stem",
+	MELON_STEM:                    "melon_stem",
+	VINES:                         "vine",
+	FENCE_GATE:                    "fence_gate",
+	BRICK_STAIRS:                  "brick_stairs",
+	STONE_BRICK_STAIRS:            "stone_brick_stairs",
+	MYCELIUM:                      "mycelium",
+	LILY_PAD:                      "waterlily",
+	NETHER_BRICK:                  "nether_brick",
+	NETHER_BRICK_FENCE:            "nether_brick_fence",
+	NETHER_BRICK_STAIRS:           "nether_brick_stairs",
+	NETHER_WART:                   "nether_wart",
+	ENCHANTING_TABLE:              "enchanting_table",
+	BREWING_STAND:                 "brewing_stand",
+	CAULDRON:                      "cauldron",
+	END_PORTAL:                    "end_portal",
+	END_PORTAL_FRAME:              "end_portal_frame",
+	END_STONE:                     "end_stone",
+	DRAGON_EGG:                    "dragon_egg",
+	REDSTONE_LAMP:                 "redstone_lamp",
+	LIT_REDSTONE_LAMP:             "lit_redstone_lamp",
+	DROPPER:                       "dropper",
+	ACTIVATOR_RAIL:                "activator_rail",
+	COCOA:                         "cocoa",
+	SANDSTONE_STAIRS:              "sandstone_stairs",
+	EMERALD_ORE:                   "emerald_ore",
+	ENDER_CHEST:                   "ender_chest",
+	TRIPWIRE_HOOK:                 "tripwire_hook",
+	TRIPWIRE:                      "tripWire",
+	EMERALD_BLOCK:                 "emerald_block",
+	SPRUCE_STAIRS:                 "spruce_stairs",
+	BIRCH_STAIRS:                  "birch_stairs",
+	JUNGLE_STAIRS:                 "jungle_stairs",
+	COMMAND_BLOCK:                 "command_block",
+	BEACON:                        "beacon",
+	COBBLESTONE_WALL:              "cobblestone_wall",
+	FLOWER_POT:                    "flower_pot",
+	CARROTS:                       "carrots",
+	POTATOES:                      "potatoes",
+	WOODEN_BUTTON:                 "wooden_button",
+	SKULL:                         "skull",
+	ANVIL:                         "anvil",
+	TRAPPED_CHEST:                 "trapped_chest",
+	LIGHT_WEIGHTED_PRESSURE_PLATE: "light_weighted_pressure_plate",
+	HEAVY_WEIGHTED_PRESSURE_PLATE: "heavy_weighted_pressure_plate",
+	UNPOWERED_COMPARATOR:          "unpowered_comparator",
+	POWERED_COMPARATOR:            "powered_comparator",
+	DAYLIGHT_SENSOR:               "daylight_detector",
+	REDSTONE_BLOCK:                "redstone_block",
+	NETHER_QUARTZ_ORE:             "quartz_ore",
+	HOPPER:                        "hopper",
+	QUARTZ_BLOCK:                  "quartz_block",
+	QUARTZ_STAIRS:                 "quartz_stairs",
+	DOUBLE_WOODEN_SLAB:            "double_wooden_slab",
+	WOODEN_SLAB:                   "wooden_slab",
+	STAINED_CLAY:                  "stained_hardened_clay",
+	STAINED_GLASS_PANE:            "stained_glass_pane",
+	LEAVES2:                       "leaves2",
+	LOG2:                          "log2",
+	ACACIA_STAIRS:                 "acacia_stairs",
+	DARK_OAK_STAIRS:               "dark_oak_stairs",
+	SLIME_BLOCK:                   "slime",
+	IRON_TRAPDOOR:                 "iron_trapdoor",
+	PRISMARINE:                    "prismarine",
+	SEA_LANTERN:                   "seaLantern",
+	HAY_BALE:                      "hay_block",
+	CARPET:                        "carpet",
+	HARDENED_CLAY:                 "hardened_clay",
+	COAL_BLOCK:                    "coal_block",
+	PACKED_ICE:                    "packed_ice",
+	DOUBLE_PLANT:                  "double_plant",
+	STANDING_BANNER:               "standing_banner",
+	WALL_BANNER:                   "wall_banner",
+	DAYLIGHT_SENSOR_INVERTED:      "daylight_detector_inverted",
+	RED_SANDSTONE:                 "red_sandstone",
+	RED_SANDSTONE_STAIRS:          "red_sandstone_stairs",
+	DOUBLE_STONE_SLAB2:            "double_stone_slab2",
+	STONE_SLAB2:                   "stone_slab2",
+	SPRUCE_FENCE_GATE:             "spruce_fence_gate",
+	BIRCH_FENCE_GATE:              "birch_fence_gate",
+	JUNGLE_FENCE_GATE:             "jungle_fence_gate",
+	DARK_OAK_FENCE_GATE:           "dark_oak_fence_gate",
+	ACACIA_FENCE_GATE:             "acacia_fence_gate",
+	REPEATING_COMMAND_BLOCK:       "repeating_command_block",
+	CHAIN_COMMAND_BLOCK:           "chain_command_block",
+	SPRUCE_DOOR:                   "spruce_door",
+	BIRCH_DOOR:                    "birch_door",
+	JUNGLE_DOOR:                   "jungle_door",
+	ACACIA_DOOR:                   "acacia_door",
+	DARK_OAK_DOOR:                 "dark_oak_door",
+	GRASS_PATH:                    "grass_path",
+	ITEM_FRAME:                    "frame",
+	CHORUS_FLOWER:                 "chorus_flower",
+	PURPUR_BLOCK:                  "purpur_block",
+	PURPUR_STAIRS:                 "purpur_stairs",
+	UNDYED_SHULKER_BOX:            "undyed_shulker_box",
+	END_BRICKS:                    "end_bricks",
+	FROSTED_ICE:                   "frosted_ice",
+	END_ROD:                       "end_rod",
+	END_GATEWAY:                   "end_gateway",
+	MAGMA:                         "magma",
+	NETHER_WART_BLOCK:             "nether_wart_block",
+	RED_NETHER_BRICK:              "red_nether_brick",
+	BONE_BLOCK:                    "bone_block",
+	STRUCTURE_VOID:                "structure_void",
+	SHULKER_BOX:                   "shulker_box",
+	PURPLE_GLAZED_TERRACOTTA:      "purple_glazed_terracotta",
+	WHITE_GLAZED_TERRACOTTA:       "white_glazed_terracotta",
+	ORANGE_GLAZED_TERRACOTTA:      "orange_glazed_terracotta",
+	MAGENTA_GLAZED_TERRACOTTA:     "magenta_glazed_terracotta",
+	LIGHT_BLUE_GLAZED_TERRACOTTA:  "light_blue_glazed_terracotta",
+	YELLOW_GLAZED_TERRACOTTA:      "yellow_glazed_terracotta",
+	LIME_GLAZED_TERRACOTTA:        "lime_glazed_terracotta",
+	PINK_GLAZED_TERRACOTTA:        "pink_glazed_terracotta",
+	GRAY_GLAZED_TERRACOTTA:        "gray_glazed_terracotta",
+	SILVER_GLAZED_TERRACOTTA:      "silver_glazed_terracotta",
+	CYAN_GLAZED_TERRACOTTA:        "cyan_glazed_terracotta",
+	BLUE_GLAZED_TERRACOTTA:        "blue_glazed_terracotta",
+	BROWN_GLAZED_TERRACOTTA:       "brown_glazed_terracotta",
+	GREEN_GLAZED_TERRACOTTA:       "green_glazed_terracotta",
+	RED_GLAZED_TERRACOTTA:         "red_glazed_terracotta",
+	BLACK_GLAZED_TERRACOTTA:       "black_glazed_terracotta",
+	CONCRETE:                      "concrete",
+	CONCRETE_POWDER:               "concretePowder",
+	CHORUS_PLANT:                  "chorus_plant",
+	STAINED_GLASS:                 "stained_glass",
+	PODZOL:                        "podzol",
+	BEETROOT:                      "beetroot",
+	STONECUTTER:                   "stonecutter",
+	GLOWING_OBSIDIAN:              "glowingobsidian",
+	NETHER_REACTOR:                "netherreactor",
+	INFO_UPDATE:                   "info_update",
+	INFO_UPDATE2:                  "info_update2",
+	MOVING_BLOCK:                  "movingBlock",
+	OBSERVER:                      "observer",
+	STRUCTURE_BLOCK:               "structure_block",
+	RESERVED6:                     "reserved6",
+}
+
+// blockIds maps the identifiers of vanilla blocks, without namespace, to their block IDs.
+var blockIds = newBlockIds()
+
+// Returns a map of all identifiers without namespace to their block IDs.
+
+func newBlockIds() map[string]byte {
+	var ids = make(map[string]byte, len(identifiers))
+	for id, identifier := range identifiers {
+		if identifier != "" {
+			ids[identifier] = byte(id)
+		}
+	}
+	return ids
+}
+
+// Returns the namespaced string identifier of the block ID, such as "minecraft:stone".
+// Returns an empty string if the ID has no vanilla block.
+
+func GetIdentifier(id byte) string {
+	if identifiers[id] == "" {
+		return ""
+	}
+	return IdentifierNamespace + identifiers[id]
+}
+
+// Returns the block ID of a string identifier, with or without the minecraft namespace.
+// Returns false if no vanilla block has the identifier.
+
+func GetIdByIdentifier(identifier string) (byte, bool) {
+	var id, ok = blockIds[strings.TrimPrefix(identifier, IdentifierNamespace)]
+	return id, ok
+}
@@ -0,0 +1,360 @@
+package blocks
+
+import (
+	"github.com/irmine/gomine/vectors"
+)
+
+// Light filter levels of vanilla blocks.
+const (
+	filterNone   = 0
+	filterLeaves = 1
+	filterWater  = 2
+	filterSolid  = 15
+)
+
+// colors are the names of the 16 dye colors, in order of their block data.
+var colors = []string{"White", "Orange", "Magenta", "Light Blue", "Yellow", "Lime", "Pink", "Gray", "Light Gray", "Cyan", "Purple", "Blue", "Brown", "Green", "Red", "Black"}
+
+// woods are the names of the 6 wood types, in order of their block data.
+var woods = []string{"Oak", "Spruce", "Birch", "Jungle", "Acacia", "Dark Oak"}
+
+// properties are the properties of a vanilla block.
+type properties struct {
+	id              int
+	name            string
+	hardness        float32
+	blastResistance int
+	lightEmission   byte
+	lightFilter     byte
+	// box returns the collision box of the block with the given data. A nil box is a full cube.
+	box func(data byte) *vectors.CubesBox
+	// variantMask is applied to the block data to get the index in the variants.
+	variantMask byte
+	variants    []string
+}
+
+// VanillaBlock is a block of which the properties are defined in the vanilla block table.
+type VanillaBlock struct {
+	*Block
+	variants    []string
+	variantMask byte
+}
+
+// Returns a new vanilla block with the given properties and data.
+
+func newVanillaBlock(props properties, data byte) *VanillaBlock {
+	var block = &VanillaBlock{NewBlock(props.id, data, props.name), props.variants, props.variantMask}
+	block.SetHardness(props.hardness)
+	block.SetBlastResistance(props.blastResistance)
+	block.SetLightEmissionLevel(props.lightEmission)
+	block.SetLightFilterLevel(props.lightFilter)
+	block.SetLightDiffusing(props.lightFilter != filterNone)
+	if props.box != nil {
+		block.SetCollisionBox(props.box(data))
+	}
+	return block
+}
+
+// Returns the name of the block adapting to its variant.
+
+func (block *VanillaBlock) GetName() string {
+	var index = int(block.GetData() & block.variantMask)
+	if index < len(block.variants) && block.variants[index] != "" {
+		return block.variants[index]
+	}
+	return block.Block.GetName()
+}
+
+// Returns the names of the given variants prefixed to the given name.
+
+func prefixed(prefixes []string, name string) []string {
+	var names = make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		names[i] = prefix + " " + name
+	}
+	return names
+}
+
+// Returns a collision box function for blocks without collision box.
+
+func noBox(byte) *vectors.CubesBox {
+	return vectors.NewCubesBox([]*vectors.Cube{})
+}
+
+// Returns a collision box function for blocks that are lower than a full block.
+
+func heightBox(height float64) func(byte) *vectors.CubesBox {
+	return func(byte) *vectors.CubesBox {
+		return vectors.NewCubesBox([]*vectors.Cube{vectors.NewCube(0, 0, 0, 1, height, 1)})
+	}
+}
+
+// Returns a collision box function for blocks that are inset from the sides of the block, such as chests and cactus.
+
+func insetBox(inset, height float64) func(byte) *vectors.CubesBox {
+	return func(byte) *vectors.CubesBox {
+		return vectors.NewCubesBox([]*vectors.Cube{vectors.NewCube(inset, 0, inset, 1-inset, height, 1-inset)})
+	}
+}
+
+// Returns the collision box of a slab, which is in the upper half of the block if the top bit of its data is set.
+
+func slabBox(data byte) *vectors.CubesBox {
+	if data&8 != 0 {
+		return vectors.NewCubesBox([]*vectors.Cube{vectors.NewCube(0, 0.5, 0, 1, 1, 1)})
+	}
+	return vectors.NewCubesBox([]*vectors.Cube{vectors.NewCube(0, 0, 0, 1, 0.5, 1)})
+}
+
+// Returns the collision box of a snow layer, which grows an eighth of a block per layer.
+
+func snowLayerBox(data byte) *vectors.CubesBox {
+	if data&7 == 0 {
+		return noBox(data)
+	}
+	return heightBox(float64(data&7) / 8)(data)
+}
+
+// vanillaBlocks are the properties of all vanilla blocks.
+// Blast resistances are five times the blast resistance of the vanilla game, as used in explosion calculations.
+var vanillaBlocks = []properties{
+	{GRASS, "Grass", 0.6, 3, 0, filterSolid, nil, 0, nil},
+	{DIRT, "Dirt", 0.5, 2, 0, filterSolid, nil, 1, []string{"Dirt", "Coarse Dirt"}},
+	{COBBLESTONE, "Cobblestone", 2, 30, 0, filterSolid, nil, 0, nil},
+	{PLANKS, "Planks", 2, 15, 0, filterSolid, nil, 7, prefixed(woods, "Planks")},
+	{SAPLING, "Sapling", 0, 0, 0, filterNone, noBox, 7, prefixed(woods, "Sapling")},
+	{BEDROCK, "Bedrock", -1, 18000000, 0, filterSolid, nil, 0, nil},
+	{FLOWING_WATER, "Flowing Water", 100, 500, 0, filterWater, noBox, 0, nil},
+	{STILL_WATER, "Still Water", 100, 500, 0, filterWater, noBox, 0, nil},
+	{FLOWING_LAVA, "Flowing Lava", 100, 500, 15, filterNone, noBox, 0, nil},
+	{STILL_LAVA, "Still Lava", 100, 500, 15, filterNone, noBox, 0, nil},
+	{SAND, "Sand", 0.5, 2, 0, filterSolid, nil, 1, []string{"Sand", "Red Sand"}},
+	{GRAVEL, "Gravel", 0.6, 3, 0, filterSolid, nil, 0, nil},
+	{GOLD_ORE, "Gold Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{IRON_ORE, "Iron Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{COAL_ORE, "Coal Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{LOG, "Log", 2, 10, 0, filterSolid, nil, 3, prefixed(woods[:4], "Log")},
+	{LEAVES, "Leaves", 0.2, 1, 0, filterLeaves, nil, 3, prefixed(woods[:4], "Leaves")},
+	{SPONGE, "Sponge", 0.6, 3, 0, filterSolid, nil, 1, []string{"Sponge", "Wet Sponge"}},
+	{GLASS, "Glass", 0.3, 1, 0, filterNone, nil, 0, nil},
+	{LAPIS_ORE, "Lapis Lazuli Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{LAPIS_BLOCK, "Lapis Lazuli Block", 3, 15, 0, filterSolid, nil, 0, nil},
+	{DISPENSER, "Dispenser", 3.5, 17, 0, filterSolid, nil, 0, nil},
+	{SANDSTONE, "Sandstone", 0.8, 4, 0, filterSolid, nil, 3, []string{"Sandstone", "Chiseled Sandstone", "Smooth Sandstone"}},
+	{NOTE_BLOCK, "Note Block", 0.8, 4, 0, filterSolid, nil, 0, nil},
+	{BED, "Bed", 0.2, 1, 0, filterNone, heightBox(0.5625), 0, nil},
+	{POWERED_RAIL, "Powered Rail", 0.7, 3, 0, filterNone, noBox, 0, nil},
+	{DETECTOR_RAIL, "Detector Rail", 0.7, 3, 0, filterNone, noBox, 0, nil},
+	{STICKY_PISTON, "Sticky Piston", 0.5, 2, 0, filterNone, nil, 0, nil},
+	{COBWEB, "Cobweb", 4, 20, 0, filterLeaves, noBox, 0, nil},
+	{TALL_GRASS, "Tall Grass", 0, 0, 0, filterNone, noBox, 3, []string{"Shrub", "Tall Grass", "Fern", "Fern"}},
+	{DEAD_BUSH, "Dead Bush", 0, 0, 0, filterNone, noBox, 0, nil},
+	{PISTON, "Piston", 0.5, 2, 0, filterNone, nil, 0, nil},
+	{PISTON_ARM_COLLISION, "Piston Head", 0.5, 2, 0, filterNone, nil, 0, nil},
+	{WOOL, "Wool", 0.8, 4, 0, filterSolid, nil, 15, prefixed(colors, "Wool")},
+	{ELEMENT_0, "Element 0", 0, 0, 0, filterSolid, nil, 0, nil},
+	{DANDELION, "Dandelion", 0, 0, 0, filterNone, noBox, 0, nil},
+	{RED_FLOWER, "Flower", 0, 0, 0, filterNone, noBox, 15, []string{"Poppy", "Blue Orchid", "Allium", "Azure Bluet", "Red Tulip", "Orange Tulip", "White Tulip", "Pink Tulip", "Oxeye Daisy"}},
+	{BROWN_MUSHROOM, "Brown Mushroom", 0, 0, 1, filterNone, noBox, 0, nil},
+	{RED_MUSHROOM, "Red Mushroom", 0, 0, 0, filterNone, noBox, 0, nil},
+	{GOLD_BLOCK, "Gold Block", 3, 30, 0, filterSolid, nil, 0, nil},
+	{IRON_BLOCK, "Iron Block", 5, 30, 0, filterSolid, nil, 0, nil},
+	{DOUBLE_STONE_SLAB, "Double Stone Slab", 2, 30, 0, filterSolid, nil, 7, prefixed([]string{"Stone", "Sandstone", "Wooden", "Cobblestone", "Brick", "Stone Brick", "Quartz", "Nether Brick"}, "Double Slab")},
+	{STONE_SLAB, "Stone Slab", 2, 30, 0, filterNone, slabBox, 7, prefixed([]string{"Stone", "Sandstone", "Wooden", "Cobblestone", "Brick", "Stone Brick", "Quartz", "Nether Brick"}, "Slab")},
+	{BRICK_BLOCK, "Bricks", 2, 30, 0, filterSolid, nil, 0, nil},
+	{TNT, "TNT", 0, 0, 0, filterSolid, nil, 0, nil},
+	{BOOKSHELF, "Bookshelf", 1.5, 7, 0, filterSolid, nil, 0, nil},
+	{MOSSY_COBBLESTONE, "Moss Stone", 2, 30, 0, filterSolid, nil, 0, nil},
+	{OBSIDIAN, "Obsidian", 50, 6000, 0, filterSolid, nil, 0, nil},
+	{TORCH, "Torch", 0, 0, 14, filterNone, noBox, 0, nil},
+	{FIRE, "Fire", 0, 0, 15, filterNone, noBox, 0, nil},
+	{MOB_SPAWNER, "Monster Spawner", 5, 25, 0, filterNone, nil, 0, nil},
+	{OAK_STAIRS, "Oak Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{CHEST, "Chest", 2.5, 12, 0, filterNone, insetBox(0.0625, 0.875), 0, nil},
+	{REDSTONE_WIRE, "Redstone Wire", 0, 0, 0, filterNone, noBox, 0, nil},
+	{DIAMOND_ORE, "Diamond Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{DIAMOND_BLOCK, "Diamond Block", 5, 30, 0, filterSolid, nil, 0, nil},
+	{CRAFTING_TABLE, "Crafting Table", 2.5, 12, 0, filterSolid, nil, 0, nil},
+	{WHEAT, "Wheat", 0, 0, 0, filterNone, noBox, 0, nil},
+	{FARMLAND, "Farmland", 0.6, 3, 0, filterNone, heightBox(0.9375), 0, nil},
+	{FURNACE, "Furnace", 3.5, 17, 0, filterSolid, nil, 0, nil},
+	{BURNING_FURNACE, "Burning Furnace", 3.5, 17, 13, filterSolid, nil, 0, nil},
+	{STANDING_SIGN, "Sign", 1, 5, 0, filterNone, noBox, 0, nil},
+	{OAK_DOOR, "Oak Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{LADDER, "Ladder", 0.4, 2, 0, filterNone, noBox, 0, nil},
+	{RAIL, "Rail", 0.7, 3, 0, filterNone, noBox, 0, nil},
+	{COBBLESTONE_STAIRS, "Cobblestone Stairs", 2, 30, 0, filterNone, nil, 0, nil},
+	{WALL_SIGN, "Wall Sign", 1, 5, 0, filterNone, noBox, 0, nil},
+	{LEVER, "Lever", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{STONE_PRESSURE_PLATE, "Stone Pressure Plate", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{IRON_DOOR, "Iron Door", 5, 25, 0, filterNone, nil, 0, nil},
+	{WOODEN_PRESSURE_PLATE, "Wooden Pressure Plate", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{REDSTONE_ORE, "Redstone Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{GLOWING_REDSTONE_ORE, "Glowing Redstone Ore", 3, 15, 9, filterSolid, nil, 0, nil},
+	{UNLIT_REDSTONE_TORCH, "Unlit Redstone Torch", 0, 0, 0, filterNone, noBox, 0, nil},
+	{REDSTONE_TORCH, "Redstone Torch", 0, 0, 7, filterNone, noBox, 0, nil},
+	{STONE_BUTTON, "Stone Button", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{SNOW_LAYER, "Snow Layer", 0.1, 0, 0, filterNone, snowLayerBox, 0, nil},
+	{ICE, "Ice", 0.5, 2, 0, filterWater, nil, 0, nil},
+	{SNOW, "Snow", 0.2, 1, 0, filterSolid, nil, 0, nil},
+	{CACTUS, "Cactus", 0.4, 2, 0, filterNone, insetBox(0.0625, 1), 0, nil},
+	{CLAY, "Clay", 0.6, 3, 0, filterSolid, nil, 0, nil},
+	{SUGARCANE, "Sugarcane", 0, 0, 0, filterNone, noBox, 0, nil},
+	{JUKEBOX, "Jukebox", 2, 30, 0, filterSolid, nil, 0, nil},
+	{FENCE, "Fence", 2, 15, 0, filterNone, heightBox(1.5), 7, prefixed(woods, "Fence")},
+	{PUMPKIN, "Pumpkin", 1, 5, 0, filterSolid, nil, 0, nil},
+	{NETHERRACK, "Netherrack", 0.4, 2, 0, filterSolid, nil, 0, nil},
+	{SOUL_SAND, "Soul Sand", 0.5, 2, 0, filterSolid, heightBox(0.875), 0, nil},
+	{GLOWSTONE, "Glowstone", 0.3, 1, 15, filterNone, nil, 0, nil},
+	{PORTAL, "Nether Portal", -1, 0, 11, filterNone, noBox, 0, nil},
+	{LIT_PUMPKIN, "Jack o'Lantern", 1, 5, 15, filterSolid, nil, 0, nil},
+	{CAKE, "Cake", 0.5, 2, 0, filterNone, insetBox(0.0625, 0.5), 0, nil},
+	{UNPOWERED_REPEATER, "Redstone Repeater", 0, 0, 0, filterNone, heightBox(0.125), 0, nil},
+	{POWERED_REPEATER, "Powered Redstone Repeater", 0, 0, 0, filterNone, heightBox(0.125), 0, nil},
+	{INVISIBLE_BEDROCK, "Invisible Bedrock", -1, 18000000, 0, filterNone, nil, 0, nil},
+	{TRAPDOOR, "Trapdoor", 3, 15, 0, filterNone, heightBox(0.1875), 0, nil},
+	{MONSTER_EGG, "Monster Egg", 0.75, 3, 0, filterSolid, nil, 7, prefixed([]string{"Stone", "Cobblestone", "Stone Brick", "Mossy Stone Brick", "Cracked Stone Brick", "Chiseled Stone Brick"}, "Monster Egg")},
+	{STONE_BRICK, "Stone Bricks", 1.5, 30, 0, filterSolid, nil, 3, []string{"Stone Bricks", "Mossy Stone Bricks", "Cracked Stone Bricks", "Chiseled Stone Bricks"}},
+	{BROWN_MUSHROOM_BLOCK, "Brown Mushroom Block", 0.2, 1, 0, filterSolid, nil, 0, nil},
+	{RED_MUSHROOM_BLOCK, "Red Mushroom Block", 0.2, 1, 0, filterSolid, nil, 0, nil},
+	{IRON_BARS, "Iron Bars", 5, 30, 0, filterNone, nil, 0, nil},
+	{GLASS_PANE, "Glass Pane", 0.3, 1, 0, filterNone, nil, 0, nil},
+	{MELON_BLOCK, "Melon Block", 1, 5, 0, filterSolid, nil, 0, nil},
+	{PUMPKIN_STEM, "Pumpkin Stem", 0, 0, 0, filterNone, noBox, 0, nil},
+	{MELON_STEM, "Melon Stem", 0, 0, 0, filterNone, noBox, 0, nil},
+	{VINES, "Vines", 0.2, 1, 0, filterNone, noBox, 0, nil},
+	{FENCE_GATE, "Oak Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{BRICK_STAIRS, "Brick Stairs", 2, 30, 0, filterNone, nil, 0, nil},
+	{STONE_BRICK_STAIRS, "Stone Brick Stairs", 1.5, 30, 0, filterNone, nil, 0, nil},
+	{MYCELIUM, "Mycelium", 0.6, 3, 0, filterSolid, nil, 0, nil},
+	{LILY_PAD, "Lily Pad", 0, 0, 0, filterNone, heightBox(0.015625), 0, nil},
+	{NETHER_BRICK, "Nether Bricks", 2, 30, 0, filterSolid, nil, 0, nil},
+	{NETHER_BRICK_FENCE, "Nether Brick Fence", 2, 30, 0, filterNone, heightBox(1.5), 0, nil},
+	{NETHER_BRICK_STAIRS, "Nether Brick Stairs", 2, 30, 0, filterNone, nil, 0, nil},
+	{NETHER_WART, "Nether Wart", 0, 0, 0, filterNone, noBox, 0, nil},
+	{ENCHANTING_TABLE, "Enchanting Table", 5, 6000, 0, filterNone, heightBox(0.75), 0, nil},
+	{BREWING_STAND, "Brewing Stand", 0.5, 2, 1, filterNone, nil, 0, nil},
+	{CAULDRON, "Cauldron", 2, 10, 0, filterNone, nil, 0, nil},
+	{END_PORTAL, "End Portal", -1, 18000000, 15, filterNone, noBox, 0, nil},
+	{END_PORTAL_FRAME, "End Portal Frame", -1, 18000000, 1, filterNone, heightBox(0.8125), 0, nil},
+	{END_STONE, "End Stone", 3, 45, 0, filterSolid, nil, 0, nil},
+	{DRAGON_EGG, "Dragon Egg", 3, 45, 1, filterNone, insetBox(0.0625, 1), 0, nil},
+	{REDSTONE_LAMP, "Redstone Lamp", 0.3, 1, 0, filterSolid, nil, 0, nil},
+	{LIT_REDSTONE_LAMP, "Lit Redstone Lamp", 0.3, 1, 15, filterSolid, nil, 0, nil},
+	{DROPPER, "Dropper", 3.5, 17, 0, filterSolid, nil, 0, nil},
+	{ACTIVATOR_RAIL, "Activator Rail", 0.7, 3, 0, filterNone, noBox, 0, nil},
+	{COCOA, "Cocoa", 0.2, 15, 0, filterNone, nil, 0, nil},
+	{SANDSTONE_STAIRS, "Sandstone Stairs", 0.8, 4, 0, filterNone, nil, 0, nil},
+	{EMERALD_ORE, "Emerald Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{ENDER_CHEST, "Ender Chest", 22.5, 3000, 7, filterNone, insetBox(0.0625, 0.875), 0, nil},
+	{TRIPWIRE_HOOK, "Tripwire Hook", 0, 0, 0, filterNone, noBox, 0, nil},
+	{TRIPWIRE, "Tripwire", 0, 0, 0, filterNone, noBox, 0, nil},
+	{EMERALD_BLOCK, "Emerald Block", 5, 30, 0, filterSolid, nil, 0, nil},
+	{SPRUCE_STAIRS, "Spruce Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{BIRCH_STAIRS, "Birch Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{JUNGLE_STAIRS, "Jungle Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{COMMAND_BLOCK, "Command Block", -1, 18000000, 0, filterSolid, nil, 0, nil},
+	{BEACON, "Beacon", 3, 15, 15, filterNone, nil, 0, nil},
+	{COBBLESTONE_WALL, "Cobblestone Wall", 2, 30, 0, filterNone, heightBox(1.5), 1, []string{"Cobblestone Wall", "Mossy Cobblestone Wall"}},
+	{FLOWER_POT, "Flower Pot", 0, 0, 0, filterNone, insetBox(0.3125, 0.375), 0, nil},
+	{CARROTS, "Carrots", 0, 0, 0, filterNone, noBox, 0, nil},
+	{POTATOES, "Potatoes", 0, 0, 0, filterNone, noBox, 0, nil},
+	{WOODEN_BUTTON, "Wooden Button", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{SKULL, "Mob Head", 1, 5, 0, filterNone, insetBox(0.25, 0.5), 0, nil},
+	{ANVIL, "Anvil", 5, 6000, 0, filterNone, nil, 0, nil},
+	{TRAPPED_CHEST, "Trapped Chest", 2.5, 12, 0, filterNone, insetBox(0.0625, 0.875), 0, nil},
+	{LIGHT_WEIGHTED_PRESSURE_PLATE, "Weighted Pressure Plate (Light)", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{HEAVY_WEIGHTED_PRESSURE_PLATE, "Weighted Pressure Plate (Heavy)", 0.5, 2, 0, filterNone, noBox, 0, nil},
+	{UNPOWERED_COMPARATOR, "Redstone Comparator", 0, 0, 0, filterNone, heightBox(0.125), 0, nil},
+	{POWERED_COMPARATOR, "Powered Redstone Comparator", 0, 0, 0, filterNone, heightBox(0.125), 0, nil},
+	{DAYLIGHT_SENSOR, "Daylight Sensor", 0.2, 1, 0, filterNone, heightBox(0.375), 0, nil},
+	{REDSTONE_BLOCK, "Redstone Block", 5, 30, 0, filterSolid, nil, 0, nil},
+	{NETHER_QUARTZ_ORE, "Nether Quartz Ore", 3, 15, 0, filterSolid, nil, 0, nil},
+	{HOPPER, "Hopper", 3, 24, 0, filterNone, nil, 0, nil},
+	{QUARTZ_BLOCK, "Quartz Block", 0.8, 4, 0, filterSolid, nil, 3, []string{"Quartz Block", "Chiseled Quartz Block", "Quartz Pillar"}},
+	{QUARTZ_STAIRS, "Quartz Stairs", 0.8, 4, 0, filterNone, nil, 0, nil},
+	{DOUBLE_WOODEN_SLAB, "Double Wooden Slab", 2, 15, 0, filterSolid, nil, 7, prefixed(woods, "Double Slab")},
+	{WOODEN_SLAB, "Wooden Slab", 2, 15, 0, filterNone, slabBox, 7, prefixed(woods, "Slab")},
+	{STAINED_CLAY, "Stained Terracotta", 1.25, 21, 0, filterSolid, nil, 15, prefixed(colors, "Terracotta")},
+	{STAINED_GLASS_PANE, "Stained Glass Pane", 0.3, 1, 0, filterNone, nil, 15, prefixed(colors, "Stained Glass Pane")},
+	{LEAVES2, "Leaves", 0.2, 1, 0, filterLeaves, nil, 1, prefixed(woods[4:], "Leaves")},
+	{LOG2, "Log", 2, 10, 0, filterSolid, nil, 1, prefixed(woods[4:], "Log")},
+	{ACACIA_STAIRS, "Acacia Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{DARK_OAK_STAIRS, "Dark Oak Stairs", 2, 15, 0, filterNone, nil, 0, nil},
+	{SLIME_BLOCK, "Slime Block", 0, 0, 0, filterNone, nil, 0, nil},
+	{IRON_TRAPDOOR, "Iron Trapdoor", 5, 25, 0, filterNone, heightBox(0.1875), 0, nil},
+	{PRISMARINE, "Prismarine", 1.5, 30, 0, filterSolid, nil, 3, []string{"Prismarine", "Dark Prismarine", "Prismarine Bricks"}},
+	{SEA_LANTERN, "Sea Lantern", 0.3, 1, 15, filterSolid, nil, 0, nil},
+	{HAY_BALE, "Hay Bale", 0.5, 2, 0, filterSolid, nil, 0, nil},
+	{CARPET, "Carpet", 0.1, 0, 0, filterNone, heightBox(0.0625), 15, prefixed(colors, "Carpet")},
+	{HARDENED_CLAY, "Terracotta", 1.25, 21, 0, filterSolid, nil, 0, nil},
+	{COAL_BLOCK, "Coal Block", 5, 30, 0, filterSolid, nil, 0, nil},
+	{PACKED_ICE, "Packed Ice", 0.5, 2, 0, filterSolid, nil, 0, nil},
+	{DOUBLE_PLANT, "Double Plant", 0, 0, 0, filterNone, noBox, 7, []string{"Sunflower", "Lilac", "Double Tallgrass", "Large Fern", "Rose Bush", "Peony"}},
+	{STANDING_BANNER, "Banner", 1, 5, 0, filterNone, noBox, 0, nil},
+	{WALL_BANNER, "Wall Banner", 1, 5, 0, filterNone, noBox, 0, nil},
+	{DAYLIGHT_SENSOR_INVERTED, "Inverted Daylight Sensor", 0.2, 1, 0, filterNone, heightBox(0.375), 0, nil},
+	{RED_SANDSTONE, "Red Sandstone", 0.8, 4, 0, filterSolid, nil, 3, []string{"Red Sandstone", "Chiseled Red Sandstone", "Smooth Red Sandstone"}},
+	{RED_SANDSTONE_STAIRS, "Red Sandstone Stairs", 0.8, 4, 0, filterNone, nil, 0, nil},
+	{DOUBLE_STONE_SLAB2, "Double Red Sandstone Slab", 2, 30, 0, filterSolid, nil, 7, []string{"Double Red Sandstone Slab", "Double Purpur Slab"}},
+	{STONE_SLAB2, "Red Sandstone Slab", 2, 30, 0, filterNone, slabBox, 7, []string{"Red Sandstone Slab", "Purpur Slab"}},
+	{SPRUCE_FENCE_GATE, "Spruce Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{BIRCH_FENCE_GATE, "Birch Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{JUNGLE_FENCE_GATE, "Jungle Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{DARK_OAK_FENCE_GATE, "Dark Oak Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{ACACIA_FENCE_GATE, "Acacia Fence Gate", 2, 15, 0, filterNone, heightBox(1.5), 0, nil},
+	{REPEATING_COMMAND_BLOCK, "Repeating Command Block", -1, 18000000, 0, filterSolid, nil, 0, nil},
+	{CHAIN_COMMAND_BLOCK, "Chain Command Block", -1, 18000000, 0, filterSolid, nil, 0, nil},
+	{SPRUCE_DOOR, "Spruce Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{BIRCH_DOOR, "Birch Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{JUNGLE_DOOR, "Jungle Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{ACACIA_DOOR, "Acacia Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{DARK_OAK_DOOR, "Dark Oak Door", 3, 15, 0, filterNone, nil, 0, nil},
+	{GRASS_PATH, "Grass Path", 0.6, 3, 0, filterNone, heightBox(0.9375), 0, nil},
+	{ITEM_FRAME, "Item Frame", 0.25, 1, 0, filterNone, noBox, 0, nil},
+	{CHORUS_FLOWER, "Chorus Flower", 0.4, 2, 0, filterNone, nil, 0, nil},
+	{PURPUR_BLOCK, "Purpur Block", 1.5, 30, 0, filterSolid, nil, 3, []string{"Purpur Block", "", "Purpur Pillar"}},
+	{PURPUR_STAIRS, "Purpur Stairs", 1.5, 30, 0, filterNone, nil, 0, nil},
+	{UNDYED_SHULKER_BOX, "Shulker Box", 2, 10, 0, filterNone, nil, 0, nil},
+	{END_BRICKS, "End Stone Bricks", 0.8, 4, 0, filterSolid, nil, 0, nil},
+	{FROSTED_ICE, "Frosted Ice", 0.5, 2, 0, filterWater, nil, 0, nil},
+	{END_ROD, "End Rod", 0, 0, 14, filterNone, insetBox(0.375, 1), 0, nil},
+	{END_GATEWAY, "End Gateway", -1, 18000000, 15, filterNone, noBox, 0, nil},
+	{MAGMA, "Magma Block", 0.5, 2, 3, filterSolid, nil, 0, nil},
+	{NETHER_WART_BLOCK, "Nether Wart Block", 1, 5, 0, filterSolid, nil, 0, nil},
+	{RED_NETHER_BRICK, "Red Nether Bricks", 2, 30, 0, filterSolid, nil, 0, nil},
+	{BONE_BLOCK, "Bone Block", 2, 10, 0, filterSolid, nil, 0, nil},
+	{STRUCTURE_VOID, "Structure Void", 0, 0, 0, filterNone, noBox, 0, nil},
+	{SHULKER_BOX, "Shulker Box", 2, 10, 0, filterNone, nil, 15, prefixed(colors, "Shulker Box")},
+	{PURPLE_GLAZED_TERRACOTTA, "Purple Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{WHITE_GLAZED_TERRACOTTA, "White Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{ORANGE_GLAZED_TERRACOTTA, "Orange Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{MAGENTA_GLAZED_TERRACOTTA, "Magenta Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{LIGHT_BLUE_GLAZED_TERRACOTTA, "Light Blue Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{YELLOW_GLAZED_TERRACOTTA, "Yellow Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{LIME_GLAZED_TERRACOTTA, "Lime Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{PINK_GLAZED_TERRACOTTA, "Pink Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{GRAY_GLAZED_TERRACOTTA, "Gray Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{SILVER_GLAZED_TERRACOTTA, "Light Gray Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{CYAN_GLAZED_TERRACOTTA, "Cyan Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{BLUE_GLAZED_TERRACOTTA, "Blue Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{BROWN_GLAZED_TERRACOTTA, "Brown Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{GREEN_GLAZED_TERRACOTTA, "Green Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{RED_GLAZED_TERRACOTTA, "Red Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{BLACK_GLAZED_TERRACOTTA, "Black Glazed Terracotta", 1.4, 7, 0, filterSolid, nil, 0, nil},
+	{CONCRETE, "Concrete", 1.8, 9, 0, filterSolid, nil, 15, prefixed(colors, "Concrete")},
+	{CONCRETE_POWDER, "Concrete Powder", 0.5, 2, 0, filterSolid, nil, 15, prefixed(colors, "Concrete Powder")},
+	{CHORUS_PLANT, "Chorus Plant", 0.4, 2, 0, filterNone, nil, 0, nil},
+	{STAINED_GLASS, "Stained Glass", 0.3, 1, 0, filterNone, nil, 15, prefixed(colors, "Stained Glass")},
+	{PODZOL, "Podzol", 0.5, 2, 0, filterSolid, nil, 0, nil},
+	{BEETROOT, "Beetroot", 0, 0, 0, filterNone, noBox, 0, nil},
+	{STONECUTTER, "Stonecutter", 3.5, 17, 0, filterSolid, nil, 0, nil},
+	{GLOWING_OBSIDIAN, "Glowing Obsidian", 10, 6000, 12, filterSolid, nil, 0, nil},
+	{NETHER_REACTOR, "Nether Reactor Core", 3, 30, 0, filterSolid, nil, 0, nil},
+	{INFO_UPDATE, "Update Block", 1, 5, 0, filterSolid, nil, 0, nil},
+	{INFO_UPDATE2, "Update Block", 1, 5, 0, filterSolid, nil, 0, nil},
+	{MOVING_BLOCK, "Moving Block", -1, 0, 0, filterNone, noBox, 0, nil},
+	{OBSERVER, "Observer", 3, 17, 0, filterSolid, nil, 0, nil},
+	{STRUCTURE_BLOCK, "Structure Block", -1, 18000000, 0, filterSolid, nil, 0, nil},
+	{RESERVED6, "Reserved", 0, 0, 0, filterSolid, nil, 0, nil},
+}
@@ -1,11 +1,14 @@
 package chunks
 
 import (
+	"bytes"
 	"errors"
 	"sync"
 
 	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/nbt"
 	"github.com/irmine/gomine/tiles"
+	"github.com/irmine/gomine/worlds/blocks"
 	"github.com/irmine/binutils"
 )
 
@@ -15,11 +18,20 @@ type Chunk struct {
 	subChunks        map[int]interfaces.ISubChunk
 	LightPopulated   bool
 	TerrainPopulated bool
-	tiles            map[uint64]tiles.Tile
+	tiles            map[int]interfaces.ITile
 	entities         map[uint64]interfaces.IEntity
-	biomes           map[int]int
-	heightMap        [257]int16
+	biomes           [256]byte
+	heightMap        [256]int16
 	viewers          sync.Map
+	entityNBT        []byte
+	unknownTiles     []nbt.Compound
+
+	cacheMutex  sync.Mutex
+	version     uint64
+	payload     []byte
+	batches     map[int32][]byte
+	encodeMutex sync.Mutex
+	batchMutex  sync.Mutex
 }
 
 func NewChunk(x, z int32) *Chunk {
@@ -28,13 +40,21 @@ func NewChunk(x, z int32) *Chunk {
 		x,
 		z,
 		make(map[int]interfaces.ISubChunk),
-		true,
-		true,
-		make(map[uint64]tiles.Tile),
+		false,
+		false,
+		make(map[int]interfaces.ITile),
 		make(map[uint64]interfaces.IEntity),
-		make(map[int]int),
-		[257]int16{},
+		[256]byte{},
+		[256]int16{},
 		sync.Map{},
+		[]byte{},
+		nil,
+		sync.Mutex{},
+		0,
+		nil,
+		nil,
+		sync.Mutex{},
+		sync.Mutex{},
 	}
 }
 
@@ -90,7 +110,7 @@ func (chunk *Chunk) SetLightPopulated(v bool) {
 // Returns if this chunk is terrain populated.
 
 func (chunk *Chunk) IsTerrainPopulated() bool {
-	return chunk.LightPopulated
+	return chunk.TerrainPopulated
 }
 
 // Sets this chunk terrain populated.
@@ -114,13 +134,14 @@ func (chunk *Chunk) SetHeight(height int) {
 // Returns the biome of this coordinate. (?)
 
 func (chunk *Chunk) GetBiome(x, z int) int {
-	return chunk.biomes[chunk.GetBiomeIndex(x, z)]
+	return int(chunk.biomes[chunk.GetBiomeIndex(x, z)])
 }
 
 // Sets the biome of this coordinate. (?)
 
 func (chunk *Chunk) SetBiome(x, z, biome int) {
-	chunk.biomes[chunk.GetBiomeIndex(x, z)] = biome
+	chunk.biomes[chunk.GetBiomeIndex(x, z)] = byte(biome)
+	chunk.MarkDirty()
 }
 
 // Adds a new entity to this chunk.
@@ -147,24 +168,114 @@ func (chunk *Chunk) GetEntities() map[uint64]interfaces.IEntity {
 	return chunk.entities
 }
 
-func (chunk *Chunk) AddTile(tile tiles.Tile) bool {
+// Returns the raw little endian NBT of all entities saved in this chunk.
+
+func (chunk *Chunk) GetEntityNBT() []byte {
+	return chunk.entityNBT
+}
+
+// Sets the raw little endian NBT of all entities saved in this chunk.
+
+func (chunk *Chunk) SetEntityNBT(data []byte) {
+	chunk.entityNBT = data
+}
+
+// Returns the little endian NBT of all tiles in this chunk, as saved on disk.
+// Tiles of unknown types that were loaded with the chunk are saved unchanged.
+// Tiles of which the NBT can not be encoded are left out, and the first error encountered is returned.
+
+func (chunk *Chunk) GetTileNBT() ([]byte, error) {
+	var buffer = bytes.NewBuffer(nil)
+	var encodeErr error
+	for _, tile := range chunk.tiles {
+		if err := appendNBT(buffer, nbt.LittleEndian, tile.Save()); err != nil && encodeErr == nil {
+			encodeErr = err
+		}
+	}
+	for _, compound := range chunk.unknownTiles {
+		if err := appendNBT(buffer, nbt.LittleEndian, compound); err != nil && encodeErr == nil {
+			encodeErr = err
+		}
+	}
+	return buffer.Bytes(), encodeErr
+}
+
+// Loads the tiles of this chunk from the given little endian NBT, as saved on disk.
+// Tiles of types that are not registered are kept, so that they get saved again.
+
+func (chunk *Chunk) SetTileNBT(data []byte) {
+	var decoder = nbt.NewDecoder(bytes.NewReader(data), nbt.LittleEndian)
+	for {
+		var compound, err = decoder.Decode()
+		if err != nil {
+			return
+		}
+		if tile := tiles.NewTileFromNBT(compound); tile != nil {
+			chunk.AddTile(tile)
+		} else {
+			chunk.unknownTiles = append(chunk.unknownTiles, compound)
+		}
+	}
+}
+
+// Adds a tile to this chunk, replacing any tile at the same position.
+
+func (chunk *Chunk) AddTile(tile interfaces.ITile) bool {
 	if tile.IsClosed() {
-		panic("Cannot add closed entity to chunk")
+		panic("Cannot add closed tile to chunk")
 	}
-	chunk.tiles[tile.GetId()] = tile
+	chunk.tiles[chunk.GetIndex(tile.GetX()&15, tile.GetY(), tile.GetZ()&15)] = tile
+	chunk.MarkDirty()
 	return true
 }
 
-func (chunk *Chunk) RemoveTile(tile tiles.Tile) {
-	if k, ok := chunk.entities[tile.GetId()]; ok {
-		delete(chunk.entities, k.GetRuntimeId())
+// Removes a tile from this chunk.
+
+func (chunk *Chunk) RemoveTile(tile interfaces.ITile) {
+	var index = chunk.GetIndex(tile.GetX()&15, tile.GetY(), tile.GetZ()&15)
+	if current, ok := chunk.tiles[index]; ok && current.GetId() == tile.GetId() {
+		delete(chunk.tiles, index)
+		chunk.MarkDirty()
 	}
 }
 
+// Returns the tile at the given position in this chunk, or nil if there is no tile at the position.
+
+func (chunk *Chunk) GetTile(x, y, z int) interfaces.ITile {
+	return chunk.tiles[chunk.GetIndex(x, y, z)]
+}
+
+// Returns all tiles in this chunk.
+
+func (chunk *Chunk) GetTiles() map[int]interfaces.ITile {
+	return chunk.tiles
+}
+
+// Writes the network NBT of all tiles in this chunk to the given buffer, as sent in the chunk payload.
+// Tiles of which the NBT can not be encoded are not sent.
+
+func (chunk *Chunk) writeTiles(buffer *bytes.Buffer) {
+	for _, tile := range chunk.tiles {
+		appendNBT(buffer, nbt.NetworkLittleEndian, tile.GetSpawnCompound())
+	}
+}
+
+// Appends the compound to the buffer, encoded with the given encoding.
+// Nothing gets appended if the compound can not be encoded, so that the compounds before and after it stay readable.
+
+func appendNBT(buffer *bytes.Buffer, encoding nbt.Encoding, compound nbt.Compound) error {
+	var data, err = nbt.Marshal(encoding, compound)
+	if err != nil {
+		return err
+	}
+	buffer.Write(data)
+	return nil
+}
+
 // Returns the biome index of a coordinate in a chunk.
 
 func (chunk *Chunk) GetBiomeIndex(x, z int) int {
-	return (x << 4) | z
+	return (z << 4) | x
 }
 
 // Returns the index of a position in a chunk.
@@ -190,13 +301,13 @@ func (chunk *Chunk) SetBlockId(x, y, z int, blockId byte) {
 		sub.SetBlockId(x, y&15, z, blockId)
 		chunk.SetSubChunk(y>>4, sub)
 	}
+	chunk.MarkDirty()
 }
 
 // Returns the block ID on a position in this chunk.
 
 func (chunk *Chunk) GetBlockId(x, y, z int) byte {
-	v, err := chunk.GetSubChunk(y >> 4)
-	if err == nil {
+	if v, ok := chunk.subChunks[y>>4]; ok {
 		return v.GetBlockId(x, y&15, z)
 	}
 	return 0
@@ -208,14 +319,14 @@ func (chunk *Chunk) SetBlockData(x, y, z int, data byte) {
 	v, err := chunk.GetSubChunk(y >> 4)
 	if err == nil {
 		v.SetBlockData(x, y&15, z, data)
+		chunk.MarkDirty()
 	}
 }
 
 // Returns the block data on a position in this chunk.
 
 func (chunk *Chunk) GetBlockData(x, y, z int) byte {
-	v, err := chunk.GetSubChunk(y >> 4)
-	if err == nil {
+	if v, ok := chunk.subChunks[y>>4]; ok {
 		return v.GetBlockData(x, y&15, z)
 	}
 	return 0
@@ -227,14 +338,14 @@ func (chunk *Chunk) SetBlockLight(x, y, z int, level byte) {
 	v, err := chunk.GetSubChunk(y >> 4)
 	if err == nil {
 		v.SetBlockLight(x, y&15, z, level)
+		chunk.MarkDirty()
 	}
 }
 
 // Returns the block light on a position in this chunk.
 
 func (chunk *Chunk) GetBlockLight(x, y, z int) byte {
-	v, err := chunk.GetSubChunk(y >> 4)
-	if err == nil {
+	if v, ok := chunk.subChunks[y>>4]; ok {
 		return v.GetBlockLight(x, y&15, z)
 	}
 	return 0
@@ -246,14 +357,14 @@ func (chunk *Chunk) SetSkyLight(x, y, z int, level byte) {
 	v, err := chunk.GetSubChunk(y >> 4)
 	if err == nil {
 		v.SetSkyLight(x, y&15, z, level)
+		chunk.MarkDirty()
 	}
 }
 
 // Returns the sky light on a position in this chunk.
 
 func (chunk *Chunk) GetSkyLight(x, y, z int) byte {
-	v, err := chunk.GetSubChunk(y >> 4)
-	if err == nil {
+	if v, ok := chunk.subChunks[y>>4]; ok {
 		return v.GetSkyLight(x, y&15, z)
 	}
 	return 0
@@ -262,17 +373,19 @@ func (chunk *Chunk) GetSkyLight(x, y, z int) byte {
 // Sets a SubChunk on a position in this chunk.
 
 func (chunk *Chunk) SetSubChunk(y int, subChunk interfaces.ISubChunk) bool {
-	if y > chunk.height || y < 0 {
+	if y >= chunk.height>>4 || y < 0 {
 		return false
 	}
 	chunk.subChunks[y] = subChunk
+	chunk.MarkDirty()
 	return true
 }
 
 // Returns a SubChunk on a given height index in this chunk.
+// A new SubChunk gets created if none exists at the height index yet.
 
 func (chunk *Chunk) GetSubChunk(y int) (interfaces.ISubChunk, error) {
-	if y > chunk.height || y < 0 {
+	if y >= chunk.height>>4 || y < 0 {
 		return NewEmptySubChunk(), errors.New("SubChunk does not exist")
 	}
 	if _, ok := chunk.subChunks[y]; ok {
@@ -292,6 +405,7 @@ func (chunk *Chunk) GetSubChunks() map[int]interfaces.ISubChunk {
 
 func (chunk *Chunk) SetHeightMap(x, z int, value int16) {
 	chunk.heightMap[chunk.GetHeightMapIndex(x, z)] = value
+	chunk.MarkDirty()
 }
 
 // Returns the height in the HeightMap on the given index.
@@ -305,16 +419,28 @@ func (chunk *Chunk) GetHeightMap(x, z int) int16 {
 func (chunk *Chunk) RecalculateHeightMap() {
 	for x := 0; x < 16; x++ {
 		for z := 0; z < 16; z++ {
+			chunk.RecalculateHeightMapColumn(x, z)
+		}
+	}
+}
 
-			id := int(chunk.GetHighestBlockId(x, z))
+// Recalculates the HeightMap value of a single column.
+// The height is one above the highest block that filters light, or 0 if the column has none.
 
-			if GetLightFilter(id) > 0 && !DiffusesLight(id) {
-				break
+func (chunk *Chunk) RecalculateHeightMapColumn(x, z int) {
+	for y := 15; y >= 0; y-- {
+		var subChunk, ok = chunk.subChunks[y]
+		if !ok {
+			continue
+		}
+		for blockY := 15; blockY >= 0; blockY-- {
+			if blocks.GetLightFilter(subChunk.GetBlockId(x, blockY, z)) > 0 {
+				chunk.SetHeightMap(x, z, int16(y<<4|blockY)+1)
+				return
 			}
-
-			chunk.SetHeightMap(x, z, chunk.GetHighestBlock(x, z)+1)
 		}
 	}
+	chunk.SetHeightMap(x, z, 0)
 }
 
 // Returns highest SubChunk in this chunk
@@ -346,54 +472,182 @@ func (chunk *Chunk) GetHighestBlockData(x, z int) byte {
 	return chunk.GetHighestSubChunk().GetHighestBlockData(x, z)
 }
 
-// Returns highest light filtering block at certain x, z coordinates in this chunk
+// Returns the Y coordinate of the highest non-air block at certain x, z coordinates in this chunk.
+// Returns 0 if the column is empty.
 
 func (chunk *Chunk) GetHighestBlock(x, z int) int16 {
-	return int16(chunk.GetHighestSubChunk().GetHighestBlock(x, z))
+	for y := 15; y >= 0; y-- {
+		var subChunk, ok = chunk.subChunks[y]
+		if !ok || subChunk.IsAllAir() {
+			continue
+		}
+		for blockY := 15; blockY >= 0; blockY-- {
+			if subChunk.GetBlockId(x, blockY, z) != 0 {
+				return int16(y<<4 | blockY)
+			}
+		}
+	}
+	return 0
 }
 
-// Returns the count of non-empty SubChunks in this chunk.
+// Returns the count of SubChunks up to and including the highest non-empty SubChunk in this chunk.
+// SubChunks that PruneEmptySubChunks would remove are not counted, but the chunk itself is left unchanged.
 
 func (chunk *Chunk) GetFilledSubChunks() byte {
-	chunk.PruneEmptySubChunks()
-	return byte(len(chunk.subChunks))
+	var count byte
+	for y, subChunk := range chunk.subChunks {
+		if !chunk.isPrunable(y, subChunk) && byte(y+1) > count {
+			count = byte(y + 1)
+		}
+	}
+	return count
 }
 
-// Prunes all empty SubChunks in this chunk.
+// Compacts all SubChunks in this chunk, and removes SubChunks that are completely empty.
+// SubChunks are only removed if they hold no light other than the light they implicitly have when missing.
 
 func (chunk *Chunk) PruneEmptySubChunks() {
 	for y, subChunk := range chunk.subChunks {
-		if y > chunk.height || y < 0 {
+		if y >= 0 && y < chunk.height>>4 {
+			subChunk.Compact()
+		}
+		if chunk.isPrunable(y, subChunk) {
 			delete(chunk.subChunks, y)
-			continue
 		}
-		if subChunk.IsAllAir() {
-			chunk.subChunks[y] = NewEmptySubChunk()
+	}
+}
+
+// Checks if the SubChunk at the given Y can be removed without changing the chunk:
+// it lies outside of the chunk height, or it is completely empty and holds only implicit light.
+
+func (chunk *Chunk) isPrunable(y int, subChunk interfaces.ISubChunk) bool {
+	if y >= chunk.height>>4 || y < 0 {
+		return true
+	}
+	return subChunk.IsAllAir() && chunk.hasImplicitLight(y, subChunk)
+}
+
+// Checks if the light of a SubChunk equals the light a missing SubChunk has:
+// no block light, and full sky light only if the SubChunk is completely above the HeightMap.
+
+func (chunk *Chunk) hasImplicitLight(y int, subChunk interfaces.ISubChunk) bool {
+	var above, below = true, true
+	for _, height := range chunk.heightMap {
+		if int(height) > y<<4 {
+			above = false
+		}
+		if int(height) < (y+1)<<4 {
+			below = false
 		}
 	}
+	return (above && subChunk.HasUniformLight(0, 15)) || (below && subChunk.HasUniformLight(0, 0))
+}
+
+// Marks the chunk as changed, so that its payload and compressed batches get encoded again when it is sent next.
+// Setting blocks, light, biomes or tiles through the chunk marks it automatically,
+// but changes made to SubChunks or tiles directly need to mark the chunk themselves.
+
+func (chunk *Chunk) MarkDirty() {
+	chunk.cacheMutex.Lock()
+	chunk.version++
+	chunk.payload = nil
+	chunk.batches = nil
+	chunk.cacheMutex.Unlock()
+}
+
+// Returns if the chunk changed since its payload was last encoded.
+
+func (chunk *Chunk) IsDirty() bool {
+	chunk.cacheMutex.Lock()
+	defer chunk.cacheMutex.Unlock()
+	return chunk.payload == nil
+}
+
+// Returns the compressed batch of the chunk for the given protocol number.
+// The batch is cached until the chunk changes, so that it is only encoded once for all players receiving the chunk.
+// The compress function produces the batch if no batch is cached for the protocol.
+// Concurrent calls wait for the batch being compressed instead of compressing it again.
+// The returned batch is shared and must not be modified.
+
+func (chunk *Chunk) GetCompressedBatch(protocol int32, compress func() []byte) []byte {
+	chunk.batchMutex.Lock()
+	defer chunk.batchMutex.Unlock()
+
+	chunk.cacheMutex.Lock()
+	if batch, ok := chunk.batches[protocol]; ok {
+		chunk.cacheMutex.Unlock()
+		return batch
+	}
+	var version = chunk.version
+	chunk.cacheMutex.Unlock()
+
+	var batch = compress()
+
+	chunk.cacheMutex.Lock()
+	if chunk.version == version {
+		if chunk.batches == nil {
+			chunk.batches = make(map[int32][]byte)
+		}
+		chunk.batches[protocol] = batch
+	}
+	chunk.cacheMutex.Unlock()
+	return batch
 }
 
 // Converts the chunk to binary preparing it to send to the client.
+// The payload is cached until the chunk changes, and concurrent calls wait for the payload being encoded
+// instead of encoding it again. The returned payload is shared and must not be modified.
 
 func (chunk *Chunk) ToBinary() []byte {
+	chunk.encodeMutex.Lock()
+	defer chunk.encodeMutex.Unlock()
+
+	chunk.cacheMutex.Lock()
+	if chunk.payload != nil {
+		var payload = chunk.payload
+		chunk.cacheMutex.Unlock()
+		return payload
+	}
+	var version = chunk.version
+	chunk.cacheMutex.Unlock()
+
+	var payload = chunk.encode()
+
+	chunk.cacheMutex.Lock()
+	if chunk.version == version {
+		chunk.payload = payload
+	}
+	chunk.cacheMutex.Unlock()
+	return payload
+}
+
+// Encodes the chunk payload sent to the client.
+// Encoding only reads the chunk: empty SubChunks are written as if they were pruned, without removing them.
+
+func (chunk *Chunk) encode() []byte {
 	var stream = binutils.NewStream()
 	var subChunkCount = chunk.GetFilledSubChunks()
 
 	stream.PutByte(subChunkCount)
 	for i := 0; i < int(subChunkCount); i++ {
-		stream.PutBytes(chunk.subChunks[i].ToBinary())
+		if subChunk, ok := chunk.subChunks[i]; ok && !chunk.isPrunable(i, subChunk) {
+			stream.PutBytes(subChunk.ToBinary())
+			continue
+		}
+		stream.PutBytes(NewSubChunk().ToBinary())
 	}
 
-	for i := 256; i >= 0; i-- {
-		stream.PutLittleShort(chunk.heightMap[i])
+	for _, height := range chunk.heightMap {
+		stream.PutLittleShort(height)
 	}
 
-	for _, biome := range chunk.biomes {
-		stream.PutByte(byte(biome))
-	}
-	stream.PutByte(0)
+	stream.PutBytes(chunk.biomes[:])
+	stream.PutByte(0) // Border blocks
+	stream.PutVarInt(0) // Extra data
 
-	stream.PutVarInt(0)
+	var tileData = bytes.NewBuffer(nil)
+	chunk.writeTiles(tileData)
+	stream.PutBytes(tileData.Bytes())
 
 	return stream.GetBuffer()
 }
@@ -67,6 +67,14 @@ func (subChunk *EmptySubChunk) GetHighestBlock(x, z int) int {
 	return 0
 }
 
+func (subChunk *EmptySubChunk) HasUniformLight(blockLight, skyLight byte) bool {
+	return blockLight == 0 && skyLight == 0
+}
+
+func (subChunk *EmptySubChunk) Compact() {
+
+}
+
 func (subChunk *EmptySubChunk) ToBinary() []byte {
 	return []byte{}
 }
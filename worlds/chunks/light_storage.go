@@ -0,0 +1,67 @@
+package chunks
+
+// LightStorage stores the 4096 light levels of a sub chunk as nibbles.
+// A storage holding the same level everywhere only stores that level, and allocates
+// the nibble array once a different level gets set.
+type LightStorage struct {
+	level   byte
+	nibbles []byte
+}
+
+// NewLightStorage returns a new light storage with all light set to the given level.
+func NewLightStorage(level byte) *LightStorage {
+	return &LightStorage{level: level & 15}
+}
+
+// Get returns the light level at the given index.
+func (storage *LightStorage) Get(index int) byte {
+	if storage.nibbles == nil {
+		return storage.level
+	}
+	return storage.nibbles[index>>1] >> (uint(index&1) << 2) & 15
+}
+
+// Set sets the light level at the given index.
+func (storage *LightStorage) Set(index int, level byte) {
+	level &= 15
+	if storage.nibbles == nil {
+		if level == storage.level {
+			return
+		}
+		storage.nibbles = make([]byte, subChunkSize/2)
+		if storage.level != 0 {
+			var filled = storage.level | storage.level<<4
+			for i := range storage.nibbles {
+				storage.nibbles[i] = filled
+			}
+		}
+	}
+	var shift = uint(index&1) << 2
+	storage.nibbles[index>>1] = storage.nibbles[index>>1]&^(15<<shift) | level<<shift
+}
+
+// IsUniform checks if the light level is equal to the given level everywhere.
+func (storage *LightStorage) IsUniform(level byte) bool {
+	if storage.nibbles == nil {
+		return storage.level == level
+	}
+	var filled = level | level<<4
+	for _, b := range storage.nibbles {
+		if b != filled {
+			return false
+		}
+	}
+	return true
+}
+
+// Compact collapses the storage to a single level if the light level is equal everywhere.
+func (storage *LightStorage) Compact() {
+	if storage.nibbles == nil {
+		return
+	}
+	var level = storage.nibbles[0] & 15
+	if storage.IsUniform(level) {
+		storage.level = level
+		storage.nibbles = nil
+	}
+}
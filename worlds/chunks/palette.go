@@ -0,0 +1,169 @@
+package chunks
+
+// subChunkSize is the amount of blocks in a sub chunk.
+const subChunkSize = 4096
+
+// paletteBitSizes are the bit sizes an index may have in paletted storage, as used by Minecraft Bedrock Edition.
+// Indices never span two words, so sizes that do not divide 32 leave some bits of every word unused.
+var paletteBitSizes = []uint{1, 2, 3, 4, 5, 6, 8, 16}
+
+// PalettedStorage stores the 4096 values of a sub chunk as indices into a palette of distinct values.
+// The indices are packed into 32-bit words using as few bits as the palette size allows.
+// A storage holding only a single value has no indices at all.
+type PalettedStorage struct {
+	bitsPerIndex   uint
+	indicesPerWord uint
+	words          []uint32
+	palette        []uint16
+}
+
+// NewPalettedStorage returns a new paletted storage with all values set to the given value.
+func NewPalettedStorage(value uint16) *PalettedStorage {
+	return &PalettedStorage{palette: []uint16{value}}
+}
+
+// Get returns the value at the given index.
+func (storage *PalettedStorage) Get(index int) uint16 {
+	if storage.bitsPerIndex == 0 {
+		return storage.palette[0]
+	}
+	return storage.palette[storage.getIndex(index)]
+}
+
+// Set sets the value at the given index.
+// The palette grows, and the indices get repacked with more bits, if the value is not in the palette yet.
+func (storage *PalettedStorage) Set(index int, value uint16) {
+	var paletteIndex = storage.paletteIndex(value)
+	if paletteIndex == -1 {
+		storage.palette = append(storage.palette, value)
+		paletteIndex = len(storage.palette) - 1
+		if len(storage.palette) > 1<<storage.bitsPerIndex {
+			storage.resize(bitsFor(len(storage.palette)))
+		}
+	}
+	if storage.bitsPerIndex == 0 {
+		return
+	}
+	storage.setIndex(index, uint32(paletteIndex))
+}
+
+// IsUniform checks if all values in the storage are equal to the given value.
+func (storage *PalettedStorage) IsUniform(value uint16) bool {
+	if storage.bitsPerIndex == 0 {
+		return storage.palette[0] == value
+	}
+	var paletteIndex = storage.paletteIndex(value)
+	if paletteIndex == -1 {
+		return false
+	}
+	for i := 0; i < subChunkSize; i++ {
+		if storage.getIndex(i) != uint32(paletteIndex) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPalette returns the palette of the storage.
+// The palette may contain values that are no longer used until the storage is compacted.
+func (storage *PalettedStorage) GetPalette() []uint16 {
+	return storage.palette
+}
+
+// GetBitsPerIndex returns the amount of bits used per palette index.
+// Returns 0 if the storage holds only a single value.
+func (storage *PalettedStorage) GetBitsPerIndex() uint {
+	return storage.bitsPerIndex
+}
+
+// GetWords returns the words the palette indices are packed into.
+func (storage *PalettedStorage) GetWords() []uint32 {
+	return storage.words
+}
+
+// Compact removes all unused values from the palette, and repacks the indices with as few bits as possible.
+// A storage of which all values are equal collapses to a single value.
+func (storage *PalettedStorage) Compact() {
+	if storage.bitsPerIndex == 0 {
+		return
+	}
+	var used = make([]bool, len(storage.palette))
+	for i := 0; i < subChunkSize; i++ {
+		used[storage.getIndex(i)] = true
+	}
+
+	var remap = make([]uint32, len(storage.palette))
+	var palette = make([]uint16, 0, len(storage.palette))
+	for i, value := range storage.palette {
+		if used[i] {
+			remap[i] = uint32(len(palette))
+			palette = append(palette, value)
+		}
+	}
+	if len(palette) == len(storage.palette) && bitsFor(len(palette)) == storage.bitsPerIndex {
+		return
+	}
+	if len(palette) == 1 {
+		*storage = PalettedStorage{palette: palette}
+		return
+	}
+
+	var compacted = &PalettedStorage{palette: palette}
+	compacted.resize(bitsFor(len(palette)))
+	for i := 0; i < subChunkSize; i++ {
+		compacted.setIndex(i, remap[storage.getIndex(i)])
+	}
+	*storage = *compacted
+}
+
+// paletteIndex returns the index of the value in the palette, or -1 if the palette does not contain it.
+func (storage *PalettedStorage) paletteIndex(value uint16) int {
+	for i, v := range storage.palette {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// getIndex returns the palette index stored at the given index.
+func (storage *PalettedStorage) getIndex(index int) uint32 {
+	var word = storage.words[uint(index)/storage.indicesPerWord]
+	var shift = (uint(index) % storage.indicesPerWord) * storage.bitsPerIndex
+	return (word >> shift) & (1<<storage.bitsPerIndex - 1)
+}
+
+// setIndex sets the palette index stored at the given index.
+func (storage *PalettedStorage) setIndex(index int, paletteIndex uint32) {
+	var wordIndex = uint(index) / storage.indicesPerWord
+	var shift = (uint(index) % storage.indicesPerWord) * storage.bitsPerIndex
+	var mask = uint32(1<<storage.bitsPerIndex-1) << shift
+	storage.words[wordIndex] = storage.words[wordIndex]&^mask | paletteIndex<<shift
+}
+
+// resize repacks all palette indices using the given amount of bits per index.
+func (storage *PalettedStorage) resize(bits uint) {
+	var indicesPerWord = 32 / bits
+	var resized = &PalettedStorage{
+		bitsPerIndex:   bits,
+		indicesPerWord: indicesPerWord,
+		words:          make([]uint32, (subChunkSize+indicesPerWord-1)/indicesPerWord),
+		palette:        storage.palette,
+	}
+	if storage.bitsPerIndex != 0 {
+		for i := 0; i < subChunkSize; i++ {
+			resized.setIndex(i, storage.getIndex(i))
+		}
+	}
+	*storage = *resized
+}
+
+// bitsFor returns the smallest supported amount of bits per index that can address a palette of the given size.
+func bitsFor(paletteSize int) uint {
+	for _, bits := range paletteBitSizes {
+		if paletteSize <= 1<<bits {
+			return bits
+		}
+	}
+	return 16
+}
@@ -1,20 +1,38 @@
 package chunks
 
+// SubChunk is a 16x16x16 section of a chunk.
+// Blocks are stored in paletted storage, with the block ID and data of a block combined into one palette value.
+// Light is stored as nibble arrays, which collapse to a single value when all light in the sub chunk is equal.
 type SubChunk struct {
-	BlockIds   []byte
-	BlockData  []byte
-	BlockLight []byte
-	SkyLight   []byte
+	blocks     *PalettedStorage
+	blockLight *LightStorage
+	skyLight   *LightStorage
 }
 
 func NewSubChunk() *SubChunk {
-	return &SubChunk{make([]byte, 4096), make([]byte, 2048), make([]byte, 2048), make([]byte, 2048)}
+	return &SubChunk{NewPalettedStorage(0), NewLightStorage(0), NewLightStorage(0)}
 }
 
 // Checks if this SubChunk is completely empty.
 
 func (subChunk *SubChunk) IsAllAir() bool {
-	return string(subChunk.BlockIds) == string(make([]byte, 4096))
+	for _, value := range subChunk.blocks.GetPalette() {
+		if value>>4 != 0 {
+			return subChunk.isUniformId(0)
+		}
+	}
+	return true
+}
+
+// Checks if all blocks in this SubChunk have the given block ID.
+
+func (subChunk *SubChunk) isUniformId(id byte) bool {
+	for i := 0; i < subChunkSize; i++ {
+		if byte(subChunk.blocks.Get(i)>>4) != id {
+			return false
+		}
+	}
+	return true
 }
 
 // Returns the index of the given xyz values for IDs in the SubChunk.
@@ -32,104 +50,84 @@ func (subChunk *SubChunk) GetDataIndex(x, y, z int) int {
 // Returns the block ID in the SubChunk at the given position.
 
 func (subChunk *SubChunk) GetBlockId(x, y, z int) byte {
-	return subChunk.BlockIds[subChunk.GetIdIndex(x, y, z)]
+	return byte(subChunk.blocks.Get(subChunk.GetIdIndex(x, y, z)) >> 4)
 }
 
 // Sets the block ID in the SubChunk at the given position.
 
 func (subChunk *SubChunk) SetBlockId(x, y, z int, id byte) {
-	subChunk.BlockIds[subChunk.GetIdIndex(x, y, z)] = id
+	var i = subChunk.GetIdIndex(x, y, z)
+	subChunk.blocks.Set(i, uint16(id)<<4|subChunk.blocks.Get(i)&15)
 }
 
 // Returns the block light in the SubChunk at the given position.
 
 func (subChunk *SubChunk) GetBlockLight(x, y, z int) byte {
-	var data = subChunk.BlockLight[subChunk.GetDataIndex(x, y, z)]
-	if (y & 0x01) == 0 {
-		return data & 0x0f
-	}
-	return data >> 4
+	return subChunk.blockLight.Get(subChunk.GetIdIndex(x, y, z))
 }
 
 // Sets the block light in the SubChunk at the given position.
 
 func (subChunk *SubChunk) SetBlockLight(x, y, z int, data byte) {
-	var i = subChunk.GetDataIndex(x, y, z)
-	var d = subChunk.BlockLight[i]
-	if (y & 0x01) == 0 {
-		subChunk.BlockLight[i] = (d & 0xf0) | (data & 0x0f)
-		return
-	}
-	subChunk.BlockLight[i] = ((data & 0x0f) << 4) | (d & 0x0f)
+	subChunk.blockLight.Set(subChunk.GetIdIndex(x, y, z), data)
 }
 
 // Returns the sky light in the SubChunk at the given position.
 
 func (subChunk *SubChunk) GetSkyLight(x, y, z int) byte {
-	var data = subChunk.SkyLight[subChunk.GetDataIndex(x, y, z)]
-	if (y & 0x01) == 0 {
-		return data & 0x0f
-	}
-	return data >> 4
+	return subChunk.skyLight.Get(subChunk.GetIdIndex(x, y, z))
 }
 
 // Sets the sky light in the SubChunk at the given position.
 
 func (subChunk *SubChunk) SetSkyLight(x, y, z int, data byte) {
-	var i = subChunk.GetDataIndex(x, y, z)
-	var d = subChunk.SkyLight[i]
-	if (y & 0x01) == 0 {
-		subChunk.SkyLight[i] = (d & 0xf0) | (data & 0x0f)
-		return
-	}
-	subChunk.SkyLight[i] = ((data & 0x0f) << 4) | (d & 0x0f)
+	subChunk.skyLight.Set(subChunk.GetIdIndex(x, y, z), data)
 }
 
 // Returns the block data of a block in the SubChunk on the given position.
 
 func (subChunk *SubChunk) GetBlockData(x, y, z int) byte {
-	var data = subChunk.BlockData[subChunk.GetDataIndex(x, y, z)]
-	if (y & 0x01) == 0 {
-		return data & 0x0f
-	}
-	return data >> 4
+	return byte(subChunk.blocks.Get(subChunk.GetIdIndex(x, y, z)) & 15)
 }
 
 // Sets the block data of a block in the SubChunk on the given position.
 
 func (subChunk *SubChunk) SetBlockData(x, y, z int, data byte) {
-	var i = subChunk.GetDataIndex(x, y, z)
-	var d = subChunk.BlockData[i]
-	if (y & 0x01) == 0 {
-		subChunk.BlockData[i] = (d & 0xf0) | (data & 0x0f)
-		return
-	}
-	subChunk.BlockData[i] = ((data & 0x0f) << 4) | (d & 0x0f)
+	var i = subChunk.GetIdIndex(x, y, z)
+	subChunk.blocks.Set(i, subChunk.blocks.Get(i)&^15|uint16(data&15))
 }
 
-// Returns highest block id at certain x, z coordinates in this subchunk
+// Returns the block storage of this SubChunk.
 
-func (subChunk *SubChunk) GetHighestBlockId(x, z int) byte {
-	var id byte
+func (subChunk *SubChunk) GetBlockStorage() *PalettedStorage {
+	return subChunk.blocks
+}
 
-	for y := 15; y >= 0; y-- {
-		id = subChunk.GetBlockId(x, y, z)
-		if id != 0 {
-			return id
-		}
-	}
+// Checks if the block light and sky light in this SubChunk are equal to the given levels everywhere.
 
-	return 0
+func (subChunk *SubChunk) HasUniformLight(blockLight, skyLight byte) bool {
+	return subChunk.blockLight.IsUniform(blockLight) && subChunk.skyLight.IsUniform(skyLight)
+}
+
+// Compacts the block storage and light of this SubChunk, removing unused palette values and
+// collapsing uniform storage to a single value.
+
+func (subChunk *SubChunk) Compact() {
+	subChunk.blocks.Compact()
+	subChunk.blockLight.Compact()
+	subChunk.skyLight.Compact()
+}
+
+// Returns highest block id at certain x, z coordinates in this subchunk
+
+func (subChunk *SubChunk) GetHighestBlockId(x, z int) byte {
+	return subChunk.GetBlockId(x, subChunk.GetHighestBlock(x, z), z)
 }
 
 // Returns block meta data at certain x, z coordinates in this subchunk
 
 func (subChunk *SubChunk) GetHighestBlockData(x, z int) byte {
-	for y := 15; y >= 0; y-- {
-		return subChunk.GetBlockData(x, y, z)
-	}
-
-	return 0
+	return subChunk.GetBlockData(x, subChunk.GetHighestBlock(x, z), z)
 }
 
 // Returns highest light filtering at certain x, z coordinates in this subchunk
@@ -145,10 +143,36 @@ func (subChunk *SubChunk) GetHighestBlock(x, z int) int {
 }
 
 // Converts the sub chunk into binary.
+// The network format has no palette, so all block IDs are written, followed by all block data as nibbles.
 
 func (subChunk *SubChunk) ToBinary() []byte {
-	var bytes = []byte{00}
-	bytes = append(bytes, subChunk.BlockIds...)
-	bytes = append(bytes, subChunk.BlockData...)
+	var bytes = make([]byte, 1+subChunkSize+subChunkSize/2)
+	var ids, data = bytes[1 : 1+subChunkSize], bytes[1+subChunkSize:]
+	var storage = subChunk.blocks
+	if storage.bitsPerIndex == 0 {
+		var value = storage.palette[0]
+		if value != 0 {
+			for i := range ids {
+				ids[i] = byte(value >> 4)
+			}
+			for i := range data {
+				data[i] = byte(value&15) * 0x11
+			}
+		}
+		return bytes
+	}
+
+	// The words are unpacked one by one, instead of looking up every index separately.
+	var mask = uint32(1)<<storage.bitsPerIndex - 1
+	var i = 0
+	for _, word := range storage.words {
+		for j := uint(0); j < storage.indicesPerWord && i < subChunkSize; j++ {
+			var value = storage.palette[word&mask]
+			word >>= storage.bitsPerIndex
+			ids[i] = byte(value >> 4)
+			data[i>>1] |= byte(value&15) << (uint(i&1) << 2)
+			i++
+		}
+	}
 	return bytes
 }
@@ -0,0 +1,238 @@
+package chunks
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// flatSubChunk stores a sub chunk the way sub chunks were stored before paletted storage,
+// with one byte per block ID and nibble arrays for block data and light.
+type flatSubChunk struct {
+	ids        []byte
+	data       []byte
+	blockLight []byte
+	skyLight   []byte
+}
+
+func newFlatSubChunk() *flatSubChunk {
+	return &flatSubChunk{make([]byte, 4096), make([]byte, 2048), make([]byte, 2048), make([]byte, 2048)}
+}
+
+func (subChunk *flatSubChunk) get(index int) uint16 {
+	return uint16(subChunk.ids[index])<<4 | uint16(subChunk.data[index>>1]>>(uint(index&1)<<2)&15)
+}
+
+func (subChunk *flatSubChunk) set(index int, value uint16) {
+	var shift = uint(index&1) << 2
+	subChunk.ids[index] = byte(value >> 4)
+	subChunk.data[index>>1] = subChunk.data[index>>1]&^(15<<shift) | byte(value&15)<<shift
+}
+
+func (subChunk *flatSubChunk) toBinary() []byte {
+	var bytes = []byte{00}
+	bytes = append(bytes, subChunk.ids...)
+	bytes = append(bytes, subChunk.data...)
+	return bytes
+}
+
+func (subChunk *flatSubChunk) memorySize() int {
+	return len(subChunk.ids) + len(subChunk.data) + len(subChunk.blockLight) + len(subChunk.skyLight)
+}
+
+// memorySize returns the amount of bytes used by the block and light arrays of the sub chunk.
+func memorySize(subChunk *SubChunk) int {
+	var blocks = subChunk.blocks
+	return len(blocks.words)*4 + len(blocks.palette)*2 + len(subChunk.blockLight.nibbles) + len(subChunk.skyLight.nibbles)
+}
+
+// subChunkLayouts returns the block values of differently filled sub chunks, combining block ID and data like paletted storage.
+func subChunkLayouts() []struct {
+	name   string
+	values []uint16
+} {
+	var random = rand.New(rand.NewSource(1))
+	var air, terrain, mixed, noise = make([]uint16, 4096), make([]uint16, 4096), make([]uint16, 4096), make([]uint16, 4096)
+	for i := range terrain {
+		var y = i & 15
+		switch {
+		case y < 10:
+			terrain[i] = 1 << 4
+			if random.Intn(40) == 0 {
+				terrain[i] = 16 << 4
+			}
+		case y < 13:
+			terrain[i] = 3 << 4
+		case y == 13:
+			terrain[i] = 2 << 4
+		}
+		mixed[i] = uint16(random.Intn(12)) << 4
+		noise[i] = uint16(random.Intn(1 << 12))
+	}
+	return []struct {
+		name   string
+		values []uint16
+	}{{"Air", air}, {"Terrain", terrain}, {"Mixed", mixed}, {"Noise", noise}}
+}
+
+func newPalettedSubChunk(values []uint16) *SubChunk {
+	var subChunk = NewSubChunk()
+	for i, value := range values {
+		subChunk.blocks.Set(i, value)
+	}
+	subChunk.Compact()
+	return subChunk
+}
+
+func newFlatSubChunkOf(values []uint16) *flatSubChunk {
+	var subChunk = newFlatSubChunk()
+	for i, value := range values {
+		subChunk.set(i, value)
+	}
+	return subChunk
+}
+
+func TestPalettedStorage(t *testing.T) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newPalettedSubChunk(layout.values)
+		for i, value := range layout.values {
+			if got := subChunk.blocks.Get(i); got != value {
+				t.Fatalf("%v: value at %v is %v, want %v", layout.name, i, got, value)
+			}
+		}
+		if !bytes.Equal(subChunk.ToBinary(), newFlatSubChunkOf(layout.values).toBinary()) {
+			t.Errorf("%v: binary differs from the flat sub chunk", layout.name)
+		}
+	}
+}
+
+func TestPalettedStorageCompact(t *testing.T) {
+	var storage = NewPalettedStorage(0)
+	for i := 0; i < 300; i++ {
+		storage.Set(i, uint16(i))
+	}
+	if storage.GetBitsPerIndex() != 16 {
+		t.Fatalf("300 values use %v bits per index, want 16", storage.GetBitsPerIndex())
+	}
+	for i := 0; i < 300; i++ {
+		storage.Set(i, uint16(i%3))
+	}
+	storage.Compact()
+	if len(storage.GetPalette()) != 3 || storage.GetBitsPerIndex() != 2 {
+		t.Errorf("compacted storage has %v values with %v bits per index, want 3 values with 2 bits", len(storage.GetPalette()), storage.GetBitsPerIndex())
+	}
+	for i := 0; i < 300; i++ {
+		if storage.Get(i) != uint16(i%3) {
+			t.Fatalf("value at %v is %v after compacting, want %v", i, storage.Get(i), i%3)
+		}
+	}
+	for i := 0; i < subChunkSize; i++ {
+		storage.Set(i, 7)
+	}
+	storage.Compact()
+	if storage.GetBitsPerIndex() != 0 || storage.GetWords() != nil {
+		t.Error("storage holding a single value was not collapsed")
+	}
+}
+
+var sink uint16
+
+func BenchmarkPalettedGet(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newPalettedSubChunk(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(memorySize(subChunk)), "B/subchunk")
+			for i := 0; i < b.N; i++ {
+				sink = subChunk.blocks.Get(i & 4095)
+			}
+		})
+	}
+}
+
+func BenchmarkFlatGet(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newFlatSubChunkOf(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(subChunk.memorySize()), "B/subchunk")
+			for i := 0; i < b.N; i++ {
+				sink = subChunk.get(i & 4095)
+			}
+		})
+	}
+}
+
+func BenchmarkPalettedSet(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newPalettedSubChunk(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				subChunk.blocks.Set(i&4095, layout.values[(i*7)&4095])
+			}
+			b.ReportMetric(float64(memorySize(subChunk)), "B/subchunk")
+		})
+	}
+}
+
+func BenchmarkFlatSet(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newFlatSubChunkOf(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				subChunk.set(i&4095, layout.values[(i*7)&4095])
+			}
+			b.ReportMetric(float64(subChunk.memorySize()), "B/subchunk")
+		})
+	}
+}
+
+func BenchmarkPalettedFill(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				newPalettedSubChunk(layout.values)
+			}
+		})
+	}
+}
+
+func BenchmarkFlatFill(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				newFlatSubChunkOf(layout.values)
+			}
+		})
+	}
+}
+
+func BenchmarkSubChunkToBinary(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newPalettedSubChunk(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(memorySize(subChunk)), "B/subchunk")
+			for i := 0; i < b.N; i++ {
+				subChunk.ToBinary()
+			}
+		})
+	}
+}
+
+func BenchmarkFlatSubChunkToBinary(b *testing.B) {
+	for _, layout := range subChunkLayouts() {
+		var subChunk = newFlatSubChunkOf(layout.values)
+		b.Run(layout.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(subChunk.memorySize()), "B/subchunk")
+			for i := 0; i < b.N; i++ {
+				subChunk.toBinary()
+			}
+		})
+	}
+}
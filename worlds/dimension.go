@@ -1,13 +1,26 @@
 package worlds
 
 import (
+	"math"
+	"math/rand"
+	"sort"
 	"sync"
 
+	"github.com/golang/geo/r3"
 	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/worlds/blocks"
 	"github.com/irmine/gomine/worlds/chunks"
 	"github.com/irmine/gomine/worlds/generation"
+	"github.com/irmine/gomine/worlds/generation/populators"
+	"github.com/irmine/gomine/worlds/lighting"
 )
 
+// FullChunkUpdateThreshold is the amount of changed blocks in a chunk in one tick from which the full chunk gets resent,
+// instead of sending an UpdateBlock packet for every block.
+const FullChunkUpdateThreshold = 64
+
 const (
 	OverworldId = 0
 	NetherId    = 1
@@ -20,8 +33,20 @@ type Dimension struct {
 	level       interfaces.ILevel
 	isGenerated bool
 
-	chunks        map[int]interfaces.IChunk
-	updatedBlocks map[int][]interfaces.IBlock
+	chunks          map[int]interfaces.IChunk
+	unusedChunks    map[int]int64
+	unloadingChunks map[int]interfaces.IChunk
+	updatedBlocks   map[int]map[int]interfaces.IBlock
+
+	pendingChunks map[int][]func(interfaces.IChunk)
+	readyChunks   []interfaces.IChunk
+	workers       *WorkerPool
+	light         *lighting.Engine
+
+	scheduledUpdates updateQueue
+	scheduledBlocks  map[int]bool
+	updateOrder      uint64
+	random           *rand.Rand
 
 	generator interfaces.IGenerator
 
@@ -32,17 +57,32 @@ type Dimension struct {
 
 func NewDimension(name string, dimensionId int, level *Level, generator string, chunks map[int]interfaces.IChunk) *Dimension {
 	var dimension = &Dimension{
-		name:          name,
-		dimensionId:   dimensionId,
-		level:         level,
-		chunks:        chunks,
-		updatedBlocks: make(map[int][]interfaces.IBlock),
+		name:            name,
+		dimensionId:     dimensionId,
+		level:           level,
+		chunks:          chunks,
+		unusedChunks:    make(map[int]int64),
+		unloadingChunks: make(map[int]interfaces.IChunk),
+		updatedBlocks:   make(map[int]map[int]interfaces.IBlock),
+		pendingChunks:   make(map[int][]func(interfaces.IChunk)),
+		workers:         level.workers,
+
+		scheduledBlocks: make(map[int]bool),
+		random:          rand.New(rand.NewSource(level.random.Int63())),
 	}
+	dimension.light = lighting.NewEngine(dimension)
 
 	if len(generator) == 0 {
-		dimension.generator = generation.GetGeneratorByName(level.server.GetConfiguration().DefaultGenerator)
-	} else {
-		dimension.generator = generation.GetGeneratorByName(generator)
+		generator = level.GetGeneratorName()
+	}
+	dimension.generator = generation.CreateGenerator(generator, level.GetSeed(), level.GetGeneratorOptions())
+	if dimension.generator == nil {
+		var fallback = level.server.GetConfiguration().DefaultGenerator
+		if !generation.GeneratorNameExists(fallback) {
+			fallback = "Flat"
+		}
+		level.server.GetLogger().Warning("Generator", generator, "does not exist. Using", fallback, "for dimension", name, "of level", level.GetName())
+		dimension.generator = generation.CreateGenerator(fallback, level.GetSeed(), level.GetGeneratorOptions())
 	}
 
 	return dimension
@@ -75,16 +115,87 @@ func (dimension *Dimension) IsChunkLoaded(x, z int32) bool {
 	return ok
 }
 
-// Sets this chunk unloaded
+// Sets this chunk unloaded, saving it first.
 
 func (dimension *Dimension) SetChunkUnloaded(x, z int32) {
-	if !dimension.IsChunkLoaded(x, z) {
+	dimension.mux.Lock()
+	var chunk = dimension.unloadChunk(GetChunkIndex(x, z))
+	dimension.mux.Unlock()
+	if chunk != nil {
+		dimension.saveUnloadedChunks([]interfaces.IChunk{chunk})
+	}
+}
+
+// Removes the chunk with the given index from the dimension, and returns it so that it can be saved.
+// The chunk is kept aside until it has been saved, so that it is not loaded from the chunk provider in the meantime.
+// Returns nil if the chunk is not loaded. The dimension mutex must be locked when calling this.
+
+func (dimension *Dimension) unloadChunk(index int) interfaces.IChunk {
+	var chunk, ok = dimension.chunks[index]
+	if !ok {
+		return nil
+	}
+	delete(dimension.chunks, index)
+	delete(dimension.unusedChunks, index)
+	delete(dimension.updatedBlocks, index)
+	dimension.unloadingChunks[index] = chunk
+	return chunk
+}
+
+// Closes the entities in the unloaded chunks and saves the chunks to the chunk provider of the level.
+// Entities are not saved with chunks, so they are despawned and closed instead of being left behind in the chunk.
+// Only the entity NBT read from the chunk provider is written back, so entities spawned since, such as primed TNT,
+// are lost. Unused chunks holding such entities are therefore not unloaded until the entities have closed.
+// Must be called without the dimension mutex locked, as saving chunks writes to disk.
+
+func (dimension *Dimension) saveUnloadedChunks(unloaded []interfaces.IChunk) {
+	var provider = dimension.level.GetChunkProvider()
+	for _, chunk := range unloaded {
+		for _, entity := range chunk.GetEntities() {
+			if _, ok := entity.(interfaces.IPlayer); ok {
+				continue
+			}
+			chunk.RemoveEntity(entity)
+			if !entity.IsClosed() {
+				entity.DespawnFromAll()
+				entity.Close()
+			}
+		}
+		chunk.PruneEmptySubChunks()
+		if provider != nil {
+			if err := provider.SaveChunk(chunk, dimension.dimensionId); err != nil {
+				dimension.level.GetServer().GetLogger().LogError(err)
+			}
+		}
+
+		var index = GetChunkIndex(chunk.GetX(), chunk.GetZ())
 		dimension.mux.Lock()
-		delete(dimension.chunks, GetChunkIndex(x, z))
+		if dimension.unloadingChunks[index] == chunk {
+			delete(dimension.unloadingChunks, index)
+		}
 		dimension.mux.Unlock()
 	}
 }
 
+// Returns the chunk at the x/z coordinates if it is being unloaded and was not saved yet, or nil if it is not.
+// The chunk stays set aside, as it still gets saved.
+
+func (dimension *Dimension) getUnloadingChunk(x, z int32) interfaces.IChunk {
+	dimension.mux.Lock()
+	var chunk = dimension.unloadingChunks[GetChunkIndex(x, z)]
+	dimension.mux.Unlock()
+	return chunk
+}
+
+// Returns the amount of chunks currently loaded in the dimension.
+
+func (dimension *Dimension) GetLoadedChunkCount() int {
+	dimension.mux.Lock()
+	var count = len(dimension.chunks)
+	dimension.mux.Unlock()
+	return count
+}
+
 // Sets a new chunk in the dimension at the x/z coordinates.
 
 func (dimension *Dimension) SetChunk(x, z int32, chunk interfaces.IChunk) {
@@ -94,19 +205,241 @@ func (dimension *Dimension) SetChunk(x, z int32, chunk interfaces.IChunk) {
 }
 
 // Gets the chunk in the dimension at the x/z coordinates.
+// The chunk gets loaded or generated on the calling goroutine if it is not loaded yet, which blocks the caller.
+// This should only be used on the goroutine ticking the dimension, and RequestChunkAsync should be preferred where possible.
 
 func (dimension *Dimension) GetChunk(x, z int32) interfaces.IChunk {
+	var index = GetChunkIndex(x, z)
 	dimension.mux.Lock()
-	if v, ok := dimension.chunks[GetChunkIndex(x, z)]; ok {
+	if v, ok := dimension.chunks[index]; ok {
+		delete(dimension.unusedChunks, index)
 		dimension.mux.Unlock()
 		return v
 	}
-	var chunk = dimension.generator.GetNewChunk(chunks.NewChunk(x, z))
-	dimension.chunks[GetChunkIndex(x, z)] = chunk
+	dimension.mux.Unlock()
+
+	var chunk = dimension.produceChunk(x, z)
+
+	dimension.mux.Lock()
+	var v, exists = dimension.chunks[index]
+	if exists {
+		chunk = v
+	} else {
+		dimension.chunks[index] = chunk
+	}
+	dimension.mux.Unlock()
+
+	if !exists {
+		dimension.addChunks([]interfaces.IChunk{chunk})
+	}
+	return chunk
+}
+
+// Returns the chunk at the x/z coordinates if it is loaded, or nil if it is not.
+
+func (dimension *Dimension) GetLoadedChunk(x, z int32) interfaces.IChunk {
+	dimension.mux.Lock()
+	var chunk = dimension.chunks[GetChunkIndex(x, z)]
 	dimension.mux.Unlock()
 	return chunk
 }
 
+// Requests the chunk at the x/z coordinates without blocking.
+// The callback gets called with the chunk once it has been loaded or generated by the worker pool of the level.
+// Callbacks are always called on the goroutine ticking the dimension, also if the chunk is already loaded,
+// in which case they get called on the next tick.
+
+func (dimension *Dimension) RequestChunkAsync(x, z int32, callback func(interfaces.IChunk)) {
+	var index = GetChunkIndex(x, z)
+	dimension.mux.Lock()
+	var callbacks, pending = dimension.pendingChunks[index]
+	dimension.pendingChunks[index] = append(callbacks, callback)
+	if chunk, ok := dimension.chunks[index]; ok {
+		delete(dimension.unusedChunks, index)
+		if !pending {
+			dimension.readyChunks = append(dimension.readyChunks, chunk)
+		}
+		dimension.mux.Unlock()
+		return
+	}
+	dimension.mux.Unlock()
+
+	if pending {
+		return
+	}
+	dimension.workers.Submit(func() {
+		var chunk = dimension.produceChunk(x, z)
+
+		dimension.mux.Lock()
+		dimension.readyChunks = append(dimension.readyChunks, chunk)
+		dimension.mux.Unlock()
+	})
+}
+
+// Delivers all chunks finished by the worker pool, storing them in the dimension and calling their callbacks.
+
+func (dimension *Dimension) deliverChunks() {
+	dimension.mux.Lock()
+	var ready = dimension.readyChunks
+	dimension.readyChunks = nil
+
+	var callbacks = make([][]func(interfaces.IChunk), len(ready))
+	var added = make([]interfaces.IChunk, 0, len(ready))
+	for i, chunk := range ready {
+		var index = GetChunkIndex(chunk.GetX(), chunk.GetZ())
+		if v, ok := dimension.chunks[index]; ok {
+			ready[i] = v
+		} else {
+			dimension.chunks[index] = chunk
+			added = append(added, chunk)
+		}
+		callbacks[i] = dimension.pendingChunks[index]
+		delete(dimension.pendingChunks, index)
+	}
+	dimension.mux.Unlock()
+
+	dimension.addChunks(added)
+
+	for i, chunk := range ready {
+		for _, callback := range callbacks[i] {
+			callback(chunk)
+		}
+	}
+}
+
+// Finishes chunks that were newly added to the dimension.
+// Light gets spread across the borders of the chunks, and all chunks around them that now have all their
+// neighbouring chunks loaded get populated.
+
+func (dimension *Dimension) addChunks(added []interfaces.IChunk) {
+	for _, chunk := range added {
+		dimension.light.SpreadBorders(chunk)
+	}
+
+	var checked = make(map[int]bool)
+	var modified = make(map[int]interfaces.IChunk)
+	for _, chunk := range added {
+		for x := chunk.GetX() - 1; x <= chunk.GetX()+1; x++ {
+			for z := chunk.GetZ() - 1; z <= chunk.GetZ()+1; z++ {
+				var index = GetChunkIndex(x, z)
+				if checked[index] {
+					continue
+				}
+				checked[index] = true
+				if area := dimension.getPopulationArea(x, z); area != nil {
+					dimension.populateChunk(area, modified)
+				}
+			}
+		}
+	}
+
+	for _, chunk := range modified {
+		for _, viewer := range chunk.GetViewers() {
+			viewer.SendFullChunkData(chunk)
+		}
+	}
+}
+
+// Returns the area around the chunk at the x/z coordinates for populating it.
+// Returns nil if the chunk is already populated, or if it or any of its neighbouring chunks is not loaded.
+
+func (dimension *Dimension) getPopulationArea(x, z int32) *populators.Area {
+	dimension.mux.Lock()
+	defer dimension.mux.Unlock()
+
+	var center, ok = dimension.chunks[GetChunkIndex(x, z)]
+	if !ok || center.IsTerrainPopulated() {
+		return nil
+	}
+	var chunks [3][3]interfaces.IChunk
+	for dx := int32(-1); dx <= 1; dx++ {
+		for dz := int32(-1); dz <= 1; dz++ {
+			var chunk, ok = dimension.chunks[GetChunkIndex(x+dx, z+dz)]
+			if !ok {
+				return nil
+			}
+			chunks[dx+1][dz+1] = chunk
+		}
+	}
+	return populators.NewArea(x, z, chunks)
+}
+
+// Runs the populators of the generator for the center chunk of the area, and relights all chunks changed by them.
+// The changed chunks get added to the modified map, so that they can be resent to their viewers.
+
+func (dimension *Dimension) populateChunk(area *populators.Area, modified map[int]interfaces.IChunk) {
+	dimension.generator.PopulateChunk(area)
+	area.GetChunk(area.GetChunkX(), area.GetChunkZ()).SetTerrainPopulated(true)
+
+	var changed = area.GetModifiedChunks()
+	for _, chunk := range changed {
+		lighting.LightChunk(chunk)
+		chunk.PruneEmptySubChunks()
+	}
+	for _, chunk := range changed {
+		dimension.light.SpreadBorders(chunk)
+		modified[GetChunkIndex(chunk.GetX(), chunk.GetZ())] = chunk
+	}
+}
+
+// Loads the chunk at the x/z coordinates from the chunk provider, or generates it if it does not exist.
+// Chunks that are still being saved after unloading are taken back instead.
+// The light of the chunk is calculated if it was not saved with the chunk.
+// The chunk does not get stored in the dimension.
+
+func (dimension *Dimension) produceChunk(x, z int32) interfaces.IChunk {
+	if chunk := dimension.getUnloadingChunk(x, z); chunk != nil {
+		return chunk
+	}
+	var chunk = dimension.loadChunk(x, z)
+	if chunk == nil {
+		chunk = dimension.generator.GetNewChunk(chunks.NewChunk(x, z))
+	}
+	if !chunk.IsLightPopulated() {
+		lighting.LightChunk(chunk)
+	}
+	chunk.PruneEmptySubChunks()
+	return chunk
+}
+
+// Loads the chunk at the x/z coordinates from the chunk provider of the level.
+// Returns nil if the chunk has not been saved yet, or if it could not be loaded.
+
+func (dimension *Dimension) loadChunk(x, z int32) interfaces.IChunk {
+	var provider = dimension.level.GetChunkProvider()
+	if provider == nil || !provider.ChunkExists(x, z, dimension.dimensionId) {
+		return nil
+	}
+	var chunk, err = provider.LoadChunk(x, z, dimension.dimensionId)
+	if err != nil {
+		dimension.level.GetServer().GetLogger().LogError(err)
+		return nil
+	}
+	return chunk
+}
+
+// Saves all loaded chunks of the dimension to the chunk provider of the level.
+
+func (dimension *Dimension) SaveChunks() {
+	var provider = dimension.level.GetChunkProvider()
+	if provider == nil {
+		return
+	}
+	dimension.mux.Lock()
+	var loaded = make([]interfaces.IChunk, 0, len(dimension.chunks))
+	for _, chunk := range dimension.chunks {
+		loaded = append(loaded, chunk)
+	}
+	dimension.mux.Unlock()
+
+	for _, chunk := range loaded {
+		chunk.PruneEmptySubChunks()
+		if err := provider.SaveChunk(chunk, dimension.dimensionId); err != nil {
+			dimension.level.GetServer().GetLogger().LogError(err)
+		}
+	}
+}
+
 // Returns if the dimension is generated or not.
 
 func (dimension *Dimension) IsGenerated() bool {
@@ -128,7 +461,7 @@ func (dimension *Dimension) GetGenerator() interfaces.IGenerator {
 // Sends all chunks required around the player.
 
 func (dimension *Dimension) RequestChunks(player interfaces.IPlayer, distance int32) {
-	xD, zD := int32(player.GetPosition().X)>>4, int32(player.GetPosition().Z)>>4
+	xD, zD := int32(math.Floor(player.GetPosition().X))>>4, int32(math.Floor(player.GetPosition().Z))>>4
 
 	for x := -distance + xD; x <= distance+xD; x++ {
 		for z := -distance + zD; z <= distance+zD; z++ {
@@ -142,51 +475,255 @@ func (dimension *Dimension) RequestChunks(player interfaces.IPlayer, distance in
 					continue
 				}
 
-				chunk := dimension.GetChunk(x, z)
-				chunk.AddViewer(player)
-				player.SendChunk(chunk, index)
-
-				for _, entity := range chunk.GetEntities() {
-					entity.SpawnTo(player)
-				}
+				dimension.RequestChunkAsync(x, z, func(chunk interfaces.IChunk) {
+					if player.GetDimension() != interfaces.IDimension(dimension) || player.HasChunkInUse(index) {
+						return
+					}
+					chunk.AddViewer(player)
+					player.SendChunk(chunk, index)
+
+					for _, entity := range chunk.GetEntities() {
+						entity.SpawnTo(player)
+					}
+				})
 			}
 		}
 	}
 }
 
-// Unloads all unused chunks
+// Unloads all unused chunks.
+// Chunks without viewers get unloaded once they have been unused for longer than the configured unload delay.
+// Chunks holding live entities other than players are kept loaded, as entities are not saved with chunks.
+// If more chunks than the configured maximum are loaded, unused chunks get unloaded early, longest unused first.
+// Unloaded chunks are saved after releasing the dimension mutex, so that saving does not block chunk lookups.
 
-func (dimension Dimension) UnloadUnusedChunks() {
+func (dimension *Dimension) UnloadUnusedChunks() {
+	var config = dimension.level.GetServer().GetConfiguration()
+	var currentTick = dimension.level.GetServer().GetCurrentTick()
+	var delay = config.ChunkUnloadDelay * 20
 
+	dimension.mux.Lock()
+	for index, chunk := range dimension.chunks {
+		if len(chunk.GetViewers()) != 0 || hasLiveEntities(chunk) {
+			delete(dimension.unusedChunks, index)
+			continue
+		}
+		if _, ok := dimension.unusedChunks[index]; !ok {
+			dimension.unusedChunks[index] = currentTick
+		}
+	}
+
+	var unloaded []interfaces.IChunk
+	var unused = make([]int, 0, len(dimension.unusedChunks))
+	for index, since := range dimension.unusedChunks {
+		if currentTick-since >= delay {
+			unloaded = append(unloaded, dimension.unloadChunk(index))
+			continue
+		}
+		unused = append(unused, index)
+	}
+
+	var excess = len(dimension.chunks) - config.MaximumLoadedChunks
+	if config.MaximumLoadedChunks > 0 && excess > 0 {
+		sort.Slice(unused, func(i, j int) bool {
+			return dimension.unusedChunks[unused[i]] < dimension.unusedChunks[unused[j]]
+		})
+		for i := 0; i < excess && i < len(unused); i++ {
+			unloaded = append(unloaded, dimension.unloadChunk(unused[i]))
+		}
+	}
+	dimension.mux.Unlock()
+
+	dimension.saveUnloadedChunks(unloaded)
 }
 
-// this function updates every block that gets changed.
+// Checks if the chunk holds entities other than players that have not been closed.
 
-func (dimension *Dimension) UpdateBlocks() {
-	/*var players2 []interfaces.IPlayer
-	batch := net.NewMinecraftPacketBatch()
+func hasLiveEntities(chunk interfaces.IChunk) bool {
+	for _, entity := range chunk.GetEntities() {
+		if _, ok := entity.(interfaces.IPlayer); !ok && !entity.IsClosed() {
+			return true
+		}
+	}
+	return false
+}
 
-	for i, blocks := range dimension.updatedBlocks {
-		x, z := GetChunkCoordinates(i)
-		players2 = dimension.GetChunkPlayers(x, z)
+// Sends all blocks changed since the last tick to the viewers of their chunks.
+// Chunks with at least FullChunkUpdateThreshold changed blocks get resent completely instead.
 
-		if len(players2) == 0 {
-			delete(dimension.chunkPlayers, GetChunkIndex(x, z))
-			break
+func (dimension *Dimension) UpdateBlocks() {
+	dimension.mux.Lock()
+	var updatedBlocks = dimension.updatedBlocks
+	dimension.updatedBlocks = make(map[int]map[int]interfaces.IBlock)
+	var updatedChunks = make(map[int]interfaces.IChunk, len(updatedBlocks))
+	for index := range updatedBlocks {
+		if chunk, ok := dimension.chunks[index]; ok {
+			updatedChunks[index] = chunk
 		}
+	}
+	dimension.mux.Unlock()
 
-		for _, block := range blocks {
-			pk := packets.NewUpdateBlockPacket()
-			pk.BlockId = uint32(block.GetId())
-			pk.BlockMetadata = uint32(block.GetData())
-			pk.Flags = 0x0
-			batch.AddPacket(pk)
+	var logger = dimension.level.GetServer().GetLogger()
+	for index, chunk := range updatedChunks {
+		// Chunk encoding only reads chunks, so SubChunks emptied by the changed blocks are removed here.
+		chunk.PruneEmptySubChunks()
+		var viewers = chunk.GetViewers()
+		if len(viewers) == 0 {
+			continue
+		}
+		var blocks = updatedBlocks[index]
+		if len(blocks) >= FullChunkUpdateThreshold {
+			for _, viewer := range viewers {
+				viewer.SendFullChunkData(chunk)
+			}
+			continue
+		}
+		for _, viewer := range viewers {
+			var batch = net.NewMinecraftPacketBatch(viewer, logger)
+			for blockIndex, block := range blocks {
+				var x, y, z = GetBlockCoordinates(blockIndex)
+				var position = r3.Vector{X: float64(x), Y: float64(y), Z: float64(z)}
+				batch.AddPacket(viewer.GetProtocol().GetUpdateBlock(position, uint32(block.GetId()), uint32(block.GetData()), data.UpdateBlockAllPriority))
+			}
+			viewer.SendBatch(batch)
 		}
 	}
+}
+
+// Sets the block at the given position, and sends the change to all viewers of the chunk on the next tick.
+// If the chunk at the position is not loaded yet, it gets requested and the block is set once the chunk is loaded.
 
-	for _, p := range players2 {
-		dimension.level.GetServer().GetRakLibAdapter().SendBatch(batch, p.GetSession(), server.PriorityMedium)
-	}*/
+func (dimension *Dimension) SetBlock(position r3.Vector, block interfaces.IBlock) {
+	var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+	if y < 0 || y > 255 {
+		return
+	}
+	var chunkX, chunkZ = int32(x >> 4), int32(z >> 4)
+	if chunk := dimension.GetLoadedChunk(chunkX, chunkZ); chunk != nil {
+		dimension.setBlock(chunk, x, y, z, block)
+		return
+	}
+	dimension.RequestChunkAsync(chunkX, chunkZ, func(chunk interfaces.IChunk) {
+		dimension.setBlock(chunk, x, y, z, block)
+	})
+}
+
+// Sets the block at the given position in the chunk, which must be the chunk the position is in.
+
+func (dimension *Dimension) setBlock(chunk interfaces.IChunk, x, y, z int, block interfaces.IBlock) {
+	if tile := chunk.GetTile(x&15, y, z&15); tile != nil && chunk.GetBlockId(x&15, y, z&15) != byte(block.GetId()) {
+		chunk.RemoveTile(tile)
+		tile.Close()
+	}
+	chunk.SetBlockId(x&15, y, z&15, byte(block.GetId()))
+	chunk.SetBlockData(x&15, y, z&15, block.GetData())
+	dimension.light.UpdateBlock(x, y, z)
+
+	var chunkIndex = GetChunkIndex(chunk.GetX(), chunk.GetZ())
+	dimension.mux.Lock()
+	if _, ok := dimension.updatedBlocks[chunkIndex]; !ok {
+		dimension.updatedBlocks[chunkIndex] = make(map[int]interfaces.IBlock)
+	}
+	dimension.updatedBlocks[chunkIndex][GetBlockIndex(x, y, z)] = block
+	dimension.mux.Unlock()
+}
+
+// Returns the block at the given position.
+// Air is returned if the chunk at the position is not loaded.
+
+func (dimension *Dimension) GetBlock(position r3.Vector) interfaces.IBlock {
+	var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+	if y < 0 || y > 255 {
+		return blocks.GetBlock(blocks.AIR, 0)
+	}
+	var chunk = dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+	if chunk == nil {
+		return blocks.GetBlock(blocks.AIR, 0)
+	}
+	return blocks.GetBlock(int(chunk.GetBlockId(x&15, y, z&15)), chunk.GetBlockData(x&15, y, z&15))
+}
+
+// Returns the tile at the given position, or nil if there is no tile at the position or its chunk is not loaded.
+
+func (dimension *Dimension) GetTile(position r3.Vector) interfaces.ITile {
+	var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+	if y < 0 || y > 255 {
+		return nil
+	}
+	var chunk = dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+	if chunk == nil {
+		return nil
+	}
+	return chunk.GetTile(x&15, y, z&15)
+}
+
+// Adds a tile to the chunk at its position, and sends it to all viewers of the chunk.
+// A tile already at the position gets closed and replaced.
+// If the chunk is not loaded yet, it gets requested and the tile is added once the chunk is loaded.
+
+func (dimension *Dimension) AddTile(tile interfaces.ITile) {
+	var chunkX, chunkZ = int32(tile.GetX() >> 4), int32(tile.GetZ() >> 4)
+	if chunk := dimension.GetLoadedChunk(chunkX, chunkZ); chunk != nil {
+		dimension.addTile(chunk, tile)
+		return
+	}
+	dimension.RequestChunkAsync(chunkX, chunkZ, func(chunk interfaces.IChunk) {
+		dimension.addTile(chunk, tile)
+	})
+}
+
+// Adds a tile to the chunk, which must be the chunk the tile is in.
+
+func (dimension *Dimension) addTile(chunk interfaces.IChunk, tile interfaces.ITile) {
+	if current := chunk.GetTile(tile.GetX()&15, tile.GetY(), tile.GetZ()&15); current != nil {
+		current.Close()
+	}
+	chunk.AddTile(tile)
+	dimension.UpdateTile(tile)
+}
+
+// Removes a tile from the chunk at its position and closes it.
+
+func (dimension *Dimension) RemoveTile(tile interfaces.ITile) {
+	if chunk := dimension.GetLoadedChunk(int32(tile.GetX()>>4), int32(tile.GetZ()>>4)); chunk != nil {
+		chunk.RemoveTile(tile)
+	}
+	tile.Close()
+}
+
+// Sends the state of a tile to all viewers of the chunk it is in.
+// Should be called after changing a tile, to show the change to players.
+
+func (dimension *Dimension) UpdateTile(tile interfaces.ITile) {
+	var chunk = dimension.GetLoadedChunk(int32(tile.GetX()>>4), int32(tile.GetZ()>>4))
+	if chunk == nil {
+		return
+	}
+	chunk.MarkDirty()
+	for _, viewer := range chunk.GetViewers() {
+		viewer.SendBlockEntityData(tile)
+	}
+}
+
+// Ticks all entities in the loaded chunks of the dimension, except for players, which get ticked by the server.
+
+func (dimension *Dimension) tickEntities() {
+	var ticked []interfaces.IEntity
+	dimension.mux.Lock()
+	for _, chunk := range dimension.chunks {
+		for _, entity := range chunk.GetEntities() {
+			if _, ok := entity.(interfaces.IPlayer); !ok {
+				ticked = append(ticked, entity)
+			}
+		}
+	}
+	dimension.mux.Unlock()
+
+	for _, entity := range ticked {
+		if !entity.IsClosed() {
+			entity.Tick()
+		}
+	}
 }
 
 // Unloads all unused chunks of the dimension.
@@ -195,7 +732,16 @@ func (dimension *Dimension) UpdateChunks() {
 	dimension.UnloadUnusedChunks()
 }
 
+// Ticks the dimension.
+// Internal. Not to be used by plugins.
+
 func (dimension *Dimension) TickDimension() {
+	dimension.deliverChunks()
+	dimension.tickEntities()
+	dimension.tickScheduledUpdates()
+	dimension.tickRandomBlocks()
 	dimension.UpdateBlocks()
-	//dimension.UpdateChunks()
+	if dimension.level.GetServer().GetCurrentTick()%20 == 0 {
+		dimension.UpdateChunks()
+	}
 }
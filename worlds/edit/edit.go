@@ -0,0 +1,214 @@
+// Package edit implements region based world editing: filling, replacing, walls and copying and pasting
+// of regions in dimensions. Blocks are changed chunk by chunk directly in the chunks, after which every
+// changed chunk is relit and sent to its viewers once, instead of sending a packet for every block.
+// Every operation returns a change set, which can be recorded in a history to undo and redo it.
+package edit
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/tiles"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/structures"
+)
+
+// state is the block ID, block data and tile NBT of a block.
+type state struct {
+	id, data byte
+	tile     nbt.Compound
+}
+
+// change is the state of a block at a position, before an edit changed it.
+type change struct {
+	x, y, z int32
+	state
+}
+
+// ChangeSet holds the previous state of all blocks changed by an edit of a dimension.
+type ChangeSet struct {
+	dimension interfaces.IDimension
+	changes   []change
+}
+
+// GetDimension returns the dimension the changes were made in.
+func (set *ChangeSet) GetDimension() interfaces.IDimension {
+	return set.dimension
+}
+
+// GetCount returns the amount of blocks that were changed.
+func (set *ChangeSet) GetCount() int {
+	return len(set.changes)
+}
+
+// revert restores the previous state of all changed blocks.
+// Returns a change set holding the state of the blocks before reverting, which reverts the revert.
+func (set *ChangeSet) revert() *ChangeSet {
+	var reverted = &ChangeSet{set.dimension, make([]change, 0, len(set.changes))}
+	var modified = make(map[[2]int32]interfaces.IChunk)
+	var chunk interfaces.IChunk
+
+	// Changes were recorded chunk by chunk, so the current chunk only needs to be looked up when it changes.
+	for i := len(set.changes) - 1; i >= 0; i-- {
+		var change = set.changes[i]
+		var chunkX, chunkZ = change.x >> 4, change.z >> 4
+		if chunk == nil || chunk.GetX() != chunkX || chunk.GetZ() != chunkZ {
+			chunk = set.dimension.GetChunk(chunkX, chunkZ)
+			modified[[2]int32{chunkX, chunkZ}] = chunk
+		}
+		var x, y, z = int(change.x), int(change.y), int(change.z)
+		reverted.changes = append(reverted.changes, record(chunk, x, y, z))
+		setState(chunk, x, y, z, change.state)
+	}
+	set.dimension.RefreshChunks(getChunks(modified))
+	return reverted
+}
+
+// Fill sets all blocks in the region to the block.
+func Fill(dimension interfaces.IDimension, region Region, block interfaces.IBlock) *ChangeSet {
+	var id, data = byte(block.GetId()), block.GetData()
+	return apply(dimension, region, func(x, y, z int, currentId, currentData byte) (state, bool) {
+		return state{id: id, data: data}, true
+	})
+}
+
+// Replace sets all blocks in the region with the block ID and block data of the old block to the replacement.
+func Replace(dimension interfaces.IDimension, region Region, old interfaces.IBlock, replacement interfaces.IBlock) *ChangeSet {
+	var oldId, oldData = byte(old.GetId()), old.GetData()
+	var id, data = byte(replacement.GetId()), replacement.GetData()
+	return apply(dimension, region, func(x, y, z int, currentId, currentData byte) (state, bool) {
+		return state{id: id, data: data}, currentId == oldId && currentData == oldData
+	})
+}
+
+// Walls sets the blocks on the horizontal outside of the region to the block.
+// For a cuboid these are its four sides, and for a sphere and cylinder their round sides.
+func Walls(dimension interfaces.IDimension, region Region, block interfaces.IBlock) *ChangeSet {
+	return Fill(dimension, walls{region}, block)
+}
+
+// Copy returns a structure of the box around the region, with the minimum corner of the region as origin.
+// Positions in the box that are not part of the region hold structure void, so that they are skipped when pasting.
+func Copy(dimension interfaces.IDimension, region Region) interfaces.IStructure {
+	var minimum = region.GetMinimum()
+	var structure = dimension.CaptureStructure(minimum, region.GetMaximum())
+	var originX, originY, originZ = blockPosition(minimum)
+	var width, height, length = structure.GetSize()
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			for z := 0; z < length; z++ {
+				if !region.Contains(originX+x, originY+y, originZ+z) {
+					structure.SetBlock(x, y, z, blocks.STRUCTURE_VOID, 0)
+					structure.SetTile(x, y, z, nil)
+				}
+			}
+		}
+	}
+	return structure
+}
+
+// Paste pastes the structure with its minimum corner at the position, after mirroring and rotating it
+// as in structures.Transform. Structure void in the structure leaves blocks unchanged.
+func Paste(dimension interfaces.IDimension, position r3.Vector, structure interfaces.IStructure, rotation int, mirror int) *ChangeSet {
+	var width, height, length = structure.GetSize()
+	if width == 0 || height == 0 || length == 0 {
+		return &ChangeSet{dimension: dimension}
+	}
+	var transformedWidth, transformedLength = structures.GetTransformedSize(width, length, rotation)
+	var originX, originY, originZ = blockPosition(position)
+	var region = NewCuboid(position, position.Add(r3.Vector{X: float64(transformedWidth - 1), Y: float64(height - 1), Z: float64(transformedLength - 1)}))
+
+	// Rotating back by the remaining quarter turns and mirroring again gives the position in the structure.
+	var inverse = (4 - rotation%4) % 4
+	return apply(dimension, region, func(x, y, z int, currentId, currentData byte) (state, bool) {
+		var structureX, structureZ = structures.Transform(x-originX, z-originZ, transformedWidth, transformedLength, inverse, structures.MirrorNone)
+		structureX, structureZ = structures.Transform(structureX, structureZ, width, length, structures.Rotation0, mirror)
+		var id, data = structure.GetBlock(structureX, y-originY, structureZ)
+		if id == blocks.STRUCTURE_VOID {
+			return state{}, false
+		}
+		return state{id, data, structure.GetTile(structureX, y-originY, structureZ)}, true
+	})
+}
+
+// apply changes the blocks in the region chunk by chunk to the states returned by the function,
+// which is called with the current block ID and block data for every position in the region.
+// Positions for which the function returns false are left unchanged.
+func apply(dimension interfaces.IDimension, region Region, function func(x, y, z int, id, data byte) (state, bool)) *ChangeSet {
+	var set = &ChangeSet{dimension: dimension}
+	var minX, minY, minZ = blockPosition(region.GetMinimum())
+	var maxX, maxY, maxZ = blockPosition(region.GetMaximum())
+	minY, maxY = int(math.Max(float64(minY), 0)), int(math.Min(float64(maxY), 255))
+	var modified = make(map[[2]int32]interfaces.IChunk)
+
+	for chunkX := minX >> 4; chunkX <= maxX>>4; chunkX++ {
+		for chunkZ := minZ >> 4; chunkZ <= maxZ>>4; chunkZ++ {
+			var chunk interfaces.IChunk
+			for x := int(math.Max(float64(minX), float64(chunkX<<4))); x <= int(math.Min(float64(maxX), float64(chunkX<<4+15))); x++ {
+				for z := int(math.Max(float64(minZ), float64(chunkZ<<4))); z <= int(math.Min(float64(maxZ), float64(chunkZ<<4+15))); z++ {
+					for y := minY; y <= maxY; y++ {
+						if !region.Contains(x, y, z) {
+							continue
+						}
+						if chunk == nil {
+							chunk = dimension.GetChunk(int32(chunkX), int32(chunkZ))
+						}
+						var id, data = chunk.GetBlockId(x&15, y, z&15), chunk.GetBlockData(x&15, y, z&15)
+						var target, ok = function(x, y, z, id, data)
+						if !ok || (target.id == id && target.data == data && target.tile == nil && chunk.GetTile(x&15, y, z&15) == nil) {
+							continue
+						}
+						set.changes = append(set.changes, record(chunk, x, y, z))
+						setState(chunk, x, y, z, target)
+						modified[[2]int32{int32(chunkX), int32(chunkZ)}] = chunk
+					}
+				}
+			}
+		}
+	}
+	dimension.RefreshChunks(getChunks(modified))
+	return set
+}
+
+// record returns the current state of the block at the position in the chunk.
+func record(chunk interfaces.IChunk, x, y, z int) change {
+	var change = change{x: int32(x), y: int32(y), z: int32(z)}
+	change.id, change.data = chunk.GetBlockId(x&15, y, z&15), chunk.GetBlockData(x&15, y, z&15)
+	if tile := chunk.GetTile(x&15, y, z&15); tile != nil {
+		change.tile = tile.Save()
+	}
+	return change
+}
+
+// setState sets the block at the position in the chunk to the state.
+// The current tile at the position is closed, and a tile is created from the NBT of the state if it has any.
+func setState(chunk interfaces.IChunk, x, y, z int, state state) {
+	if tile := chunk.GetTile(x&15, y, z&15); tile != nil {
+		chunk.RemoveTile(tile)
+		tile.Close()
+	}
+	chunk.SetBlockId(x&15, y, z&15, state.id)
+	chunk.SetBlockData(x&15, y, z&15, state.data)
+	if state.tile == nil {
+		return
+	}
+	var compound = nbt.NewCompound()
+	for name, value := range state.tile {
+		compound[name] = value
+	}
+	compound["x"], compound["y"], compound["z"] = int32(x), int32(y), int32(z)
+	if tile := tiles.NewTileFromNBT(compound); tile != nil {
+		chunk.AddTile(tile)
+	}
+}
+
+// getChunks returns the chunks in the map as a slice.
+func getChunks(chunks map[[2]int32]interfaces.IChunk) []interfaces.IChunk {
+	var list = make([]interfaces.IChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		list = append(list, chunk)
+	}
+	return list
+}
@@ -0,0 +1,117 @@
+package edit
+
+import (
+	"sync"
+)
+
+// DefaultHistoryLimit is the amount of change sets a history keeps by default.
+const DefaultHistoryLimit = 32
+
+// History is the undo and redo history of the edits of one actor, such as a player.
+type History struct {
+	mutex sync.Mutex
+	limit int
+	undo  []*ChangeSet
+	redo  []*ChangeSet
+}
+
+// NewHistory returns a new history, which keeps at most the given amount of change sets to undo.
+func NewHistory(limit int) *History {
+	if limit < 1 {
+		limit = 1
+	}
+	return &History{limit: limit}
+}
+
+// Record adds a change set to the history, so that it can be undone.
+// Change sets that were undone can no longer be redone after recording a new one.
+func (history *History) Record(set *ChangeSet) {
+	if set == nil || set.GetCount() == 0 {
+		return
+	}
+	history.mutex.Lock()
+	history.undo = append(history.undo, set)
+	if len(history.undo) > history.limit {
+		history.undo = history.undo[len(history.undo)-history.limit:]
+	}
+	history.redo = nil
+	history.mutex.Unlock()
+}
+
+// Undo reverts the last recorded change set. Returns false if there is nothing to undo.
+func (history *History) Undo() bool {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	if len(history.undo) == 0 {
+		return false
+	}
+	var set = history.undo[len(history.undo)-1]
+	history.undo = history.undo[:len(history.undo)-1]
+	history.redo = append(history.redo, set.revert())
+	return true
+}
+
+// Redo applies the last undone change set again. Returns false if there is nothing to redo.
+func (history *History) Redo() bool {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	if len(history.redo) == 0 {
+		return false
+	}
+	var set = history.redo[len(history.redo)-1]
+	history.redo = history.redo[:len(history.redo)-1]
+	history.undo = append(history.undo, set.revert())
+	return true
+}
+
+// CanUndo checks if the history has a change set to undo.
+func (history *History) CanUndo() bool {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	return len(history.undo) > 0
+}
+
+// CanRedo checks if the history has an undone change set to redo.
+func (history *History) CanRedo() bool {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	return len(history.redo) > 0
+}
+
+// Clear removes all change sets from the history.
+func (history *History) Clear() {
+	history.mutex.Lock()
+	history.undo, history.redo = nil, nil
+	history.mutex.Unlock()
+}
+
+// Histories holds the history of every actor, keyed by the name of the actor.
+type Histories struct {
+	mutex     sync.Mutex
+	limit     int
+	histories map[string]*History
+}
+
+// NewHistories returns a new set of histories, of which every history keeps at most the given amount of change sets.
+func NewHistories(limit int) *Histories {
+	return &Histories{limit: limit, histories: make(map[string]*History)}
+}
+
+// GetHistory returns the history of the actor with the given name, creating it if it does not exist yet.
+func (histories *Histories) GetHistory(actor string) *History {
+	histories.mutex.Lock()
+	defer histories.mutex.Unlock()
+	var history, ok = histories.histories[actor]
+	if !ok {
+		history = NewHistory(histories.limit)
+		histories.histories[actor] = history
+	}
+	return history
+}
+
+// RemoveHistory removes the history of the actor with the given name, for example when a player leaves.
+func (histories *Histories) RemoveHistory(actor string) {
+	histories.mutex.Lock()
+	delete(histories.histories, actor)
+	histories.mutex.Unlock()
+}
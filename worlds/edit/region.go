@@ -0,0 +1,141 @@
+package edit
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+)
+
+// Region is a selection of block positions in a dimension.
+type Region interface {
+	// GetMinimum returns the minimum corner of the box around the region.
+	GetMinimum() r3.Vector
+	// GetMaximum returns the maximum corner of the box around the region, inclusive.
+	GetMaximum() r3.Vector
+	// Contains checks if the block position is part of the region.
+	Contains(x, y, z int) bool
+}
+
+// Cuboid is a region of all blocks between two corners.
+type Cuboid struct {
+	minX, minY, minZ int
+	maxX, maxY, maxZ int
+}
+
+// NewCuboid returns a new cuboid between two corners, both inclusive.
+func NewCuboid(first, second r3.Vector) *Cuboid {
+	var x1, y1, z1 = blockPosition(first)
+	var x2, y2, z2 = blockPosition(second)
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	if z1 > z2 {
+		z1, z2 = z2, z1
+	}
+	return &Cuboid{x1, y1, z1, x2, y2, z2}
+}
+
+// GetMinimum returns the minimum corner of the cuboid.
+func (cuboid *Cuboid) GetMinimum() r3.Vector {
+	return r3.Vector{X: float64(cuboid.minX), Y: float64(cuboid.minY), Z: float64(cuboid.minZ)}
+}
+
+// GetMaximum returns the maximum corner of the cuboid.
+func (cuboid *Cuboid) GetMaximum() r3.Vector {
+	return r3.Vector{X: float64(cuboid.maxX), Y: float64(cuboid.maxY), Z: float64(cuboid.maxZ)}
+}
+
+// Contains checks if the block position is inside of the cuboid.
+func (cuboid *Cuboid) Contains(x, y, z int) bool {
+	return x >= cuboid.minX && y >= cuboid.minY && z >= cuboid.minZ && x <= cuboid.maxX && y <= cuboid.maxY && z <= cuboid.maxZ
+}
+
+// Sphere is a region of all blocks within a radius of a center block.
+type Sphere struct {
+	x, y, z int
+	radius  float64
+}
+
+// NewSphere returns a new sphere around the block at the center position.
+func NewSphere(center r3.Vector, radius float64) *Sphere {
+	var x, y, z = blockPosition(center)
+	return &Sphere{x, y, z, math.Abs(radius)}
+}
+
+// GetMinimum returns the minimum corner of the box around the sphere.
+func (sphere *Sphere) GetMinimum() r3.Vector {
+	var radius = math.Floor(sphere.radius)
+	return r3.Vector{X: float64(sphere.x) - radius, Y: float64(sphere.y) - radius, Z: float64(sphere.z) - radius}
+}
+
+// GetMaximum returns the maximum corner of the box around the sphere.
+func (sphere *Sphere) GetMaximum() r3.Vector {
+	var radius = math.Floor(sphere.radius)
+	return r3.Vector{X: float64(sphere.x) + radius, Y: float64(sphere.y) + radius, Z: float64(sphere.z) + radius}
+}
+
+// Contains checks if the block position is within the radius of the center of the sphere.
+func (sphere *Sphere) Contains(x, y, z int) bool {
+	var dx, dy, dz = float64(x - sphere.x), float64(y - sphere.y), float64(z - sphere.z)
+	return dx*dx+dy*dy+dz*dz <= sphere.radius*sphere.radius
+}
+
+// Cylinder is a region of all blocks within a horizontal radius of a center block, from the center block upwards.
+type Cylinder struct {
+	x, y, z int
+	radius  float64
+	height  int
+}
+
+// NewCylinder returns a new upright cylinder with the block at the center position as center of its bottom.
+func NewCylinder(center r3.Vector, radius float64, height int) *Cylinder {
+	var x, y, z = blockPosition(center)
+	if height < 1 {
+		height = 1
+	}
+	return &Cylinder{x, y, z, math.Abs(radius), height}
+}
+
+// GetMinimum returns the minimum corner of the box around the cylinder.
+func (cylinder *Cylinder) GetMinimum() r3.Vector {
+	var radius = math.Floor(cylinder.radius)
+	return r3.Vector{X: float64(cylinder.x) - radius, Y: float64(cylinder.y), Z: float64(cylinder.z) - radius}
+}
+
+// GetMaximum returns the maximum corner of the box around the cylinder.
+func (cylinder *Cylinder) GetMaximum() r3.Vector {
+	var radius = math.Floor(cylinder.radius)
+	return r3.Vector{X: float64(cylinder.x) + radius, Y: float64(cylinder.y + cylinder.height - 1), Z: float64(cylinder.z) + radius}
+}
+
+// Contains checks if the block position is within the height and horizontal radius of the cylinder.
+func (cylinder *Cylinder) Contains(x, y, z int) bool {
+	if y < cylinder.y || y >= cylinder.y+cylinder.height {
+		return false
+	}
+	var dx, dz = float64(x - cylinder.x), float64(z - cylinder.z)
+	return dx*dx+dz*dz <= cylinder.radius*cylinder.radius
+}
+
+// walls is the outer shell of a region on the horizontal axes: the positions of the region
+// of which at least one horizontal neighbour is not part of the region.
+type walls struct {
+	Region
+}
+
+// Contains checks if the block position is part of the walls of the region.
+func (walls walls) Contains(x, y, z int) bool {
+	if !walls.Region.Contains(x, y, z) {
+		return false
+	}
+	return !walls.Region.Contains(x+1, y, z) || !walls.Region.Contains(x-1, y, z) ||
+		!walls.Region.Contains(x, y, z+1) || !walls.Region.Contains(x, y, z-1)
+}
+
+// blockPosition returns the position of the block the vector is in.
+func blockPosition(vector r3.Vector) (int, int, int) {
+	return int(math.Floor(vector.X)), int(math.Floor(vector.Y)), int(math.Floor(vector.Z))
+}
@@ -0,0 +1,299 @@
+package worlds
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/entities"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Options of explosions, which may be combined. Explosions without options destroy blocks and damage entities.
+const (
+	// ExplosionNoBlockDamage makes an explosion leave blocks intact.
+	ExplosionNoBlockDamage = 1 << iota
+	// ExplosionNoEntityDamage makes an explosion leave entities unharmed.
+	ExplosionNoEntityDamage
+	// ExplosionFire makes an explosion set fire to some of the destroyed blocks.
+	ExplosionFire
+)
+
+// ExplosionRays is the amount of rays on every edge of the cube of rays cast by an explosion.
+const ExplosionRays = 16
+
+// ExplosionStep is the length of the steps in blocks in which explosion rays travel.
+const ExplosionStep = 0.3
+
+// ExplosionTntFuseMin and ExplosionTntFuseMax are the bounds in ticks of the fuse of TNT primed by an explosion.
+const (
+	ExplosionTntFuseMin = 10
+	ExplosionTntFuseMax = 30
+)
+
+// Explosion is an explosion in a dimension, passed to explosion handlers before it destroys blocks and damages entities.
+// Handlers may change the blocks the explosion destroys, or cancel it altogether.
+type Explosion struct {
+	dimension interfaces.IDimension
+	center    r3.Vector
+	power     float64
+	blocks    []r3.Vector
+	cancelled bool
+}
+
+// GetDimension returns the dimension the explosion happens in.
+func (explosion *Explosion) GetDimension() interfaces.IDimension {
+	return explosion.dimension
+}
+
+// GetCenter returns the center of the explosion.
+func (explosion *Explosion) GetCenter() r3.Vector {
+	return explosion.center
+}
+
+// GetPower returns the power of the explosion. The power of TNT is 4.
+func (explosion *Explosion) GetPower() float64 {
+	return explosion.power
+}
+
+// GetBlocks returns the positions of the blocks the explosion destroys.
+func (explosion *Explosion) GetBlocks() []r3.Vector {
+	return explosion.blocks
+}
+
+// SetBlocks sets the positions of the blocks the explosion destroys.
+func (explosion *Explosion) SetBlocks(blocks []r3.Vector) {
+	explosion.blocks = blocks
+}
+
+// Cancel cancels the explosion. No blocks get destroyed and no entities get damaged.
+func (explosion *Explosion) Cancel() {
+	explosion.cancelled = true
+}
+
+// IsCancelled checks if the explosion was cancelled.
+func (explosion *Explosion) IsCancelled() bool {
+	return explosion.cancelled
+}
+
+// Adds a handler called whenever an explosion is about to happen in a dimension of this level.
+
+func (level *Level) AddExplosionHandler(handler func(interfaces.IExplosion)) {
+	level.explosionHandlers = append(level.explosionHandlers, handler)
+}
+
+// Returns all handlers called when an explosion is about to happen in a dimension of this level.
+
+func (level *Level) GetExplosionHandlers() []func(interfaces.IExplosion) {
+	return level.explosionHandlers
+}
+
+// Creates an explosion with the given power at the center, as a combination of the Explosion options.
+// Rays are cast from the center in all directions, losing strength with every block they pass based on its blast resistance,
+// and all blocks reached are destroyed. Explosion handlers of the level get called first, and may change the
+// destroyed blocks or cancel the explosion. Destroyed blocks are sent to viewers in one Explode packet,
+// TNT gets primed, and entities nearby take damage and get knocked back.
+// Returns the explosion, which is cancelled if a handler cancelled it.
+
+func (dimension *Dimension) Explode(center r3.Vector, power float64, options int) interfaces.IExplosion {
+	var explosion = &Explosion{dimension: dimension, center: center, power: power}
+	if options&ExplosionNoBlockDamage == 0 {
+		explosion.blocks = dimension.getExplodedBlocks(center, power)
+	}
+	for _, handler := range dimension.level.GetExplosionHandlers() {
+		handler(explosion)
+	}
+	if explosion.IsCancelled() {
+		return explosion
+	}
+
+	dimension.destroyBlocks(explosion, options&ExplosionFire != 0)
+	if options&ExplosionNoEntityDamage == 0 {
+		dimension.damageEntities(center, power)
+	}
+	return explosion
+}
+
+// Returns the positions of all blocks reached by the rays of an explosion with the given power at the center.
+// Rays stop at chunks that are not loaded, so explosions never load or generate chunks.
+
+func (dimension *Dimension) getExplodedBlocks(center r3.Vector, power float64) []r3.Vector {
+	var reached = make(map[[3]int]bool)
+	var positions []r3.Vector
+
+	for i := 0; i < ExplosionRays; i++ {
+		for j := 0; j < ExplosionRays; j++ {
+			for k := 0; k < ExplosionRays; k++ {
+				// Only rays towards the surface of the cube of rays are cast.
+				if i != 0 && i != ExplosionRays-1 && j != 0 && j != ExplosionRays-1 && k != 0 && k != ExplosionRays-1 {
+					continue
+				}
+				var direction = r3.Vector{
+					X: float64(i)/(ExplosionRays-1)*2 - 1,
+					Y: float64(j)/(ExplosionRays-1)*2 - 1,
+					Z: float64(k)/(ExplosionRays-1)*2 - 1,
+				}.Normalize().Mul(ExplosionStep)
+
+				var position = center
+				for strength := power * (0.7 + dimension.random.Float64()*0.6); strength > 0; strength -= ExplosionStep * 0.75 {
+					var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+					if y < 0 || y > 255 {
+						break
+					}
+					var chunk = dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+					if chunk == nil {
+						break
+					}
+					var id = chunk.GetBlockId(x&15, y, z&15)
+					if id != blocks.AIR {
+						strength -= (float64(blocks.GetBlastResistance(id))/5 + 0.3) * ExplosionStep
+						if strength > 0 && !reached[[3]int{x, y, z}] {
+							reached[[3]int{x, y, z}] = true
+							positions = append(positions, r3.Vector{X: float64(x), Y: float64(y), Z: float64(z)})
+						}
+					}
+					position = position.Add(direction)
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// Destroys the blocks of the explosion directly in their chunks, and sends them to the viewers of the chunks in one
+// Explode packet. Blocks in chunks that are no longer loaded are skipped.
+// TNT among the blocks gets primed if the TNT explodes game rule is enabled.
+// Some of the destroyed blocks are set on fire if fire is true.
+
+func (dimension *Dimension) destroyBlocks(explosion *Explosion, fire bool) {
+	var center = explosion.GetCenter()
+	var origin = r3.Vector{X: math.Floor(center.X), Y: math.Floor(center.Y), Z: math.Floor(center.Z)}
+	var viewers = make(map[uint64]interfaces.IPlayer)
+	var records []r3.Vector
+	var tnt []r3.Vector
+
+	for _, position := range explosion.GetBlocks() {
+		var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+		if y < 0 || y > 255 {
+			continue
+		}
+		var chunk = dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+		if chunk == nil {
+			continue
+		}
+		var id = chunk.GetBlockId(x&15, y, z&15)
+		if id == blocks.AIR {
+			continue
+		}
+		if id == blocks.TNT {
+			tnt = append(tnt, position)
+		}
+		if tile := chunk.GetTile(x&15, y, z&15); tile != nil {
+			chunk.RemoveTile(tile)
+			tile.Close()
+		}
+		chunk.SetBlockId(x&15, y, z&15, blocks.AIR)
+		chunk.SetBlockData(x&15, y, z&15, 0)
+		dimension.light.UpdateBlock(x, y, z)
+
+		records = append(records, r3.Vector{X: float64(x) - origin.X, Y: float64(y) - origin.Y, Z: float64(z) - origin.Z})
+		for runtimeId, viewer := range chunk.GetViewers() {
+			viewers[runtimeId] = viewer
+		}
+	}
+	if len(records) == 0 {
+		return
+	}
+	for _, viewer := range viewers {
+		viewer.SendExplode(center, float32(explosion.GetPower()), records)
+	}
+
+	for _, position := range tnt {
+		dimension.PrimeTnt(position, ExplosionTntFuseMin+dimension.random.Intn(ExplosionTntFuseMax-ExplosionTntFuseMin))
+	}
+	if !fire {
+		return
+	}
+	for _, record := range records {
+		var position = origin.Add(record)
+		if dimension.random.Intn(3) != 0 || position.Y < 1 {
+			continue
+		}
+		var below = dimension.GetBlock(position.Sub(r3.Vector{Y: 1}))
+		if dimension.GetBlock(position).GetId() == blocks.AIR && below.GetLightFilterLevel() == 15 {
+			dimension.SetBlock(position, blocks.GetBlock(blocks.FIRE, 0))
+		}
+	}
+}
+
+// Damages and knocks back all entities within twice the power of an explosion from the center.
+// Entities closer to the center take more damage and get knocked back further.
+
+func (dimension *Dimension) damageEntities(center r3.Vector, power float64) {
+	var radius = power * 2
+	var minX, maxX = int32(math.Floor(center.X-radius)) >> 4, int32(math.Floor(center.X+radius)) >> 4
+	var minZ, maxZ = int32(math.Floor(center.Z-radius)) >> 4, int32(math.Floor(center.Z+radius)) >> 4
+
+	var nearby []interfaces.IEntity
+	for chunkX := minX; chunkX <= maxX; chunkX++ {
+		for chunkZ := minZ; chunkZ <= maxZ; chunkZ++ {
+			if chunk := dimension.GetLoadedChunk(chunkX, chunkZ); chunk != nil {
+				for _, entity := range chunk.GetEntities() {
+					nearby = append(nearby, entity)
+				}
+			}
+		}
+	}
+
+	for _, entity := range nearby {
+		if entity.IsClosed() {
+			continue
+		}
+		var offset = entity.GetPosition().Sub(center)
+		var distance = offset.Norm() / radius
+		if distance > 1 {
+			continue
+		}
+		var direction = r3.Vector{Y: 1}
+		if offset.Norm() > 0 {
+			direction = offset.Normalize()
+		}
+		var impact = 1 - distance
+		var damage = math.Floor((impact*impact+impact)/2*8*radius + 1)
+
+		entity.SetMotion(entity.GetMotion().Add(direction.Mul(impact)))
+		if health := entity.GetHealth() - float32(damage); health > 0 {
+			entity.SetHealth(health)
+		} else {
+			entity.Kill()
+		}
+		// Players do not have physics, so their client has to apply the knockback.
+		if player, ok := entity.(interfaces.IPlayer); ok {
+			player.SendUpdateAttributes(player, player.GetAttributeMap())
+			player.SendSetEntityMotion(player, player.GetMotion())
+		}
+	}
+}
+
+// Removes the TNT block at the given position if there is one, and spawns primed TNT that explodes after the fuse in ticks.
+// Returns the primed TNT, or nil if the TNT explodes game rule is disabled or the chunk of the position is not loaded.
+
+func (dimension *Dimension) PrimeTnt(position r3.Vector, fuse int) interfaces.IEntity {
+	if !dimension.level.GetGameRule(GameRuleTntExplodes).GetValue().(bool) {
+		return nil
+	}
+	var x, y, z = math.Floor(position.X), math.Floor(position.Y), math.Floor(position.Z)
+	var chunk = dimension.GetLoadedChunk(int32(x)>>4, int32(z)>>4)
+	if chunk == nil {
+		return nil
+	}
+	position = r3.Vector{X: x, Y: y, Z: z}
+	if dimension.GetBlock(position).GetId() == blocks.TNT {
+		dimension.SetBlock(position, blocks.GetBlock(blocks.AIR, 0))
+	}
+
+	var tnt = entities.NewTnt(r3.Vector{X: x + 0.5, Y: y, Z: z + 0.5}, fuse, dimension.level, dimension)
+	chunk.AddEntity(tnt)
+	tnt.SpawnToAll()
+	return tnt
+}
@@ -0,0 +1,53 @@
+package biomes
+
+import (
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Biome IDs as used by Minecraft Bedrock Edition.
+const (
+	Ocean     = 0
+	Plains    = 1
+	Desert    = 2
+	Mountains = 3
+	Forest    = 4
+	Hell      = 8
+	TheEnd    = 9
+)
+
+// Biome describes the surface of a biome.
+// The top block is placed at the surface, followed by the filler block for the filler depth.
+// Columns that are under water use the underwater block as top block instead.
+type Biome struct {
+	Id              int
+	Name            string
+	TopBlock        byte
+	FillerBlock     byte
+	UnderwaterBlock byte
+	FillerDepth     int
+}
+
+var list = map[int]*Biome{}
+
+func init() {
+	RegisterBiome(&Biome{Ocean, "Ocean", blocks.SAND, blocks.SAND, blocks.GRAVEL, 3})
+	RegisterBiome(&Biome{Plains, "Plains", blocks.GRASS, blocks.DIRT, blocks.DIRT, 3})
+	RegisterBiome(&Biome{Desert, "Desert", blocks.SAND, blocks.SANDSTONE, blocks.SAND, 4})
+	RegisterBiome(&Biome{Mountains, "Extreme Hills", blocks.GRASS, blocks.DIRT, blocks.GRAVEL, 2})
+	RegisterBiome(&Biome{Forest, "Forest", blocks.GRASS, blocks.DIRT, blocks.DIRT, 3})
+	RegisterBiome(&Biome{Hell, "Hell", blocks.NETHERRACK, blocks.NETHERRACK, blocks.NETHERRACK, 0})
+	RegisterBiome(&Biome{TheEnd, "The End", blocks.END_STONE, blocks.END_STONE, blocks.END_STONE, 0})
+}
+
+// RegisterBiome registers the biome, replacing any biome with the same ID.
+func RegisterBiome(biome *Biome) {
+	list[biome.Id] = biome
+}
+
+// GetBiome returns the biome with the given ID, or plains if no biome with the ID is registered.
+func GetBiome(id int) *Biome {
+	if biome, ok := list[id]; ok {
+		return biome
+	}
+	return list[Plains]
+}
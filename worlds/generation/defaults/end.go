@@ -0,0 +1,88 @@
+package defaults
+
+import (
+	"math"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/generation/biomes"
+	"github.com/irmine/gomine/worlds/generation/noise"
+)
+
+const (
+	// EndIslandRadius is the radius in blocks of the main island of the end.
+	EndIslandRadius = 96
+	// EndIslandLevel is the Y value around which the islands of the end get generated.
+	EndIslandLevel = 60
+	// EndOuterIslandDistance is the distance from the origin from which outer islands may generate.
+	EndOuterIslandDistance = 1024
+)
+
+// End generates end terrain: a main end stone island around the origin, with smaller islands far away from it.
+type End struct {
+	*Generator
+	shape   *noise.Octaves
+	islands *noise.Octaves
+}
+
+func NewEndGenerator() End {
+	return newEnd(0, "")
+}
+
+// Returns a new end generator with the given seed and options.
+
+func (e End) New(seed int64, options string) interfaces.IGenerator {
+	return newEnd(seed, options)
+}
+
+func newEnd(seed int64, options string) End {
+	return End{
+		Generator: NewGenerator("End", seed, options),
+		shape:     noise.NewOctaves(seed, 3, 1.0/32, 0.5),
+		islands:   noise.NewOctaves(seed+1, 3, 1.0/96, 0.5),
+	}
+}
+
+// Generates new chunk.
+
+func (e End) GetNewChunk(chunk interfaces.IChunk) interfaces.IChunk {
+	e.GenerateChunk(chunk)
+
+	return chunk
+}
+
+func (e End) GenerateChunk(chunk interfaces.IChunk) {
+	var baseX, baseZ = int(chunk.GetX()) << 4, int(chunk.GetZ()) << 4
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			chunk.SetBiome(x, z, biomes.TheEnd)
+
+			var bottom, top = e.getIsland(baseX+x, baseZ+z)
+			for y := bottom; y <= top; y++ {
+				chunk.SetBlockId(x, y, z, blocks.END_STONE)
+			}
+		}
+	}
+	chunk.RecalculateHeightMap()
+}
+
+// getIsland returns the lowest and highest Y value of end stone in the column at the given world x/z coordinates.
+// The lowest value is bigger than the highest value if the column is empty.
+func (e End) getIsland(x, z int) (int, int) {
+	var fx, fz = float64(x), float64(z)
+	var shape = e.shape.Noise2D(fx, fz)
+	var distance = math.Sqrt(fx*fx + fz*fz)
+
+	var size float64
+	if radius := EndIslandRadius + shape*16; distance < radius {
+		size = math.Sqrt(1 - (distance/radius)*(distance/radius))
+	} else if distance >= EndOuterIslandDistance {
+		size = (e.islands.Noise2D(fx, fz) - 0.4) * 3
+	}
+	if size <= 0 {
+		return 1, 0
+	}
+	var top = EndIslandLevel + int(size*4+shape*2)
+	var bottom = EndIslandLevel - int(size*40*(1+shape*0.25))
+	return bottom, top
+}
@@ -9,14 +9,19 @@ type Flat struct {
 }
 
 func NewFlatGenerator() Flat {
-	return Flat{NewGenerator("Flat")}
+	return Flat{NewGenerator("Flat", 0, "")}
 }
 
-// Generates and populates new chunk.
+// Returns a new flat generator with the given seed and options.
+
+func (f Flat) New(seed int64, options string) interfaces.IGenerator {
+	return Flat{NewGenerator("Flat", seed, options)}
+}
+
+// Generates new chunk.
 
 func (f Flat) GetNewChunk(chunk interfaces.IChunk) interfaces.IChunk {
 	f.GenerateChunk(chunk)
-	f.PopulateChunk(chunk)
 
 	return chunk
 }
@@ -33,12 +38,6 @@ func (f Flat) GenerateChunk(chunk interfaces.IChunk) {
 			chunk.SetBlockId(x, y, z, 3)
 			y++
 			chunk.SetBlockId(x, y, z, 2)
-
-			chunk.SetHeight(y)
-
-			for i := y - 1; i >= 0; i-- {
-				chunk.SetSkyLight(x, y, z, 0)
-			}
 		}
 	}
 	chunk.RecalculateHeightMap()
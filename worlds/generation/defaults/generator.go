@@ -1,23 +1,75 @@
 package defaults
 
 import (
+	"math/rand"
+
 	"github.com/irmine/gomine/interfaces"
 )
 
 type Generator struct {
-	name string
+	name    string
+	seed    int64
+	options string
+
+	populators      []interfaces.IPopulator
+	biomePopulators map[int][]interfaces.IPopulator
 }
 
-func NewGenerator(name string) *Generator {
-	return &Generator{name: name}
+func NewGenerator(name string, seed int64, options string) *Generator {
+	return &Generator{name: name, seed: seed, options: options, biomePopulators: make(map[int][]interfaces.IPopulator)}
 }
 
 func (gen *Generator) GetName() string {
 	return gen.name
 }
 
+// Returns the seed this generator generates chunks with.
+
+func (gen *Generator) GetSeed() int64 {
+	return gen.seed
+}
+
+// Returns the generator options, as stored in the level data.
+
+func (gen *Generator) GetOptions() string {
+	return gen.options
+}
+
 func (gen *Generator) GenerateChunk(interfaces.IChunk) {
 }
 
-func (gen *Generator) PopulateChunk(interfaces.IChunk) {
+// Adds a populator that runs for every chunk, regardless of its biome.
+
+func (gen *Generator) AddPopulator(populator interfaces.IPopulator) {
+	gen.populators = append(gen.populators, populator)
+}
+
+// Adds a populator that only runs for chunks of the given biome.
+// The biome of a chunk is the biome at its center.
+
+func (gen *Generator) AddBiomePopulator(biome int, populator interfaces.IPopulator) {
+	gen.biomePopulators[biome] = append(gen.biomePopulators[biome], populator)
+}
+
+// Returns all populators that run for chunks of the given biome.
+
+func (gen *Generator) GetPopulators(biome int) []interfaces.IPopulator {
+	var populators = make([]interfaces.IPopulator, 0, len(gen.populators)+len(gen.biomePopulators[biome]))
+	populators = append(populators, gen.populators...)
+	return append(populators, gen.biomePopulators[biome]...)
+}
+
+// Runs all populators for the chunk in the center of the area.
+// This is called by the dimension after the chunk has been generated, once all its neighbouring chunks are loaded.
+// The random used by the populators is seeded with the generator seed and the chunk coordinates,
+// so that the same seed always produces the same features.
+
+func (gen *Generator) PopulateChunk(area interfaces.IChunkArea) {
+	var x, z = int64(area.GetChunkX()), int64(area.GetChunkZ())
+	var random = rand.New(rand.NewSource(gen.seed ^ x*341873128712 ^ z*132897987541))
+
+	var biome = area.GetBiome(int(x)<<4|8, int(z)<<4|8)
+	for _, populator := range gen.GetPopulators(biome) {
+		populator.Populate(area, random)
+	}
 }
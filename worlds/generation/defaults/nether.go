@@ -0,0 +1,86 @@
+package defaults
+
+import (
+	"math"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/generation/biomes"
+	"github.com/irmine/gomine/worlds/generation/noise"
+)
+
+const (
+	// NetherHeight is the height of the nether, including the bedrock roof.
+	NetherHeight = 128
+	// LavaLevel is the highest Y value filled with lava by the nether generator.
+	LavaLevel = 31
+)
+
+// Nether generates nether terrain: a netherrack floor and roof with a lava sea in between,
+// enclosed by bedrock at the bottom and the top.
+type Nether struct {
+	*Generator
+	floor    *noise.Octaves
+	roof     *noise.Octaves
+	soulSand *noise.Octaves
+}
+
+func NewNetherGenerator() Nether {
+	return newNether(0, "")
+}
+
+// Returns a new nether generator with the given seed and options.
+
+func (n Nether) New(seed int64, options string) interfaces.IGenerator {
+	return newNether(seed, options)
+}
+
+func newNether(seed int64, options string) Nether {
+	return Nether{
+		Generator: NewGenerator("Nether", seed, options),
+		floor:     noise.NewOctaves(seed, 4, 1.0/48, 0.5),
+		roof:      noise.NewOctaves(seed+1, 4, 1.0/48, 0.5),
+		soulSand:  noise.NewOctaves(seed+2, 2, 1.0/24, 0.5),
+	}
+}
+
+// Generates new chunk.
+
+func (n Nether) GetNewChunk(chunk interfaces.IChunk) interfaces.IChunk {
+	n.GenerateChunk(chunk)
+
+	return chunk
+}
+
+func (n Nether) GenerateChunk(chunk interfaces.IChunk) {
+	var baseX, baseZ = int(chunk.GetX()) << 4, int(chunk.GetZ()) << 4
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			var fx, fz = float64(baseX + x), float64(baseZ + z)
+			var floor = int(math.Floor(LavaLevel + n.floor.Noise2D(fx, fz)*24))
+			var roof = int(math.Floor(96 + n.roof.Noise2D(fx, fz)*24))
+			if roof <= floor+4 {
+				roof = floor + 4
+			}
+			var top byte = blocks.NETHERRACK
+			if n.soulSand.Noise2D(fx, fz) > 0.35 {
+				top = blocks.SOUL_SAND
+			}
+
+			chunk.SetBiome(x, z, biomes.Hell)
+			chunk.SetBlockId(x, 0, z, blocks.BEDROCK)
+			chunk.SetBlockId(x, NetherHeight-1, z, blocks.BEDROCK)
+			for y := 1; y < NetherHeight-1; y++ {
+				switch {
+				case y == floor:
+					chunk.SetBlockId(x, y, z, top)
+				case y < floor || y >= roof:
+					chunk.SetBlockId(x, y, z, blocks.NETHERRACK)
+				case y <= LavaLevel:
+					chunk.SetBlockId(x, y, z, blocks.STILL_LAVA)
+				}
+			}
+		}
+	}
+	chunk.RecalculateHeightMap()
+}
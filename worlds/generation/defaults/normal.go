@@ -0,0 +1,145 @@
+package defaults
+
+import (
+	"math"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/generation/biomes"
+	"github.com/irmine/gomine/worlds/generation/noise"
+	"github.com/irmine/gomine/worlds/generation/populators"
+)
+
+// SeaLevel is the highest Y value filled with water by the normal generator.
+const SeaLevel = 62
+
+// Normal generates overworld terrain using simplex noise.
+// The generated terrain only depends on the seed and the chunk coordinates.
+type Normal struct {
+	*Generator
+	continental *noise.Octaves
+	detail      *noise.Octaves
+	ridges      *noise.Octaves
+	temperature *noise.Octaves
+	rainfall    *noise.Octaves
+}
+
+func NewNormalGenerator() Normal {
+	return newNormal(0, "")
+}
+
+// Returns a new normal generator with the given seed and options.
+
+func (n Normal) New(seed int64, options string) interfaces.IGenerator {
+	return newNormal(seed, options)
+}
+
+func newNormal(seed int64, options string) Normal {
+	var n = Normal{
+		Generator:   NewGenerator("Normal", seed, options),
+		continental: noise.NewOctaves(seed, 4, 1.0/600, 0.5),
+		detail:      noise.NewOctaves(seed+1, 4, 1.0/64, 0.5),
+		ridges:      noise.NewOctaves(seed+2, 3, 1.0/128, 0.5),
+		temperature: noise.NewOctaves(seed+3, 2, 1.0/400, 0.5),
+		rainfall:    noise.NewOctaves(seed+4, 2, 1.0/350, 0.5),
+	}
+
+	n.AddPopulator(populators.NewOre(blocks.COAL_ORE, 16, 20, 1, 128))
+	n.AddPopulator(populators.NewOre(blocks.IRON_ORE, 8, 20, 1, 64))
+	n.AddPopulator(populators.NewOre(blocks.GOLD_ORE, 8, 2, 1, 32))
+	n.AddPopulator(populators.NewOre(blocks.LAPIS_ORE, 6, 1, 1, 32))
+	n.AddPopulator(populators.NewOre(blocks.GRAVEL, 24, 8, 1, 128))
+
+	n.AddBiomePopulator(biomes.Plains, populators.NewLake(blocks.STILL_WATER, 12))
+	n.AddBiomePopulator(biomes.Plains, populators.NewTree(populators.WoodOak, 0, 1))
+	n.AddBiomePopulator(biomes.Plains, populators.NewTallGrass(24))
+	n.AddBiomePopulator(biomes.Plains, populators.NewFlowers(4))
+
+	n.AddBiomePopulator(biomes.Forest, populators.NewLake(blocks.STILL_WATER, 16))
+	n.AddBiomePopulator(biomes.Forest, populators.NewTree(populators.WoodOak, 3, 6))
+	n.AddBiomePopulator(biomes.Forest, populators.NewTree(populators.WoodBirch, 0, 2))
+	n.AddBiomePopulator(biomes.Forest, populators.NewTallGrass(8))
+	n.AddBiomePopulator(biomes.Forest, populators.NewFlowers(2))
+
+	n.AddBiomePopulator(biomes.Mountains, populators.NewTree(populators.WoodSpruce, 0, 2))
+	n.AddBiomePopulator(biomes.Mountains, populators.NewTallGrass(4))
+
+	n.AddBiomePopulator(biomes.Desert, populators.NewDeadBushes(2))
+
+	return n
+}
+
+// Generates new chunk.
+
+func (n Normal) GetNewChunk(chunk interfaces.IChunk) interfaces.IChunk {
+	n.GenerateChunk(chunk)
+
+	return chunk
+}
+
+func (n Normal) GenerateChunk(chunk interfaces.IChunk) {
+	var baseX, baseZ = int(chunk.GetX()) << 4, int(chunk.GetZ()) << 4
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			var height, biome = n.GetColumn(baseX+x, baseZ+z)
+			chunk.SetBiome(x, z, biome.Id)
+
+			var top, filler = biome.TopBlock, biome.FillerBlock
+			if height < SeaLevel {
+				top = biome.UnderwaterBlock
+			}
+
+			chunk.SetBlockId(x, 0, z, blocks.BEDROCK)
+			for y := 1; y <= height; y++ {
+				switch {
+				case y == height:
+					chunk.SetBlockId(x, y, z, top)
+				case y > height-biome.FillerDepth:
+					chunk.SetBlockId(x, y, z, filler)
+				default:
+					chunk.SetBlockId(x, y, z, blocks.STONE)
+				}
+			}
+			for y := height + 1; y <= SeaLevel; y++ {
+				chunk.SetBlockId(x, y, z, blocks.STILL_WATER)
+			}
+		}
+	}
+	chunk.RecalculateHeightMap()
+}
+
+// GetColumn returns the terrain height and the biome of the column at the given world x/z coordinates.
+func (n Normal) GetColumn(x, z int) (int, *biomes.Biome) {
+	var fx, fz = float64(x), float64(z)
+	// Shifted up slightly, so that there is more land than ocean.
+	var continental = n.continental.Noise2D(fx, fz) + 0.1
+	var detail = n.detail.Noise2D(fx, fz)
+
+	var height float64
+	if continental < 0 {
+		height = SeaLevel + continental*40 + detail*4
+	} else {
+		height = SeaLevel + 1 + continental*20 + detail*(3+continental*6)
+	}
+
+	var mountains = math.Max(0, math.Min(1, (continental-0.3)/0.25))
+	if mountains > 0 {
+		var ridge = 1 - math.Abs(n.ridges.Noise2D(fx, fz))
+		height += mountains * (20 + 40*ridge)
+	}
+	var y = int(math.Max(1, math.Min(250, math.Floor(height))))
+
+	var temperature = n.temperature.Noise2D(fx, fz)
+	var rainfall = n.rainfall.Noise2D(fx, fz)
+	switch {
+	case y < SeaLevel:
+		return y, biomes.GetBiome(biomes.Ocean)
+	case mountains > 0.5:
+		return y, biomes.GetBiome(biomes.Mountains)
+	case temperature > 0.25 && rainfall < 0:
+		return y, biomes.GetBiome(biomes.Desert)
+	case rainfall > 0.2:
+		return y, biomes.GetBiome(biomes.Forest)
+	}
+	return y, biomes.GetBiome(biomes.Plains)
+}
@@ -0,0 +1,47 @@
+package defaults
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/irmine/gomine/worlds/chunks"
+)
+
+// goldenChunks are the SHA-256 hashes of the network payload of chunks generated by the normal generator.
+// They have to be updated whenever the generated terrain is changed on purpose.
+var goldenChunks = []struct {
+	seed int64
+	x, z int32
+	hash string
+}{
+	{0, 0, 0, "9ed0bd15876e99608f01ea41e771e3e9a480b3143f9198f6afe8d4b70e7eb932"},
+	{42, 0, 0, "4ab420f76720cf9b5945274a57108a1aafa769ffd78e7980cdcd8f8ea555d76b"},
+	{42, -3, 7, "949c17028488019bef26a0d9f75a1d996e3bdc374c8f2a882e036b74ba36433e"},
+	{42, 100, -250, "5b28f8191a107d3e004aac4cf61599240d9adf538f4746aef8a1864f28697156"},
+	{-7389210021, 12, 12, "208b87ffc1e6d4186a90f4e29a50fe9aadfdc25e10f344dfab1cb70c88c4b2e3"},
+	{123456789, -1000, 1000, "022e7c05ac633901a6667d36b10c924f12537a21d2d6d8c07ae40afa39927ff4"},
+}
+
+func TestNormalGoldenChunks(t *testing.T) {
+	for _, golden := range goldenChunks {
+		var generator = NewNormalGenerator().New(golden.seed, "")
+		var chunk = generator.GetNewChunk(chunks.NewChunk(golden.x, golden.z))
+		var sum = sha256.Sum256(chunk.ToBinary())
+		if hash := hex.EncodeToString(sum[:]); hash != golden.hash {
+			t.Errorf("seed %v chunk %v, %v: got hash %v, want %v", golden.seed, golden.x, golden.z, hash, golden.hash)
+		}
+	}
+}
+
+func TestNormalDeterministic(t *testing.T) {
+	var first = NewNormalGenerator().New(42, "").GetNewChunk(chunks.NewChunk(5, -5)).ToBinary()
+	var second = NewNormalGenerator().New(42, "").GetNewChunk(chunks.NewChunk(5, -5)).ToBinary()
+	if sha256.Sum256(first) != sha256.Sum256(second) {
+		t.Error("generating the same chunk twice with the same seed gave different terrain")
+	}
+	var other = NewNormalGenerator().New(43, "").GetNewChunk(chunks.NewChunk(5, -5)).ToBinary()
+	if sha256.Sum256(first) == sha256.Sum256(other) {
+		t.Error("generating the same chunk with a different seed gave the same terrain")
+	}
+}
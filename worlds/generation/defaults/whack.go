@@ -11,14 +11,19 @@ type Whack struct {
 }
 
 func NewWhackGenerator() Whack {
-	return Whack{NewGenerator("Whack")}
+	return Whack{NewGenerator("Whack", 0, "")}
 }
 
-// Generates and populates new chunk.
+// Returns a new whack generator with the given seed and options.
+
+func (f Whack) New(seed int64, options string) interfaces.IGenerator {
+	return Whack{NewGenerator("Whack", seed, options)}
+}
+
+// Generates new chunk.
 
 func (f Whack) GetNewChunk(chunk interfaces.IChunk) interfaces.IChunk {
 	f.GenerateChunk(chunk)
-	f.PopulateChunk(chunk)
 
 	return chunk
 }
@@ -37,12 +42,6 @@ func (f Whack) GenerateChunk(chunk interfaces.IChunk) {
 		chunk.SetBlockId(i+x, y+i, i+z, 3)
 		y++
 		chunk.SetBlockId(i+x, y+i, i+z, 2)
-
-		chunk.SetHeight(y)
-
-		for i := y - 1; i >= 0; i-- {
-			chunk.SetSkyLight(x, y, z, 0)
-		}
 	}
 	chunk.RecalculateHeightMap()
 }
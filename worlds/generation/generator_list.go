@@ -10,6 +10,9 @@ var list = map[string]interfaces.IGenerator{}
 func init() {
 	RegisterGenerator(defaults.NewFlatGenerator())
 	RegisterGenerator(defaults.NewWhackGenerator())
+	RegisterGenerator(defaults.NewNormalGenerator())
+	RegisterGenerator(defaults.NewNetherGenerator())
+	RegisterGenerator(defaults.NewEndGenerator())
 }
 
 func RegisterGenerator(generator interfaces.IGenerator) {
@@ -41,3 +44,12 @@ func DeRegisterGeneratorByName(generator string) {
 func GetGeneratorByName(generator string) interfaces.IGenerator {
 	return list[generator]
 }
+
+// CreateGenerator returns a new generator with the given name, seed and options.
+// Returns nil if no generator with the name is registered.
+func CreateGenerator(generator string, seed int64, options string) interfaces.IGenerator {
+	if !GeneratorNameExists(generator) {
+		return nil
+	}
+	return list[generator].New(seed, options)
+}
@@ -0,0 +1,33 @@
+package noise
+
+// Octaves combines several layers of simplex noise with increasing frequency and decreasing amplitude.
+type Octaves struct {
+	layers      []*Simplex
+	frequency   float64
+	persistence float64
+	lacunarity  float64
+}
+
+// NewOctaves returns new octave noise with the given amount of octaves, seeded with the given seed.
+// Frequency is the frequency of the first octave. Every next octave has its frequency multiplied by 2,
+// and its amplitude multiplied by the persistence.
+func NewOctaves(seed int64, octaves int, frequency, persistence float64) *Octaves {
+	var layers = make([]*Simplex, octaves)
+	for i := range layers {
+		layers[i] = NewSimplex(seed + int64(i)*7919)
+	}
+	return &Octaves{layers, frequency, persistence, 2}
+}
+
+// Noise2D returns the combined noise value at the given x/z coordinates, normalized to the range of about -1 to 1.
+func (octaves *Octaves) Noise2D(x, z float64) float64 {
+	var value, amplitude, total = 0.0, 1.0, 0.0
+	var frequency = octaves.frequency
+	for _, layer := range octaves.layers {
+		value += layer.Noise2D(x*frequency, z*frequency) * amplitude
+		total += amplitude
+		amplitude *= octaves.persistence
+		frequency *= octaves.lacunarity
+	}
+	return value / total
+}
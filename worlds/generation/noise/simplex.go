@@ -0,0 +1,82 @@
+package noise
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Skewing factors for 2D simplex noise.
+var (
+	f2 = 0.5 * (math.Sqrt(3) - 1)
+	g2 = (3 - math.Sqrt(3)) / 6
+)
+
+// gradients2D are the gradient directions used for 2D simplex noise.
+var gradients2D = [12][2]float64{
+	{1, 1}, {-1, 1}, {1, -1}, {-1, -1},
+	{1, 0}, {-1, 0}, {1, 0}, {-1, 0},
+	{0, 1}, {0, -1}, {0, 1}, {0, -1},
+}
+
+// Simplex is a seeded 2D simplex noise generator.
+// The same seed always produces the same noise, regardless of the platform.
+type Simplex struct {
+	permutations [512]int
+	offsetX      float64
+	offsetZ      float64
+}
+
+// NewSimplex returns a new simplex noise generator using the given seed.
+func NewSimplex(seed int64) *Simplex {
+	var random = rand.New(rand.NewSource(seed))
+	var simplex = &Simplex{
+		offsetX: random.Float64() * 256,
+		offsetZ: random.Float64() * 256,
+	}
+	var permutations = random.Perm(256)
+	for i := 0; i < 512; i++ {
+		simplex.permutations[i] = permutations[i&255]
+	}
+	return simplex
+}
+
+// Noise2D returns the noise value at the given x/z coordinates, in the range of about -1 to 1.
+func (simplex *Simplex) Noise2D(x, z float64) float64 {
+	x += simplex.offsetX
+	z += simplex.offsetZ
+
+	var s = (x + z) * f2
+	var i = math.Floor(x + s)
+	var j = math.Floor(z + s)
+	var t = (i + j) * g2
+	var x0 = x - (i - t)
+	var z0 = z - (j - t)
+
+	var i1, j1 = 0, 1
+	if x0 > z0 {
+		i1, j1 = 1, 0
+	}
+
+	var x1 = x0 - float64(i1) + g2
+	var z1 = z0 - float64(j1) + g2
+	var x2 = x0 - 1 + 2*g2
+	var z2 = z0 - 1 + 2*g2
+
+	var ii = int(i) & 255
+	var jj = int(j) & 255
+	var p = &simplex.permutations
+
+	return 70 * (corner(p[ii+p[jj]]%12, x0, z0) +
+		corner(p[ii+i1+p[jj+j1]]%12, x1, z1) +
+		corner(p[ii+1+p[jj+1]]%12, x2, z2))
+}
+
+// corner returns the contribution of a single simplex corner.
+func corner(gradient int, x, z float64) float64 {
+	var t = 0.5 - x*x - z*z
+	if t < 0 {
+		return 0
+	}
+	t *= t
+	return t * t * (gradients2D[gradient][0]*x + gradients2D[gradient][1]*z)
+}
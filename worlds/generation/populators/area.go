@@ -0,0 +1,117 @@
+// Package populators implements chunk populators that place features such as ores, trees and plants.
+//
+// Generators only generate the terrain of new chunks. Populators run separately, on an area of three by three
+// chunks with the chunk being populated in the center, so that features may cross the borders of the chunk.
+// The dimension populates a chunk once the chunk and all its neighbouring chunks are loaded.
+package populators
+
+import (
+	"github.com/irmine/gomine/interfaces"
+)
+
+// Area is a chunk being populated together with its eight neighbouring chunks.
+type Area struct {
+	chunkX, chunkZ int32
+	chunks         [3][3]interfaces.IChunk
+	modified       [3][3]bool
+}
+
+// NewArea returns a new area around the chunk at the given chunk coordinates.
+// The chunks must be indexed by their x/z offset to the center chunk plus one.
+func NewArea(chunkX, chunkZ int32, chunks [3][3]interfaces.IChunk) *Area {
+	return &Area{chunkX: chunkX, chunkZ: chunkZ, chunks: chunks}
+}
+
+// GetChunkX returns the x coordinate of the chunk being populated.
+func (area *Area) GetChunkX() int32 {
+	return area.chunkX
+}
+
+// GetChunkZ returns the z coordinate of the chunk being populated.
+func (area *Area) GetChunkZ() int32 {
+	return area.chunkZ
+}
+
+// GetChunk returns the chunk at the given chunk coordinates, or nil if it is outside of the area.
+func (area *Area) GetChunk(x, z int32) interfaces.IChunk {
+	var dx, dz = x - area.chunkX + 1, z - area.chunkZ + 1
+	if dx < 0 || dx > 2 || dz < 0 || dz > 2 {
+		return nil
+	}
+	return area.chunks[dx][dz]
+}
+
+// GetModifiedChunks returns all chunks of the area that had blocks set.
+func (area *Area) GetModifiedChunks() []interfaces.IChunk {
+	var chunks []interfaces.IChunk
+	for x := 0; x < 3; x++ {
+		for z := 0; z < 3; z++ {
+			if area.modified[x][z] {
+				chunks = append(chunks, area.chunks[x][z])
+			}
+		}
+	}
+	return chunks
+}
+
+// chunkAt returns the chunk containing the block at the world coordinates, or nil if it is outside of the area.
+func (area *Area) chunkAt(x, y, z int) interfaces.IChunk {
+	if y < 0 || y > 255 {
+		return nil
+	}
+	return area.GetChunk(int32(x>>4), int32(z>>4))
+}
+
+// markModified marks the chunk containing the block at the world x/z coordinates as modified.
+func (area *Area) markModified(x, z int) {
+	area.modified[int32(x>>4)-area.chunkX+1][int32(z>>4)-area.chunkZ+1] = true
+}
+
+// GetBlockId returns the block ID at the given world coordinates.
+func (area *Area) GetBlockId(x, y, z int) byte {
+	if chunk := area.chunkAt(x, y, z); chunk != nil {
+		return chunk.GetBlockId(x&15, y, z&15)
+	}
+	return 0
+}
+
+// SetBlockId sets the block ID at the given world coordinates.
+func (area *Area) SetBlockId(x, y, z int, id byte) {
+	if chunk := area.chunkAt(x, y, z); chunk != nil {
+		chunk.SetBlockId(x&15, y, z&15, id)
+		area.markModified(x, z)
+	}
+}
+
+// GetBlockData returns the block data at the given world coordinates.
+func (area *Area) GetBlockData(x, y, z int) byte {
+	if chunk := area.chunkAt(x, y, z); chunk != nil {
+		return chunk.GetBlockData(x&15, y, z&15)
+	}
+	return 0
+}
+
+// SetBlockData sets the block data at the given world coordinates.
+func (area *Area) SetBlockData(x, y, z int, data byte) {
+	if chunk := area.chunkAt(x, y, z); chunk != nil {
+		chunk.SetBlockData(x&15, y, z&15, data)
+		area.markModified(x, z)
+	}
+}
+
+// GetHighestBlock returns the Y coordinate of the highest non-air block at the given world x/z coordinates.
+// Returns 0 if the column is empty or outside of the area.
+func (area *Area) GetHighestBlock(x, z int) int {
+	if chunk := area.chunkAt(x, 0, z); chunk != nil {
+		return int(chunk.GetHighestBlock(x&15, z&15))
+	}
+	return 0
+}
+
+// GetBiome returns the biome at the given world x/z coordinates.
+func (area *Area) GetBiome(x, z int) int {
+	if chunk := area.chunkAt(x, 0, z); chunk != nil {
+		return chunk.GetBiome(x&15, z&15)
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package populators
+
+import (
+	"math/rand"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Lake digs small lakes into the surface of the terrain.
+type Lake struct {
+	BlockId byte
+	// Chance is the chance of one in Chance for a chunk to get a lake.
+	Chance int
+}
+
+// NewLake returns a new lake populator filling lakes with the given liquid block.
+func NewLake(blockId byte, chance int) *Lake {
+	return &Lake{blockId, chance}
+}
+
+// Populate digs a lake in the center chunk of the area by chance.
+// The lake is an ellipse around a random surface block, and may extend into neighbouring chunks.
+// Columns that are higher than the lake surface by more than a few blocks are left untouched, as are liquid surfaces.
+func (lake *Lake) Populate(area interfaces.IChunkArea, random *rand.Rand) {
+	if random.Intn(lake.Chance) != 0 {
+		return
+	}
+	var centerX = int(area.GetChunkX())<<4 + random.Intn(16)
+	var centerZ = int(area.GetChunkZ())<<4 + random.Intn(16)
+	var surface = area.GetHighestBlock(centerX, centerZ)
+	if surface < 4 || isLiquid(area.GetBlockId(centerX, surface, centerZ)) {
+		return
+	}
+
+	var radiusX, radiusZ = 3 + random.Intn(4), 3 + random.Intn(4)
+	var depth = 2 + random.Intn(3)
+	for x := centerX - radiusX; x <= centerX+radiusX; x++ {
+		for z := centerZ - radiusZ; z <= centerZ+radiusZ; z++ {
+			var dx, dz = float64(x-centerX) / float64(radiusX), float64(z-centerZ) / float64(radiusZ)
+			var distance = dx*dx + dz*dz
+			if distance > 1 {
+				continue
+			}
+			var top = area.GetHighestBlock(x, z)
+			if top < surface || top > surface+4 || isLiquid(area.GetBlockId(x, top, z)) {
+				continue
+			}
+			var columnDepth = int(float64(depth) * (1 - distance))
+			if columnDepth == 0 {
+				continue
+			}
+			for y := surface - columnDepth; y <= top; y++ {
+				if y <= surface-1 {
+					area.SetBlockId(x, y, z, lake.BlockId)
+				} else {
+					area.SetBlockId(x, y, z, blocks.AIR)
+				}
+				area.SetBlockData(x, y, z, 0)
+			}
+		}
+	}
+}
+
+// isLiquid checks if the block ID is water or lava.
+func isLiquid(id byte) bool {
+	return id >= blocks.FLOWING_WATER && id <= blocks.STILL_LAVA
+}
@@ -0,0 +1,50 @@
+package populators
+
+import (
+	"math/rand"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Ore places veins of an ore in stone.
+type Ore struct {
+	BlockId   byte
+	BlockData byte
+	// VeinSize is the maximum amount of blocks in a single vein.
+	VeinSize int
+	// Veins is the amount of veins attempted per chunk.
+	Veins int
+	MinY  int
+	MaxY  int
+}
+
+// NewOre returns a new ore populator placing veins of the given block between the minimum and maximum Y.
+func NewOre(blockId byte, veinSize, veins, minY, maxY int) *Ore {
+	return &Ore{BlockId: blockId, VeinSize: veinSize, Veins: veins, MinY: minY, MaxY: maxY}
+}
+
+// Populate places the ore veins in the center chunk of the area.
+// A vein starts at a random position and grows by walking to random neighbouring blocks.
+func (ore *Ore) Populate(area interfaces.IChunkArea, random *rand.Rand) {
+	var baseX, baseZ = int(area.GetChunkX()) << 4, int(area.GetChunkZ()) << 4
+	for i := 0; i < ore.Veins; i++ {
+		var x = baseX + random.Intn(16)
+		var y = ore.MinY + random.Intn(ore.MaxY-ore.MinY+1)
+		var z = baseZ + random.Intn(16)
+		for j := 0; j < ore.VeinSize; j++ {
+			if area.GetBlockId(x, y, z) == blocks.STONE {
+				area.SetBlockId(x, y, z, ore.BlockId)
+				area.SetBlockData(x, y, z, ore.BlockData)
+			}
+			switch random.Intn(3) {
+			case 0:
+				x += random.Intn(3) - 1
+			case 1:
+				y += random.Intn(3) - 1
+			case 2:
+				z += random.Intn(3) - 1
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package populators
+
+import (
+	"math/rand"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Plant is a block that can be placed by the plants populator.
+type Plant struct {
+	BlockId   byte
+	BlockData byte
+}
+
+// Plants places single block plants, such as tall grass and flowers, on top of a soil block.
+type Plants struct {
+	Plants []Plant
+	Soil   byte
+	// Amount is the amount of plants attempted per chunk.
+	Amount int
+}
+
+// NewTallGrass returns a plants populator placing tall grass on grass.
+func NewTallGrass(amount int) *Plants {
+	return &Plants{[]Plant{{blocks.TALL_GRASS, 1}}, blocks.GRASS, amount}
+}
+
+// NewFlowers returns a plants populator placing dandelions and poppies on grass.
+func NewFlowers(amount int) *Plants {
+	return &Plants{[]Plant{{blocks.DANDELION, 0}, {blocks.RED_FLOWER, 0}}, blocks.GRASS, amount}
+}
+
+// NewDeadBushes returns a plants populator placing dead bushes on sand.
+func NewDeadBushes(amount int) *Plants {
+	return &Plants{[]Plant{{blocks.DEAD_BUSH, 0}}, blocks.SAND, amount}
+}
+
+// Populate places the plants in the center chunk of the area.
+func (plants *Plants) Populate(area interfaces.IChunkArea, random *rand.Rand) {
+	var baseX, baseZ = int(area.GetChunkX()) << 4, int(area.GetChunkZ()) << 4
+	for i := 0; i < plants.Amount; i++ {
+		var x, z = baseX + random.Intn(16), baseZ + random.Intn(16)
+		var plant = plants.Plants[random.Intn(len(plants.Plants))]
+		var y = area.GetHighestBlock(x, z)
+		if area.GetBlockId(x, y, z) != plants.Soil || y >= 255 {
+			continue
+		}
+		area.SetBlockId(x, y+1, z, plant.BlockId)
+		area.SetBlockData(x, y+1, z, plant.BlockData)
+	}
+}
@@ -0,0 +1,79 @@
+package populators
+
+import (
+	"math/rand"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Wood types, used as block data of logs and leaves.
+const (
+	WoodOak    = 0
+	WoodSpruce = 1
+	WoodBirch  = 2
+	WoodJungle = 3
+)
+
+// Tree places simple trees on grass and dirt.
+type Tree struct {
+	WoodType byte
+	// MinTrees and MaxTrees are the bounds of the amount of trees attempted per chunk.
+	MinTrees int
+	MaxTrees int
+}
+
+// NewTree returns a new tree populator placing between the minimum and maximum amount of trees per chunk.
+func NewTree(woodType byte, minTrees, maxTrees int) *Tree {
+	return &Tree{WoodType: woodType, MinTrees: minTrees, MaxTrees: maxTrees}
+}
+
+// Populate places the trees in the center chunk of the area. Leaves may extend into neighbouring chunks.
+func (tree *Tree) Populate(area interfaces.IChunkArea, random *rand.Rand) {
+	var baseX, baseZ = int(area.GetChunkX()) << 4, int(area.GetChunkZ()) << 4
+	var amount = tree.MinTrees + random.Intn(tree.MaxTrees-tree.MinTrees+1)
+	for i := 0; i < amount; i++ {
+		var x, z = baseX + random.Intn(16), baseZ + random.Intn(16)
+		var height = 4 + random.Intn(3)
+		tree.place(area, x, area.GetHighestBlock(x, z), z, height)
+	}
+}
+
+// place places a single tree with the given trunk height on the block at the given position.
+// Nothing is placed if the block is not grass or dirt, or if the trunk does not fit.
+func (tree *Tree) place(area interfaces.IChunkArea, x, y, z, height int) {
+	var soil = area.GetBlockId(x, y, z)
+	if (soil != blocks.GRASS && soil != blocks.DIRT) || y+height+1 > 255 {
+		return
+	}
+	for i := 1; i <= height; i++ {
+		if area.GetBlockId(x, y+i, z) != blocks.AIR {
+			return
+		}
+	}
+
+	area.SetBlockId(x, y, z, blocks.DIRT)
+	var top = y + height
+	for leafY := top - 3; leafY <= top+1; leafY++ {
+		var radius = 2
+		if leafY >= top {
+			radius = 1
+		}
+		for leafX := x - radius; leafX <= x+radius; leafX++ {
+			for leafZ := z - radius; leafZ <= z+radius; leafZ++ {
+				var corner = (leafX-x)*(leafX-x) == radius*radius && (leafZ-z)*(leafZ-z) == radius*radius
+				if corner && (leafY == top+1 || (radius == 2 && leafY == top-3)) {
+					continue
+				}
+				if area.GetBlockId(leafX, leafY, leafZ) == blocks.AIR {
+					area.SetBlockId(leafX, leafY, leafZ, blocks.LEAVES)
+					area.SetBlockData(leafX, leafY, leafZ, tree.WoodType)
+				}
+			}
+		}
+	}
+	for i := 1; i <= height; i++ {
+		area.SetBlockId(x, y+i, z, blocks.LOG)
+		area.SetBlockData(x, y+i, z, tree.WoodType)
+	}
+}
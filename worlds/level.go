@@ -1,29 +1,288 @@
 package worlds
 
 import (
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/golang/geo/r3"
 	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/resources"
+)
+
+// Times of the day, in ticks since the start of the day.
+const (
+	TimeDay      = 1000
+	TimeNoon     = 6000
+	TimeSunset   = 12000
+	TimeNight    = 13000
+	TimeMidnight = 18000
+	TimeSunrise  = 23000
+	TimeFull     = 24000
 )
 
+// TimeSyncInterval is the interval in ticks at which the time of a level gets sent to its players.
+const TimeSyncInterval = 200
+
 type Level struct {
 	server           interfaces.IServer
 	name             string
 	id               int
 	dimensions       map[string]interfaces.IDimension
 	defaultDimension interfaces.IDimension
+	chunkProvider    interfaces.IChunkProvider
+	workers          *WorkerPool
+	data             *LevelData
+	random           *rand.Rand
+
+	weather         int
+	raining         bool
+	thundering      bool
+	rainTime        int
+	thunderTime     int
+	weatherHandlers []func(interfaces.IWeatherChange)
+
+	explosionHandlers []func(interfaces.IExplosion)
 
 	gameRules map[string]interfaces.IGameRule
 }
 
 // Returns a new Level with the given level name.
+// Chunks of the level get loaded from and saved to the given chunk provider.
+// The level data gets loaded from the level.dat of the level, or gets created with the given options if the level is new.
+// Returns an error if the level has a level.dat that can not be read, in which case the level should not be used,
+// as creating new level data would overwrite the seed and spawn of the existing level.
+
+func NewLevel(levelName string, levelId int, server interfaces.IServer, provider interfaces.IChunkProvider, options resources.WorldConfig) (*Level, error) {
+	var level = &Level{server: server, name: levelName, id: levelId, chunkProvider: provider, dimensions: make(map[string]interfaces.IDimension), gameRules: make(map[string]interfaces.IGameRule), random: rand.New(rand.NewSource(time.Now().UnixNano()))}
 
-func NewLevel(levelName string, levelId int, server interfaces.IServer, chunks map[int]interfaces.IChunk) *Level {
-	var level = &Level{server: server, name: levelName, id: levelId, dimensions: make(map[string]interfaces.IDimension), gameRules: make(map[string]interfaces.IGameRule)}
+	level.initializeGameRules()
+	var isNew, err = level.loadLevelData(options)
+	if err != nil {
+		return nil, err
+	}
+	level.loadWeather()
+	level.workers = NewWorkerPool(server.GetConfiguration().ChunkGenerationWorkers)
 
-	var defaultDimension = NewDimension("Overworld", OverworldId, level, "", chunks)
+	var defaultDimension = NewDimension("Overworld", OverworldId, level, "", make(map[int]interfaces.IChunk))
 	level.SetDefaultDimension(defaultDimension)
+	level.AddDimension(NewDimension("Nether", NetherId, level, "Nether", make(map[int]interfaces.IChunk)))
+	level.AddDimension(NewDimension("The End", EndId, level, "End", make(map[int]interfaces.IChunk)))
 
-	level.initializeGameRules()
-	return level
+	if isNew {
+		level.initializeSpawn()
+	}
+	return level, nil
+}
+
+// Loads the level data from level.dat, applying the stored game rules.
+// New level data gets created from the given options if the level has no level.dat yet.
+// The default generator of the server configuration and a random seed are used if the options leave them empty.
+// Returns true if new level data was created, or an error if the existing level.dat could not be read.
+
+func (level *Level) loadLevelData(options resources.WorldConfig) (bool, error) {
+	var path = level.getLevelDataPath()
+	if _, err := os.Stat(path); path != "" && err == nil {
+		var data, err = LoadLevelData(path)
+		if err != nil {
+			return false, err
+		}
+		level.data = data
+		for name, rule := range level.gameRules {
+			level.applyGameRule(rule, data.GetGameRule(name))
+		}
+		return false, nil
+	}
+	var config = level.server.GetConfiguration()
+	if options.Generator == "" {
+		options.Generator = config.DefaultGenerator
+	}
+	if options.Seed == 0 {
+		options.Seed = NewRandomSeed()
+	}
+	level.data = NewLevelData(level.name, options.Generator, options.GeneratorOptions, options.Seed)
+	level.data.GameMode = int32(config.DefaultGameMode)
+	return true, nil
+}
+
+// Loads the rain and thunder cycles from the level data. A new level starts with clear weather of a random duration.
+
+func (level *Level) loadWeather() {
+	level.raining, level.thundering = level.data.RainLevel > 0, level.data.LightningLevel > 0
+	level.rainTime, level.thunderTime = int(level.data.RainTime), int(level.data.LightningTime)
+	if level.rainTime <= 0 {
+		level.rainTime = level.randomRainTime(level.raining)
+	}
+	if level.thunderTime <= 0 {
+		level.thunderTime = level.randomThunderTime(level.thundering)
+	}
+	level.weather = getWeather(level.raining, level.thundering)
+}
+
+// Sets the value of a game rule to a value stored in level.dat, converting it to the type of the game rule.
+
+func (level *Level) applyGameRule(rule interfaces.IGameRule, value interface{}) {
+	switch v := value.(type) {
+	case byte:
+		rule.SetValue(v != 0)
+	case int32:
+		rule.SetValue(uint32(v))
+	case float32:
+		rule.SetValue(v)
+	}
+}
+
+// Sets the spawn of a new level on top of the highest block at the origin of the default dimension.
+
+func (level *Level) initializeSpawn() {
+	var chunk = level.defaultDimension.GetChunk(0, 0)
+	level.data.SpawnY = int32(chunk.GetHighestBlock(0, 0)) + 1
+}
+
+// Returns the path of the level.dat file of this level, or an empty string if the level has no chunk provider
+// and only exists in memory.
+
+func (level *Level) getLevelDataPath() string {
+	if level.chunkProvider == nil {
+		return ""
+	}
+	return level.chunkProvider.GetPath() + "level.dat"
+}
+
+// Returns the seed of this level.
+
+func (level *Level) GetSeed() int64 {
+	return level.data.Seed
+}
+
+// Returns the spawn position of this level.
+
+func (level *Level) GetSpawnPosition() r3.Vector {
+	return r3.Vector{X: float64(level.data.SpawnX), Y: float64(level.data.SpawnY), Z: float64(level.data.SpawnZ)}
+}
+
+// Sets the spawn position of this level.
+
+func (level *Level) SetSpawnPosition(position r3.Vector) {
+	level.data.SpawnX = int32(position.X)
+	level.data.SpawnY = int32(position.Y)
+	level.data.SpawnZ = int32(position.Z)
+}
+
+// Returns the name of the generator of this level.
+
+func (level *Level) GetGeneratorName() string {
+	return level.data.GeneratorName
+}
+
+// Returns the options of the generator of this level.
+
+func (level *Level) GetGeneratorOptions() string {
+	return level.data.GeneratorOptions
+}
+
+// Returns the default game mode of players in this level.
+
+func (level *Level) GetDefaultGameMode() byte {
+	return byte(level.data.GameMode)
+}
+
+// Sets the default game mode of players in this level.
+
+func (level *Level) SetDefaultGameMode(gameMode byte) {
+	level.data.GameMode = int32(gameMode)
+}
+
+// Returns the difficulty of this level.
+
+func (level *Level) GetDifficulty() byte {
+	return byte(level.data.Difficulty)
+}
+
+// Sets the difficulty of this level.
+
+func (level *Level) SetDifficulty(difficulty byte) {
+	level.data.Difficulty = int32(difficulty)
+}
+
+// Returns the current time of this level.
+// The time is the amount of ticks the daylight cycle has run. The time of the day is the time modulo TimeFull.
+
+func (level *Level) GetTime() int64 {
+	return level.data.Time
+}
+
+// Sets the time of this level, and sends it to all players in the level.
+
+func (level *Level) SetTime(time int64) {
+	level.data.Time = time
+	level.sendTime()
+}
+
+// Stops the daylight cycle of this level by disabling the daylight cycle game rule.
+// The game rule is sent to the players in the level, so that their clients stop the sky as well.
+
+func (level *Level) StopTime() {
+	level.gameRules[GameRuleDoDaylightCycle].SetValue(false)
+	level.sendGameRule(level.gameRules[GameRuleDoDaylightCycle])
+	level.sendTime()
+}
+
+// Starts the daylight cycle of this level by enabling the daylight cycle game rule.
+// The game rule is sent to the players in the level, so that their clients move the sky again.
+
+func (level *Level) StartTime() {
+	level.gameRules[GameRuleDoDaylightCycle].SetValue(true)
+	level.sendGameRule(level.gameRules[GameRuleDoDaylightCycle])
+	level.sendTime()
+}
+
+// Returns if the daylight cycle of this level is stopped.
+
+func (level *Level) IsTimeStopped() bool {
+	return !level.gameRules[GameRuleDoDaylightCycle].GetValue().(bool)
+}
+
+// Advances the time of this level if the daylight cycle is running, and periodically sends it to its players.
+
+func (level *Level) tickTime() {
+	if level.IsTimeStopped() {
+		return
+	}
+	level.data.Time++
+	if level.data.Time%TimeSyncInterval == 0 {
+		level.sendTime()
+	}
+}
+
+// Sends the time of this level to all players in the level.
+
+func (level *Level) sendTime() {
+	for _, player := range level.GetPlayers() {
+		player.SendSetTime(int32(level.data.Time))
+	}
+}
+
+// Sends a changed game rule to all players in the level.
+// Clients only receive game rules when joining otherwise, and some rules, such as the daylight cycle, run on the client.
+
+func (level *Level) sendGameRule(rule interfaces.IGameRule) {
+	var gameRules = map[string]interfaces.IGameRule{rule.GetName(): rule}
+	for _, player := range level.GetPlayers() {
+		player.SendGameRulesChanged(gameRules)
+	}
+}
+
+// Returns all spawned players that are in this level.
+
+func (level *Level) GetPlayers() map[string]interfaces.IPlayer {
+	var players = make(map[string]interfaces.IPlayer)
+	for name, player := range level.server.GetPlayerFactory().GetPlayers() {
+		if player.HasSpawned() && player.GetLevel() == interfaces.ILevel(level) {
+			players[name] = player
+		}
+	}
+	return players
 }
 
 // Returns a GameRule with the given name.
@@ -50,6 +309,50 @@ func (level *Level) GetServer() interfaces.IServer {
 	return level.server
 }
 
+// Returns the chunk provider of this level.
+
+func (level *Level) GetChunkProvider() interfaces.IChunkProvider {
+	return level.chunkProvider
+}
+
+// Saves the level data and all loaded chunks of all dimensions of this level.
+
+func (level *Level) Save() {
+	for name, rule := range level.gameRules {
+		level.data.SetGameRule(name, rule.GetValue())
+	}
+	level.data.RainLevel, level.data.LightningLevel = 0, 0
+	if level.raining {
+		level.data.RainLevel = 1
+	}
+	if level.thundering {
+		level.data.LightningLevel = 1
+	}
+	level.data.RainTime, level.data.LightningTime = int32(level.rainTime), int32(level.thunderTime)
+	if path := level.getLevelDataPath(); path != "" {
+		if err := level.data.Save(path); err != nil {
+			level.server.GetLogger().LogError(err)
+		}
+	}
+	for _, dimension := range level.dimensions {
+		dimension.SaveChunks()
+	}
+}
+
+// Stops the chunk workers of the level, saves the level and closes its chunk provider.
+// The level should not be used after closing.
+
+func (level *Level) Close() {
+	level.workers.Stop()
+	level.Save()
+	if level.chunkProvider == nil {
+		return
+	}
+	if err := level.chunkProvider.Close(); err != nil {
+		level.server.GetLogger().LogError(err)
+	}
+}
+
 // Returns the name of this level.
 
 func (level *Level) GetName() string {
@@ -120,7 +423,13 @@ func GetBlockIndex(x, y, z int) int {
 	return int(int64(x)&0xfffffff)<<36 | (y&255)<<28 | int(int64(z)&0xfffffff)
 }
 
-// Gets the block coordinates from a chunk index
+// Gets the block coordinates from a block index
+
+func GetBlockCoordinates(index int) (int, int, int) {
+	return int(int64(index) >> 36), (index >> 28) & 255, int(int64(index) << 36 >> 36)
+}
+
+// Gets the chunk coordinates from a chunk index
 
 func GetChunkCoordinates(index int) (int32, int32) {
 	return int32(index >> 32), int32((int64(index) & 0xffffffff) << 32 >> 32)
@@ -130,6 +439,8 @@ func GetChunkCoordinates(index int) (int32, int32) {
 // Internal. Not to be used by plugins.
 
 func (level *Level) TickLevel() {
+	level.tickTime()
+	level.tickWeather()
 	for _, dimension := range level.dimensions {
 		dimension.TickDimension()
 	}
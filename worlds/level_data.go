@@ -0,0 +1,178 @@
+package worlds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/irmine/gomine/nbt"
+)
+
+// LevelDataStorageVersion is the storage version written in the header of level.dat files.
+const LevelDataStorageVersion = 8
+
+const (
+	GeneratorTypeOld      = 0
+	GeneratorTypeInfinite = 1
+	GeneratorTypeFlat     = 2
+)
+
+const (
+	DifficultyPeaceful = iota
+	DifficultyEasy
+	DifficultyNormal
+	DifficultyHard
+)
+
+var CorruptedLevelData = errors.New("level.dat is corrupted")
+
+// LevelData holds the persisted metadata of a level, stored in level.dat in the Bedrock NBT format.
+// Tags not known to GoMine are kept, so that level.dat files created by the vanilla game keep their data.
+type LevelData struct {
+	Name             string
+	Seed             int64
+	SpawnX           int32
+	SpawnY           int32
+	SpawnZ           int32
+	Time             int64
+	GeneratorName    string
+	GeneratorOptions string
+	GameMode         int32
+	Difficulty       int32
+	RainTime         int32
+	RainLevel        float32
+	LightningTime    int32
+	LightningLevel   float32
+
+	compound nbt.Compound
+}
+
+// NewLevelData returns new level data for a level with the given name, generator and seed.
+// The spawn position is left at the origin, and should be set once the generator has produced terrain.
+func NewLevelData(name string, generatorName string, generatorOptions string, seed int64) *LevelData {
+	return &LevelData{
+		Name:             name,
+		Seed:             seed,
+		GeneratorName:    generatorName,
+		GeneratorOptions: generatorOptions,
+		Difficulty:       DifficultyNormal,
+		compound:         nbt.NewCompound(),
+	}
+}
+
+// NewRandomSeed returns a new random level seed.
+func NewRandomSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// LoadLevelData loads the level data from the level.dat file at the given path.
+func LoadLevelData(path string) (*LevelData, error) {
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(file) < 8 {
+		return nil, CorruptedLevelData
+	}
+	var length = int(binary.LittleEndian.Uint32(file[4:8]))
+	if length > len(file)-8 {
+		return nil, CorruptedLevelData
+	}
+	compound, err := nbt.Unmarshal(nbt.LittleEndian, file[8:8+length])
+	if err != nil {
+		return nil, err
+	}
+
+	var data = &LevelData{
+		Name:             compound.GetString("LevelName"),
+		Seed:             compound.GetLong("RandomSeed"),
+		SpawnX:           compound.GetInt("SpawnX"),
+		SpawnY:           compound.GetInt("SpawnY"),
+		SpawnZ:           compound.GetInt("SpawnZ"),
+		Time:             compound.GetLong("Time"),
+		GeneratorName:    compound.GetString("GoMineGenerator"),
+		GeneratorOptions: compound.GetString("GoMineGeneratorOptions"),
+		GameMode:         compound.GetInt("GameType"),
+		Difficulty:       compound.GetInt("Difficulty"),
+		RainTime:         compound.GetInt("rainTime"),
+		RainLevel:        compound.GetFloat("rainLevel"),
+		LightningTime:    compound.GetInt("lightningTime"),
+		LightningLevel:   compound.GetFloat("lightningLevel"),
+		compound:         compound,
+	}
+	if data.GeneratorName == "" {
+		switch compound.GetInt("Generator") {
+		case GeneratorTypeFlat:
+			data.GeneratorName = "Flat"
+		default:
+			data.GeneratorName = "Normal"
+		}
+	}
+	return data, nil
+}
+
+// GetGameRule returns the value of the game rule with the given name as stored in level.dat.
+// Boolean game rules are stored as bytes, and integer game rules as int32.
+// Returns nil if the game rule is not stored.
+func (data *LevelData) GetGameRule(name string) interface{} {
+	return data.compound[name]
+}
+
+// SetGameRule sets the value of the game rule with the given name to be stored in level.dat.
+func (data *LevelData) SetGameRule(name string, value interface{}) {
+	switch v := value.(type) {
+	case uint32:
+		value = int32(v)
+	case bool:
+		if v {
+			value = byte(1)
+		} else {
+			value = byte(0)
+		}
+	}
+	data.compound[name] = value
+}
+
+// Save writes the level data to the level.dat file at the given path.
+// The file is written to a temporary file first, so that a crash can not leave a half written level.dat behind.
+func (data *LevelData) Save(path string) error {
+	var compound = data.compound
+	compound["LevelName"] = data.Name
+	compound["RandomSeed"] = data.Seed
+	compound["SpawnX"] = data.SpawnX
+	compound["SpawnY"] = data.SpawnY
+	compound["SpawnZ"] = data.SpawnZ
+	compound["Time"] = data.Time
+	compound["GoMineGenerator"] = data.GeneratorName
+	compound["GoMineGeneratorOptions"] = data.GeneratorOptions
+	compound["GameType"] = data.GameMode
+	compound["Difficulty"] = data.Difficulty
+	compound["rainTime"] = data.RainTime
+	compound["rainLevel"] = data.RainLevel
+	compound["lightningTime"] = data.LightningTime
+	compound["lightningLevel"] = data.LightningLevel
+	compound["StorageVersion"] = int32(LevelDataStorageVersion)
+	compound["LastPlayed"] = time.Now().Unix()
+	if data.GeneratorName == "Flat" {
+		compound["Generator"] = int32(GeneratorTypeFlat)
+	} else {
+		compound["Generator"] = int32(GeneratorTypeInfinite)
+	}
+
+	var payload, err = nbt.Marshal(nbt.LittleEndian, compound)
+	if err != nil {
+		return err
+	}
+	var buffer = bytes.NewBuffer(make([]byte, 0, len(payload)+8))
+	binary.Write(buffer, binary.LittleEndian, int32(LevelDataStorageVersion))
+	binary.Write(buffer, binary.LittleEndian, int32(len(payload)))
+	buffer.Write(payload)
+
+	if err := ioutil.WriteFile(path+".tmp", buffer.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,410 @@
+// Package lighting calculates sky light and block light of chunks.
+//
+// Light spreads from cell to cell, losing at least one level per step, and losing the light filter level of a block
+// when entering it. Sky light enters from above, keeping a level of 15 down to the height map of a column.
+// Sub chunks that do not exist are treated as air: sky light is 15 above the height map and 0 below, and block light is 0.
+package lighting
+
+import (
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/chunks"
+)
+
+const MaximumLightLevel = 15
+
+// ChunkSource provides the chunks light may spread into.
+type ChunkSource interface {
+	// GetLoadedChunk returns the chunk at the given chunk coordinates, or nil if it is not loaded.
+	// Light never causes chunks to be loaded or generated.
+	GetLoadedChunk(x, z int32) interfaces.IChunk
+}
+
+// lightType selects the kind of light a function operates on.
+type lightType int
+
+const (
+	skyLight lightType = iota
+	blockLight
+)
+
+// position is a block position in world coordinates.
+type position struct {
+	x, y, z int
+}
+
+// removal is a position queued for light removal, together with the light level it had.
+type removal struct {
+	position
+	level byte
+}
+
+var faces = [6]position{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+
+// LightChunk calculates all sky light and block light of the chunk, and sets it light populated.
+// Light is only spread within the chunk itself, as neighbouring chunks may not be safe to access.
+// Engine.SpreadBorders should be called once the chunk has been added to its dimension.
+func LightChunk(chunk interfaces.IChunk) {
+	chunk.RecalculateHeightMap()
+	var world = &world{single: chunk}
+
+	for y, subChunk := range chunk.GetSubChunks() {
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				var height = int(chunk.GetHeightMap(x, z))
+				for blockY := 0; blockY < 16; blockY++ {
+					var level byte
+					if y<<4|blockY >= height {
+						level = MaximumLightLevel
+					}
+					subChunk.SetSkyLight(x, blockY, z, level)
+					subChunk.SetBlockLight(x, blockY, z, 0)
+				}
+			}
+		}
+	}
+
+	var baseX, baseZ = int(chunk.GetX()) << 4, int(chunk.GetZ()) << 4
+	var skyQueue, blockQueue []position
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			var height = int(chunk.GetHeightMap(x, z))
+			var top = height + 1
+			for _, face := range faces {
+				var nx, nz = x + face.x, z + face.z
+				if face.y != 0 || nx < 0 || nx > 15 || nz < 0 || nz > 15 {
+					continue
+				}
+				if neighbour := int(chunk.GetHeightMap(nx, nz)); neighbour > top {
+					top = neighbour
+				}
+			}
+			for y := height; y < top && y < 256; y++ {
+				skyQueue = append(skyQueue, position{baseX + x, y, baseZ + z})
+			}
+		}
+	}
+
+	for y, subChunk := range chunk.GetSubChunks() {
+		if subChunk.IsAllAir() {
+			continue
+		}
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				for blockY := 0; blockY < 16; blockY++ {
+					var emission = blocks.GetLightEmission(subChunk.GetBlockId(x, blockY, z))
+					if emission == 0 {
+						continue
+					}
+					subChunk.SetBlockLight(x, blockY, z, emission)
+					blockQueue = append(blockQueue, position{baseX + x, y<<4 | blockY, baseZ + z})
+				}
+			}
+		}
+	}
+
+	world.spread(skyLight, skyQueue)
+	world.spread(blockLight, blockQueue)
+	chunk.SetLightPopulated(true)
+	chunk.MarkDirty()
+}
+
+// Engine spreads light across chunk borders and updates light when blocks change.
+type Engine struct {
+	source ChunkSource
+}
+
+// NewEngine returns a new light engine spreading light into the chunks of the source.
+func NewEngine(source ChunkSource) *Engine {
+	return &Engine{source}
+}
+
+// SpreadBorders exchanges light between the chunk and its loaded neighbours.
+// The chunk must have been lit using LightChunk.
+func (engine *Engine) SpreadBorders(chunk interfaces.IChunk) {
+	var world = &world{source: engine.source}
+	var chunkX, chunkZ = chunk.GetX(), chunk.GetZ()
+	var baseX, baseZ = int(chunkX) << 4, int(chunkZ) << 4
+
+	for _, lightType := range []lightType{skyLight, blockLight} {
+		var queue []position
+		for _, face := range faces {
+			if face.y != 0 || engine.source.GetLoadedChunk(chunkX+int32(face.x), chunkZ+int32(face.z)) == nil {
+				continue
+			}
+			for i := 0; i < 16; i++ {
+				var x, z int
+				switch {
+				case face.x == 1:
+					x, z = 15, i
+				case face.x == -1:
+					x, z = 0, i
+				case face.z == 1:
+					x, z = i, 15
+				default:
+					x, z = i, 0
+				}
+				for y := 0; y < 256; y++ {
+					var inside = position{baseX + x, y, baseZ + z}
+					var outside = position{inside.x + face.x, y, inside.z + face.z}
+					var insideLevel, outsideLevel = world.getLight(lightType, inside), world.getLight(lightType, outside)
+					if insideLevel > outsideLevel+1 {
+						queue = append(queue, inside)
+					} else if outsideLevel > insideLevel+1 {
+						queue = append(queue, outside)
+					}
+				}
+			}
+		}
+		world.spread(lightType, queue)
+	}
+}
+
+// UpdateBlock updates sky light and block light around the block at the given world coordinates after it changed.
+func (engine *Engine) UpdateBlock(x, y, z int) {
+	if y < 0 || y > 255 {
+		return
+	}
+	var chunk = engine.source.GetLoadedChunk(int32(x>>4), int32(z>>4))
+	if chunk == nil {
+		return
+	}
+	var world = &world{source: engine.source}
+	var changed = position{x, y, z}
+
+	var oldHeight = int(chunk.GetHeightMap(x&15, z&15))
+	chunk.RecalculateHeightMapColumn(x&15, z&15)
+	var newHeight = int(chunk.GetHeightMap(x&15, z&15))
+
+	var removals = []removal{{changed, world.getLight(skyLight, changed)}}
+	var additions []position
+	// The implicit sky light of missing sub chunks depends on the height map, so light is removed using the old height.
+	chunk.SetHeightMap(x&15, z&15, int16(oldHeight))
+	for columnY := oldHeight; columnY < newHeight; columnY++ {
+		removals = append(removals, removal{position{x, columnY, z}, MaximumLightLevel})
+	}
+	for _, removal := range removals {
+		world.setLight(skyLight, removal.position, 0)
+	}
+	chunk.SetHeightMap(x&15, z&15, int16(newHeight))
+	for columnY := newHeight; columnY < oldHeight; columnY++ {
+		world.setLight(skyLight, position{x, columnY, z}, MaximumLightLevel)
+		additions = append(additions, position{x, columnY, z})
+	}
+	if y >= newHeight {
+		world.setLight(skyLight, changed, MaximumLightLevel)
+		additions = append(additions, changed)
+	}
+	world.unspread(skyLight, removals, additions)
+
+	removals = []removal{{changed, world.getLight(blockLight, changed)}}
+	world.setLight(blockLight, changed, 0)
+	additions = nil
+	if emission := world.getEmission(changed); emission > 0 {
+		world.setLight(blockLight, changed, emission)
+		additions = append(additions, changed)
+	}
+	world.unspread(blockLight, removals, additions)
+}
+
+// world gives access to light and blocks by world coordinates.
+// It either accesses a single chunk, or all loaded chunks of a chunk source.
+type world struct {
+	single interfaces.IChunk
+	source ChunkSource
+
+	lastChunk          interfaces.IChunk
+	lastX, lastZ       int32
+	lastChunkAvailable bool
+}
+
+// chunkAt returns the chunk containing the given world coordinates, or nil if it is not accessible.
+func (world *world) chunkAt(x, z int) interfaces.IChunk {
+	var chunkX, chunkZ = int32(x >> 4), int32(z >> 4)
+	if world.single != nil {
+		if world.single.GetX() == chunkX && world.single.GetZ() == chunkZ {
+			return world.single
+		}
+		return nil
+	}
+	if world.lastChunkAvailable && world.lastX == chunkX && world.lastZ == chunkZ {
+		return world.lastChunk
+	}
+	world.lastChunk = world.source.GetLoadedChunk(chunkX, chunkZ)
+	world.lastX, world.lastZ, world.lastChunkAvailable = chunkX, chunkZ, true
+	return world.lastChunk
+}
+
+// getLight returns the light level at the position, or 0 if the position is not accessible.
+func (world *world) getLight(lightType lightType, pos position) byte {
+	if pos.y < 0 {
+		return 0
+	}
+	if pos.y > 255 {
+		if lightType == skyLight {
+			return MaximumLightLevel
+		}
+		return 0
+	}
+	var chunk = world.chunkAt(pos.x, pos.z)
+	if chunk == nil {
+		return 0
+	}
+	var subChunk, ok = chunk.GetSubChunks()[pos.y>>4]
+	if !ok {
+		return implicitLight(lightType, chunk, pos)
+	}
+	if lightType == skyLight {
+		return subChunk.GetSkyLight(pos.x&15, pos.y&15, pos.z&15)
+	}
+	return subChunk.GetBlockLight(pos.x&15, pos.y&15, pos.z&15)
+}
+
+// setLight sets the light level at the position if it is accessible.
+// Missing sub chunks are only created if the level differs from the implicit light level.
+func (world *world) setLight(lightType lightType, pos position, level byte) {
+	if pos.y < 0 || pos.y > 255 {
+		return
+	}
+	var chunk = world.chunkAt(pos.x, pos.z)
+	if chunk == nil {
+		return
+	}
+	var subChunk, ok = chunk.GetSubChunks()[pos.y>>4]
+	if !ok {
+		if level == implicitLight(lightType, chunk, pos) {
+			return
+		}
+		subChunk = newSubChunk(chunk, pos.y>>4)
+		chunk.SetSubChunk(pos.y>>4, subChunk)
+	}
+	chunk.MarkDirty()
+	if lightType == skyLight {
+		subChunk.SetSkyLight(pos.x&15, pos.y&15, pos.z&15, level)
+		return
+	}
+	subChunk.SetBlockLight(pos.x&15, pos.y&15, pos.z&15, level)
+}
+
+// getFilter returns the light filter level of the block at the position.
+// Inaccessible positions filter all light.
+func (world *world) getFilter(pos position) byte {
+	if pos.y < 0 || pos.y > 255 {
+		return MaximumLightLevel
+	}
+	var chunk = world.chunkAt(pos.x, pos.z)
+	if chunk == nil {
+		return MaximumLightLevel
+	}
+	var subChunk, ok = chunk.GetSubChunks()[pos.y>>4]
+	if !ok {
+		return 0
+	}
+	return blocks.GetLightFilter(subChunk.GetBlockId(pos.x&15, pos.y&15, pos.z&15))
+}
+
+// getEmission returns the light emission level of the block at the position.
+func (world *world) getEmission(pos position) byte {
+	if pos.y < 0 || pos.y > 255 {
+		return 0
+	}
+	var chunk = world.chunkAt(pos.x, pos.z)
+	if chunk == nil {
+		return 0
+	}
+	var subChunk, ok = chunk.GetSubChunks()[pos.y>>4]
+	if !ok {
+		return 0
+	}
+	return blocks.GetLightEmission(subChunk.GetBlockId(pos.x&15, pos.y&15, pos.z&15))
+}
+
+// spread spreads light outwards from all positions in the queue, raising the light of neighbours where it is lower.
+func (world *world) spread(lightType lightType, queue []position) {
+	for len(queue) > 0 {
+		var pos = queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		var level = world.getLight(lightType, pos)
+		if level <= 1 {
+			continue
+		}
+		for _, face := range faces {
+			var neighbour = position{pos.x + face.x, pos.y + face.y, pos.z + face.z}
+			if world.chunkAt(neighbour.x, neighbour.z) == nil || neighbour.y < 0 || neighbour.y > 255 {
+				continue
+			}
+			var filter = world.getFilter(neighbour)
+			if filter < 1 {
+				filter = 1
+			}
+			if filter >= level {
+				continue
+			}
+			var newLevel = level - filter
+			if newLevel > world.getLight(lightType, neighbour) {
+				world.setLight(lightType, neighbour, newLevel)
+				queue = append(queue, neighbour)
+			}
+		}
+	}
+}
+
+// unspread removes light that originated from the removed positions, which must already have been set to 0.
+// Light from other sources bordering the removed area gets spread again, together with the additional positions.
+func (world *world) unspread(lightType lightType, removals []removal, additions []position) {
+	for len(removals) > 0 {
+		var removed = removals[len(removals)-1]
+		removals = removals[:len(removals)-1]
+
+		for _, face := range faces {
+			var neighbour = position{removed.x + face.x, removed.y + face.y, removed.z + face.z}
+			if world.chunkAt(neighbour.x, neighbour.z) == nil || neighbour.y < 0 || neighbour.y > 255 {
+				continue
+			}
+			var level = world.getLight(lightType, neighbour)
+			if level == 0 {
+				continue
+			}
+			// Neighbours with a lower level may have been lit by the removed light, and are removed as well.
+			// Neighbours with an equal or higher level are lit by another source, and spread their light again.
+			if level < removed.level {
+				world.setLight(lightType, neighbour, 0)
+				removals = append(removals, removal{neighbour, level})
+				if lightType == blockLight {
+					if emission := world.getEmission(neighbour); emission > 0 {
+						world.setLight(lightType, neighbour, emission)
+						additions = append(additions, neighbour)
+					}
+				}
+				continue
+			}
+			additions = append(additions, neighbour)
+		}
+	}
+	world.spread(lightType, additions)
+}
+
+// implicitLight returns the light level of a position in a sub chunk that does not exist.
+func implicitLight(lightType lightType, chunk interfaces.IChunk, pos position) byte {
+	if lightType == skyLight && pos.y >= int(chunk.GetHeightMap(pos.x&15, pos.z&15)) {
+		return MaximumLightLevel
+	}
+	return 0
+}
+
+// newSubChunk returns a new empty sub chunk at the given sub chunk Y, with the sky light it implicitly had.
+func newSubChunk(chunk interfaces.IChunk, y int) interfaces.ISubChunk {
+	var subChunk = chunks.NewSubChunk()
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			var height = int(chunk.GetHeightMap(x, z))
+			for blockY := 0; blockY < 16; blockY++ {
+				if y<<4|blockY >= height {
+					subChunk.SetSkyLight(x, blockY, z, MaximumLightLevel)
+				}
+			}
+		}
+	}
+	return subChunk
+}
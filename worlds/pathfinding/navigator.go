@@ -0,0 +1,244 @@
+package pathfinding
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+)
+
+// DefaultSpeed is the speed in blocks per tick at which navigators move their entity by default.
+const DefaultSpeed = 0.2
+
+// DefaultNodesPerTick is the amount of positions a navigator visits every tick by default while searching a path.
+const DefaultNodesPerTick = 200
+
+// JumpVelocity is the upward motion given to an entity to jump a little over one block high with the default gravity and drag.
+const JumpVelocity = 0.5
+
+// SwimVelocity is the upward motion given to an entity to swim up.
+const SwimVelocity = 0.2
+
+// ReachDistance is the horizontal distance in blocks from a position of a path at which an entity reached it.
+const ReachDistance = 0.3
+
+// StuckTicks is the amount of ticks an entity may not move before its navigator searches a new path.
+const StuckTicks = 40
+
+// MaxRepaths is the amount of times in a row a navigator searches a new path for a stuck entity before it gives up.
+const MaxRepaths = 3
+
+// Navigator moves an entity along paths to a goal, and is ticked by the entity every tick.
+// Paths are searched over several ticks, and searched again when blocks along them change or the entity gets stuck.
+// The entity should have physics, as the navigator moves it by setting its motion.
+type Navigator struct {
+	entity       interfaces.IEntity
+	settings     Settings
+	speed        float64
+	nodesPerTick int
+
+	goal       r3.Vector
+	navigating bool
+	search     *Search
+	path       *Path
+
+	lastPosition r3.Vector
+	stuckTicks   int
+	repaths      int
+}
+
+// NewNavigator returns a new navigator for the entity, searching paths with the given settings.
+func NewNavigator(entity interfaces.IEntity, settings Settings) *Navigator {
+	return &Navigator{entity: entity, settings: settings, speed: DefaultSpeed, nodesPerTick: DefaultNodesPerTick}
+}
+
+// GetSettings returns the settings paths are searched with.
+func (navigator *Navigator) GetSettings() Settings {
+	return navigator.settings
+}
+
+// SetSettings sets the settings paths are searched with. Searches that already started keep their settings.
+func (navigator *Navigator) SetSettings(settings Settings) {
+	navigator.settings = settings
+}
+
+// GetSpeed returns the speed in blocks per tick at which the entity is moved.
+func (navigator *Navigator) GetSpeed() float64 {
+	return navigator.speed
+}
+
+// SetSpeed sets the speed in blocks per tick at which the entity is moved.
+func (navigator *Navigator) SetSpeed(speed float64) {
+	navigator.speed = speed
+}
+
+// SetNodesPerTick sets the amount of positions visited every tick while searching a path.
+func (navigator *Navigator) SetNodesPerTick(nodes int) {
+	if nodes < 1 {
+		nodes = 1
+	}
+	navigator.nodesPerTick = nodes
+}
+
+// MoveTo starts moving the entity to the goal, searching a path to it first.
+func (navigator *Navigator) MoveTo(goal r3.Vector) {
+	navigator.goal = goal
+	navigator.navigating = true
+	navigator.repaths = 0
+	navigator.repath()
+}
+
+// Stop stops moving the entity, and discards the path.
+func (navigator *Navigator) Stop() {
+	navigator.navigating = false
+	navigator.search = nil
+	navigator.path = nil
+	var motion = navigator.entity.GetMotion()
+	navigator.entity.SetMotion(r3.Vector{Y: motion.Y})
+}
+
+// IsNavigating checks if the navigator is moving the entity to a goal, or searching a path to it.
+func (navigator *Navigator) IsNavigating() bool {
+	return navigator.navigating
+}
+
+// GetGoal returns the goal the entity is moved to.
+func (navigator *Navigator) GetGoal() r3.Vector {
+	return navigator.goal
+}
+
+// GetPath returns the path the entity follows, or nil if the path is still being searched.
+func (navigator *Navigator) GetPath() *Path {
+	return navigator.path
+}
+
+// Tick continues the search of a path, or moves the entity along its path.
+// All remaining positions of the path are checked for changed blocks first, and a new path is searched if the entity
+// can no longer stand at any of them. Navigation stops when the goal is reached or no path to it can be found.
+func (navigator *Navigator) Tick() {
+	if !navigator.navigating {
+		return
+	}
+	var dimension = navigator.entity.GetDimension()
+	if dimension == nil {
+		navigator.Stop()
+		return
+	}
+	if navigator.search != nil {
+		if !navigator.search.Run(navigator.nodesPerTick) {
+			return
+		}
+		navigator.path = navigator.search.GetPath()
+		navigator.search = nil
+		if navigator.path == nil {
+			navigator.Stop()
+			return
+		}
+	}
+
+	if !navigator.isValid(terrain{dimension, navigator.settings}) {
+		navigator.repath()
+		return
+	}
+
+	var position = navigator.entity.GetPosition()
+	for !navigator.path.IsFinished() {
+		var target, _ = navigator.path.GetCurrent()
+		if math.Hypot(target.X-position.X, target.Z-position.Z) > ReachDistance || math.Abs(target.Y-position.Y) > 1 {
+			break
+		}
+		navigator.path.Advance()
+	}
+	if navigator.path.IsFinished() {
+		if navigator.path.IsPartial() {
+			// The search gave up before the goal, so the rest of the way is searched from the end of the partial path.
+			navigator.repath()
+			return
+		}
+		navigator.Stop()
+		return
+	}
+
+	if navigator.isStuck(position) {
+		if navigator.repaths >= MaxRepaths {
+			navigator.Stop()
+			return
+		}
+		navigator.repaths++
+		navigator.repath()
+		return
+	}
+	navigator.move(position)
+}
+
+// move sets the motion of the entity towards the position of the path it is walking to,
+// making it jump or swim up when the position is higher, and turns the entity to face the position.
+func (navigator *Navigator) move(position r3.Vector) {
+	var target, _ = navigator.path.GetCurrent()
+	var x, z = target.X - position.X, target.Z - position.Z
+	var distance = math.Hypot(x, z)
+	var motion = navigator.entity.GetMotion()
+	motion.X, motion.Z = 0, 0
+	if distance > 0 {
+		var speed = math.Min(navigator.speed, distance)
+		motion.X, motion.Z = x/distance*speed, z/distance*speed
+	}
+
+	var climb = target.Y - position.Y
+	if climb > navigator.settings.StepHeight && navigator.path.NeedsJump() && navigator.entity.IsOnGround() {
+		motion.Y = JumpVelocity
+	} else if climb > 0 && navigator.isInWater(position) {
+		motion.Y = SwimVelocity
+	}
+	navigator.entity.SetMotion(motion)
+
+	if rotation := navigator.entity.GetRotation(); rotation != nil && distance > 0 {
+		var yaw = float32(math.Atan2(z, x)*180/math.Pi - 90)
+		rotation.SetYaw(yaw)
+		rotation.SetHeadYaw(yaw)
+	}
+}
+
+// isValid checks if the entity can still stand at all remaining positions of the path, so that blocks changed anywhere
+// along the path are noticed before the entity walks into them.
+func (navigator *Navigator) isValid(terrain terrain) bool {
+	var path = navigator.path
+	for i := path.index; i < len(path.steps); i++ {
+		if !terrain.isValid(path.steps[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isStuck checks if the entity did not move for the amount of stuck ticks.
+func (navigator *Navigator) isStuck(position r3.Vector) bool {
+	if math.Hypot(position.X-navigator.lastPosition.X, position.Z-navigator.lastPosition.Z) > 0.01 || position.Y != navigator.lastPosition.Y {
+		navigator.lastPosition = position
+		navigator.stuckTicks = 0
+		navigator.repaths = 0
+		return false
+	}
+	navigator.stuckTicks++
+	return navigator.stuckTicks >= StuckTicks
+}
+
+// isInWater checks if the feet of the entity at the position are in water.
+func (navigator *Navigator) isInWater(position r3.Vector) bool {
+	var terrain = terrain{navigator.entity.GetDimension(), navigator.settings}
+	var block, ok = terrain.getBlock(int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z)))
+	return ok && isWater(block.GetId())
+}
+
+// repath starts a new search for a path from the position of the entity to the goal.
+func (navigator *Navigator) repath() {
+	var dimension = navigator.entity.GetDimension()
+	if dimension == nil {
+		navigator.Stop()
+		return
+	}
+	navigator.path = nil
+	navigator.stuckTicks = 0
+	navigator.lastPosition = navigator.entity.GetPosition()
+	navigator.search = NewSearch(dimension, navigator.entity.GetPosition(), navigator.goal, navigator.settings)
+}
@@ -0,0 +1,72 @@
+package pathfinding
+
+import (
+	"github.com/golang/geo/r3"
+)
+
+// Path is a path found by a search, made up of the positions an entity walks through one by one.
+// The path keeps track of the position the entity is currently walking to.
+type Path struct {
+	steps   []step
+	index   int
+	partial bool
+}
+
+// GetPoints returns all positions of the path, at the center of the blocks at the height the entity stands at.
+func (path *Path) GetPoints() []r3.Vector {
+	var points = make([]r3.Vector, len(path.steps))
+	for i, current := range path.steps {
+		points[i] = getPosition(current)
+	}
+	return points
+}
+
+// GetLength returns the amount of positions of the path.
+func (path *Path) GetLength() int {
+	return len(path.steps)
+}
+
+// GetIndex returns the index of the position the entity is currently walking to.
+func (path *Path) GetIndex() int {
+	return path.index
+}
+
+// GetCurrent returns the position the entity is currently walking to.
+// Returns false if the entity reached the end of the path.
+func (path *Path) GetCurrent() (r3.Vector, bool) {
+	if path.IsFinished() {
+		return r3.Vector{}, false
+	}
+	return getPosition(path.steps[path.index]), true
+}
+
+// GetEnd returns the last position of the path, or false if the path has no positions.
+func (path *Path) GetEnd() (r3.Vector, bool) {
+	if len(path.steps) == 0 {
+		return r3.Vector{}, false
+	}
+	return getPosition(path.steps[len(path.steps)-1]), true
+}
+
+// NeedsJump checks if the entity has to jump to get to the position it is currently walking to.
+func (path *Path) NeedsJump() bool {
+	return !path.IsFinished() && path.steps[path.index].jump
+}
+
+// Advance moves on to the next position of the path.
+func (path *Path) Advance() {
+	if !path.IsFinished() {
+		path.index++
+	}
+}
+
+// IsFinished checks if the entity reached the end of the path.
+func (path *Path) IsFinished() bool {
+	return path.index >= len(path.steps)
+}
+
+// IsPartial checks if the path ends before the goal, because the goal could not be reached
+// or the search ran out of positions to visit.
+func (path *Path) IsPartial() bool {
+	return path.partial
+}
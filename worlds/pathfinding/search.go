@@ -0,0 +1,243 @@
+package pathfinding
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+)
+
+// JumpCost is the extra cost of a step the entity has to jump for, on top of the distance.
+const JumpCost = 0.5
+
+// WaterCost is the factor by which the cost of steps in water is multiplied, so that entities prefer to stay dry.
+const WaterCost = 2.0
+
+// Settings describe the entity a path is searched for, and limit the search.
+type Settings struct {
+	// Width and Height are the size in blocks of the bounding box of the entity.
+	Width, Height float64
+	// StepHeight is the height in blocks the entity walks up without jumping.
+	StepHeight float64
+	// JumpHeight is the highest height in blocks the entity can climb by jumping.
+	JumpHeight float64
+	// MaxFall is the amount of blocks the entity may drop down from a ledge.
+	MaxFall int
+	// Swim is true if the entity may move through water.
+	Swim bool
+	// MaxNodes is the amount of positions a search visits before it gives up.
+	MaxNodes int
+}
+
+// DefaultSettings returns the settings of an entity of the default size, which steps up slabs, jumps up one block,
+// drops down at most three blocks and swims. Searches visit at most 2000 positions.
+func DefaultSettings() Settings {
+	return Settings{Width: 0.6, Height: 1.8, StepHeight: 0.5, JumpHeight: 1, MaxFall: 3, Swim: true, MaxNodes: 2000}
+}
+
+// node is a step visited by a search, with the cost of the cheapest path to it found so far.
+type node struct {
+	step
+	cost     float64
+	estimate float64
+	parent   *node
+	index    int
+	closed   bool
+}
+
+// queue is the open set of a search, ordered by the estimated cost of the path through every node.
+type queue []*node
+
+func (queue queue) Len() int {
+	return len(queue)
+}
+
+func (queue queue) Less(i, j int) bool {
+	return queue[i].cost+queue[i].estimate < queue[j].cost+queue[j].estimate
+}
+
+func (queue queue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+	queue[i].index, queue[j].index = i, j
+}
+
+func (queue *queue) Push(value interface{}) {
+	var n = value.(*node)
+	n.index = len(*queue)
+	*queue = append(*queue, n)
+}
+
+func (queue *queue) Pop() interface{} {
+	var old = *queue
+	var n = old[len(old)-1]
+	*queue = old[:len(old)-1]
+	n.index = -1
+	return n
+}
+
+// Search is an A* search for a path from a start to a goal position over the blocks of a dimension.
+// A search runs in parts with Run, so that a long search is spread over several ticks instead of stalling one.
+type Search struct {
+	terrain  terrain
+	goal     r3.Vector
+	start    *node
+	best     *node
+	open     queue
+	nodes    map[[3]int]*node
+	visited  int
+	finished bool
+	path     *Path
+}
+
+// NewSearch returns a new search for a path from the start to the goal position for an entity with the given settings.
+// The start is usually the position of the entity, and the goal is the position the entity should stand at.
+func NewSearch(dimension interfaces.IDimension, start, goal r3.Vector, settings Settings) *Search {
+	goal = r3.Vector{X: math.Floor(goal.X) + 0.5, Y: goal.Y, Z: math.Floor(goal.Z) + 0.5}
+	var search = &Search{terrain: terrain{dimension, settings}, goal: goal, nodes: make(map[[3]int]*node)}
+	var x, y, z = int(math.Floor(start.X)), int(math.Floor(start.Y)), int(math.Floor(start.Z))
+	var first, ok = search.terrain.getFloor(x, y, z)
+	if !ok {
+		// Entities in the air or stuck in blocks start where they are.
+		first = step{x: x, y: y, z: z, floor: start.Y}
+	}
+	search.start = &node{step: first}
+	search.start.estimate = search.getEstimate(first)
+	search.best = search.start
+	search.nodes[[3]int{x, y, z}] = search.start
+	heap.Push(&search.open, search.start)
+	return search
+}
+
+// Run continues the search, visiting at most the given amount of positions. Returns true if the search is finished.
+// A search finishes when the goal is reached, when no positions are left to visit, or when the maximum amount of
+// positions of the settings was visited. Searches that did not reach the goal get a partial path to the visited
+// position closest to the goal.
+func (search *Search) Run(nodes int) bool {
+	for i := 0; i < nodes && !search.finished; i++ {
+		if search.open.Len() == 0 || search.visited >= search.terrain.settings.MaxNodes {
+			search.finish(search.best, true)
+			break
+		}
+		var current = heap.Pop(&search.open).(*node)
+		current.closed = true
+		search.visited++
+
+		if current.estimate < search.best.estimate {
+			search.best = current
+		}
+		if search.isGoal(current.step) {
+			search.finish(current, false)
+			break
+		}
+		search.expand(current)
+	}
+	return search.finished
+}
+
+// IsFinished checks if the search is finished.
+func (search *Search) IsFinished() bool {
+	return search.finished
+}
+
+// GetVisited returns the amount of positions the search visited so far.
+func (search *Search) GetVisited() int {
+	return search.visited
+}
+
+// GetPath returns the path found by the search, which may be partial if the goal could not be reached.
+// Returns nil if the search is not finished yet, or if the entity can not move closer to the goal at all.
+func (search *Search) GetPath() *Path {
+	return search.path
+}
+
+// expand adds all steps reachable from the node to the open set, or updates them if a cheaper path to them was found.
+func (search *Search) expand(current *node) {
+	var neighbours = make([]step, 0, 10)
+	var flat [4]bool
+	for i, offset := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		if next, ok := search.terrain.getMove(current.step, offset[0], offset[1]); ok {
+			neighbours = append(neighbours, next)
+			flat[i] = next.floor == current.floor
+		}
+	}
+	// Diagonal steps are only taken over flat ground next to both straight steps, so that entities do not cut corners of blocks.
+	for _, diagonal := range [4]struct{ x, z, first, second int }{{1, 1, 0, 2}, {1, -1, 0, 3}, {-1, 1, 1, 2}, {-1, -1, 1, 3}} {
+		if !flat[diagonal.first] || !flat[diagonal.second] {
+			continue
+		}
+		if next, ok := search.terrain.getMove(current.step, diagonal.x, diagonal.z); ok && next.floor == current.floor {
+			neighbours = append(neighbours, next)
+		}
+	}
+	for _, offsetY := range [2]int{1, -1} {
+		if next, ok := search.terrain.getSwim(current.step, offsetY); ok {
+			neighbours = append(neighbours, next)
+		}
+	}
+
+	for _, next := range neighbours {
+		var cost = current.cost + search.getCost(current.step, next)
+		var key = [3]int{next.x, next.y, next.z}
+		var n, ok = search.nodes[key]
+		if !ok {
+			n = &node{step: next, cost: cost, estimate: search.getEstimate(next), parent: current}
+			search.nodes[key] = n
+			heap.Push(&search.open, n)
+			continue
+		}
+		if n.closed || cost >= n.cost {
+			continue
+		}
+		n.step, n.cost, n.parent = next, cost, current
+		heap.Fix(&search.open, n.index)
+	}
+}
+
+// getCost returns the cost of moving from one step to the next.
+func (search *Search) getCost(from, to step) float64 {
+	var cost = getPosition(from).Distance(getPosition(to))
+	if to.jump {
+		cost += JumpCost
+	}
+	if to.swim {
+		cost *= WaterCost
+	}
+	return cost
+}
+
+// getEstimate returns the estimated cost of the path from the step to the goal, which is never higher than the actual cost.
+func (search *Search) getEstimate(current step) float64 {
+	return getPosition(current).Distance(search.goal)
+}
+
+// isGoal checks if the step is at the goal.
+func (search *Search) isGoal(current step) bool {
+	return current.x == int(math.Floor(search.goal.X)) && current.z == int(math.Floor(search.goal.Z)) &&
+		math.Abs(current.floor-search.goal.Y) < 1
+}
+
+// finish finishes the search with the path to the last node, which is partial if the node is not at the goal.
+func (search *Search) finish(last *node, partial bool) {
+	search.finished = true
+	search.open = nil
+	if last == search.start {
+		if !partial {
+			search.path = &Path{partial: false}
+		}
+		return
+	}
+	var steps []step
+	for n := last; n != search.start; n = n.parent {
+		steps = append(steps, n.step)
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	search.path = &Path{steps: steps, partial: partial}
+}
+
+// getPosition returns the position an entity stands at in the step.
+func getPosition(current step) r3.Vector {
+	return r3.Vector{X: float64(current.x) + 0.5, Y: current.floor, Z: float64(current.z) + 0.5}
+}
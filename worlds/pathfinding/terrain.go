@@ -0,0 +1,186 @@
+package pathfinding
+
+import (
+	"math"
+
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/vectors"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// terrain reads the blocks of a dimension to decide where an entity with the given settings can stand and move.
+type terrain struct {
+	dimension interfaces.IDimension
+	settings  Settings
+}
+
+// step is a position an entity can stand at, at the floor height in the block at x, y and z.
+// Jump is true if the entity has to jump to get to the step, and swim is true if the step is in water.
+type step struct {
+	x, y, z int
+	floor   float64
+	jump    bool
+	swim    bool
+}
+
+// getBlock returns the block at the given position, and false if the chunk of the block is not loaded.
+// Positions below and above the world are air.
+func (terrain *terrain) getBlock(x, y, z int) (interfaces.IBlock, bool) {
+	if y < 0 || y > 255 {
+		return blocks.GetBlock(blocks.AIR, 0), true
+	}
+	var chunk = terrain.dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+	if chunk == nil {
+		return nil, false
+	}
+	return blocks.GetBlock(int(chunk.GetBlockId(x&15, y, z&15)), chunk.GetBlockData(x&15, y, z&15)), true
+}
+
+// getTop returns the height of the top of the collision box of the block at the given position,
+// and false if the block has no collision box or is not loaded.
+func (terrain *terrain) getTop(x, y, z int) (float64, bool) {
+	var block, ok = terrain.getBlock(x, y, z)
+	if !ok || !block.HasCollisionBox() {
+		return 0, false
+	}
+	var top, found = 0.0, false
+	for _, cube := range block.GetCollisionBox().GetCubes() {
+		if !found || cube.MaxY > top {
+			top, found = cube.MaxY, true
+		}
+	}
+	return float64(y) + top, found
+}
+
+// getFloor returns the height at which an entity stands in the block at the given position.
+// The floor is the top of a block below, such as a full block or a fence, or of a partial block at the position,
+// such as a slab. In water without floor the entity swims at the bottom of the block.
+// Returns false if the entity can not stand at the position.
+func (terrain *terrain) getFloor(x, y, z int) (step, bool) {
+	if y < 0 || y > 255 {
+		return step{}, false
+	}
+	var current = step{x: x, y: y, z: z, floor: -1}
+	for _, below := range [2]int{y, y - 1} {
+		if top, ok := terrain.getTop(x, below, z); ok && top >= float64(y) && top < float64(y+1) && top > current.floor {
+			current.floor = top
+		}
+	}
+
+	var block, ok = terrain.getBlock(x, y, z)
+	if !ok {
+		return step{}, false
+	}
+	current.swim = isWater(block.GetId())
+	if current.floor < 0 {
+		if !current.swim || !terrain.settings.Swim {
+			return step{}, false
+		}
+		current.floor = float64(y)
+	}
+	if current.swim && !terrain.settings.Swim {
+		return step{}, false
+	}
+	if terrain.isDangerous(x, y, z) || terrain.isDangerous(x, int(math.Floor(current.floor-0.5)), z) {
+		return step{}, false
+	}
+	if !terrain.isFree(terrain.getBox(x, z, current.floor, current.floor)) {
+		return step{}, false
+	}
+	return current, true
+}
+
+// getMove returns the step an entity at the given step gets to when moving to the next block on the X and Z axis.
+// The entity walks up blocks no higher than its step height, jumps onto blocks no higher than its jump height,
+// and walks off ledges no deeper than its maximum fall distance. Returns false if the entity can not move there.
+func (terrain *terrain) getMove(from step, offsetX, offsetZ int) (step, bool) {
+	var x, z = from.x + offsetX, from.z + offsetZ
+	for y := from.y + int(math.Ceil(terrain.settings.JumpHeight)); y >= from.y-terrain.settings.MaxFall; y-- {
+		var to, ok = terrain.getFloor(x, y, z)
+		if !ok {
+			continue
+		}
+		var climb = to.floor - from.floor
+		if climb > terrain.settings.JumpHeight || -climb > float64(terrain.settings.MaxFall) {
+			continue
+		}
+		// The entity needs room to move up in its own block when climbing, and room to drop down in the next when falling.
+		var top = math.Max(from.floor, to.floor)
+		if !terrain.isFree(terrain.getBox(from.x, from.z, from.floor, top)) || !terrain.isFree(terrain.getBox(x, z, to.floor, top)) {
+			continue
+		}
+		to.jump = climb > terrain.settings.StepHeight && !from.swim
+		return to, true
+	}
+	return step{}, false
+}
+
+// getSwim returns the step an entity swimming at the given step gets to when swimming one block up or down.
+// Returns false if the entity can not swim there.
+func (terrain *terrain) getSwim(from step, offsetY int) (step, bool) {
+	if !from.swim {
+		return step{}, false
+	}
+	var to, ok = terrain.getFloor(from.x, from.y+offsetY, from.z)
+	if !ok || to.floor == from.floor {
+		return step{}, false
+	}
+	return to, true
+}
+
+// isValid checks if an entity can still stand at the step, which it can not if blocks were changed around it.
+func (terrain *terrain) isValid(current step) bool {
+	var checked, ok = terrain.getFloor(current.x, current.y, current.z)
+	return ok && checked.floor == current.floor && checked.swim == current.swim
+}
+
+// getBox returns the bounding box of an entity standing in the center of the block on the X and Z axis at the bottom height,
+// stretched upwards so that its bottom is at the bottom height and the top of the entity reaches above the top height.
+func (terrain *terrain) getBox(x, z int, bottom, top float64) *vectors.Cube {
+	var halfWidth = terrain.settings.Width / 2
+	var centerX, centerZ = float64(x) + 0.5, float64(z) + 0.5
+	return vectors.NewCube(centerX-halfWidth, bottom, centerZ-halfWidth, centerX+halfWidth, top+terrain.settings.Height, centerZ+halfWidth)
+}
+
+// isFree checks if the box does not intersect the collision box of any block.
+// Blocks in chunks that are not loaded are never free.
+func (terrain *terrain) isFree(box *vectors.Cube) bool {
+	// Blocks such as fences have collision boxes higher than a block, so the layer below the box is checked too.
+	for x := int(math.Floor(box.MinX)); x <= int(math.Floor(box.MaxX)); x++ {
+		for z := int(math.Floor(box.MinZ)); z <= int(math.Floor(box.MaxZ)); z++ {
+			for y := int(math.Floor(box.MinY)) - 1; y <= int(math.Floor(box.MaxY)); y++ {
+				var block, ok = terrain.getBlock(x, y, z)
+				if !ok {
+					return false
+				}
+				if !block.HasCollisionBox() {
+					continue
+				}
+				for _, cube := range block.GetCollisionBox().GetCubes() {
+					if cube.Offset(float64(x), float64(y), float64(z)).Intersects(box) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// isDangerous checks if the block at the given position hurts entities standing in or on it.
+func (terrain *terrain) isDangerous(x, y, z int) bool {
+	var block, ok = terrain.getBlock(x, y, z)
+	if !ok {
+		return true
+	}
+	switch block.GetId() {
+	case blocks.FLOWING_LAVA, blocks.STILL_LAVA, blocks.FIRE, blocks.CACTUS, blocks.MAGMA:
+		return true
+	}
+	return false
+}
+
+// isWater checks if the block ID is an ID of water.
+func isWater(id int) bool {
+	return id == blocks.FLOWING_WATER || id == blocks.STILL_WATER
+}
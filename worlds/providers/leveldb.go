@@ -0,0 +1,512 @@
+package providers
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/worlds/chunks"
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/table"
+)
+
+// Key tags used by Minecraft Bedrock Edition to identify chunk data in the database.
+const (
+	TagData2D         = 0x2d
+	TagData2DLegacy   = 0x2e
+	TagSubChunkPrefix = 0x2f
+	TagLegacyTerrain  = 0x30
+	TagBlockEntity    = 0x31
+	TagEntity         = 0x32
+	TagPendingTicks   = 0x33
+	TagBlockExtraData = 0x34
+	TagBiomeState     = 0x35
+	TagFinalizedState = 0x36
+	TagVersion        = 0x76
+)
+
+const (
+	CurrentChunkVersion    = 7
+	CurrentSubChunkVersion = 0
+)
+
+const (
+	FinalizedStateNeedsInstaticking = iota
+	FinalizedStateNeedsPopulation
+	FinalizedStateDone
+)
+
+const (
+	subChunkIdsSize    = 4096
+	subChunkNibbleSize = 2048
+)
+
+// backupBatchSize is the amount of keys written to the database of a backup in one batch.
+const backupBatchSize = 4096
+
+// Block compression types of LevelDB tables. The vanilla game writes zlib and raw deflate compressed blocks,
+// which the LevelDB implementation used can not read.
+const (
+	compressionNone   = 0
+	compressionSnappy = 1
+)
+
+// tableFooterSize is the size of the footer at the end of every LevelDB table file.
+const tableFooterSize = 48
+
+var (
+	UnsupportedChunkVersion    = errors.New("unsupported chunk version")
+	UnsupportedSubChunkVersion = errors.New("unsupported sub chunk version")
+	CorruptedSubChunk          = errors.New("sub chunk data is corrupted")
+	CorruptedTable             = errors.New("database table is corrupted")
+	UnsupportedCompression     = errors.New("database contains zlib compressed tables, which are not supported: convert the world to an uncompressed database first")
+)
+
+// LevelDBProvider is a chunk provider that reads and writes chunks in the LevelDB format used by Minecraft Bedrock Edition.
+// The level folder layout is equal to the one of the vanilla game, with all chunk data being stored in the 'db' folder.
+// Tables are written without compression, which the vanilla game reads fine. Worlds last saved by the vanilla game
+// contain zlib compressed tables however, which can not be read and are rejected with UnsupportedCompression.
+type LevelDBProvider struct {
+	path  string
+	db    *leveldb.DB
+	mutex sync.RWMutex
+}
+
+// NewLevelDBProvider returns a new LevelDB provider for the level at the given path.
+// The database gets created if it does not yet exist.
+// Returns UnsupportedCompression if the database contains compressed tables written by the vanilla game.
+func NewLevelDBProvider(path string) (*LevelDBProvider, error) {
+	var provider = &LevelDBProvider{path: path}
+	if err := os.MkdirAll(path+"db", 0700); err != nil {
+		return provider, err
+	}
+	if err := checkCompression(path + "db"); err != nil {
+		return provider, err
+	}
+
+	var db, err = leveldb.OpenFile(path+"db", &opt.Options{Compression: opt.NoCompression})
+	if err != nil {
+		return provider, translateError(err)
+	}
+	provider.db = db
+
+	return provider, nil
+}
+
+// GetPath returns the path of the level folder of this provider.
+func (provider *LevelDBProvider) GetPath() string {
+	return provider.path
+}
+
+// GetChunkKey returns the database key prefix of a chunk at the given coordinates in the given dimension.
+// The dimension ID is omitted for the overworld, as the vanilla game does.
+func GetChunkKey(x, z int32, dimension int) []byte {
+	var stream = binutils.NewStream()
+	stream.PutLittleInt(x)
+	stream.PutLittleInt(z)
+	if dimension != 0 {
+		stream.PutLittleInt(int32(dimension))
+	}
+	return stream.GetBuffer()
+}
+
+// getKey returns the full database key of a chunk, followed by the given tag bytes.
+func (provider *LevelDBProvider) getKey(x, z int32, dimension int, tag ...byte) []byte {
+	return append(GetChunkKey(x, z, dimension), tag...)
+}
+
+// ChunkExists checks if a chunk at the given coordinates has been saved in the given dimension.
+func (provider *LevelDBProvider) ChunkExists(x, z int32, dimension int) bool {
+	var exists, err = provider.db.Has(provider.getKey(x, z, dimension, TagVersion), nil)
+	return exists && err == nil
+}
+
+// get returns the value of the key in the database, or nil if the key does not exist.
+// Errors caused by compressed blocks are returned as UnsupportedCompression.
+func (provider *LevelDBProvider) get(key []byte) ([]byte, error) {
+	var data, err = provider.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	return data, translateError(err)
+}
+
+// LoadChunk loads a chunk at the given coordinates in the given dimension from the database.
+// Returns an error if the chunk does not exist, or if any of its data could not be read or decoded.
+func (provider *LevelDBProvider) LoadChunk(x, z int32, dimension int) (interfaces.IChunk, error) {
+	var version, err = provider.db.Get(provider.getKey(x, z, dimension, TagVersion), nil)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	if len(version) == 0 || version[0] > CurrentChunkVersion {
+		return nil, UnsupportedChunkVersion
+	}
+	var chunkVersion = version[0]
+	var chunk = chunks.NewChunk(x, z)
+	var lightPopulated = true
+
+	for y := 0; y < 16; y++ {
+		var data, err = provider.get(provider.getKey(x, z, dimension, TagSubChunkPrefix, byte(y)))
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		var subChunk, hasLight, decodeErr = decodeSubChunk(data, chunkVersion)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		if !hasLight {
+			lightPopulated = false
+		}
+		chunk.SetSubChunk(y, subChunk)
+	}
+	chunk.SetLightPopulated(lightPopulated)
+
+	var data2D, data2DErr = provider.get(provider.getKey(x, z, dimension, TagData2D))
+	if data2DErr != nil {
+		return nil, data2DErr
+	}
+	if len(data2D) >= 768 {
+		var stream = binutils.NewStream()
+		stream.SetBuffer(data2D)
+		for z := 0; z < 16; z++ {
+			for x := 0; x < 16; x++ {
+				chunk.SetHeightMap(x, z, stream.GetLittleShort())
+			}
+		}
+		for z := 0; z < 16; z++ {
+			for x := 0; x < 16; x++ {
+				chunk.SetBiome(x, z, int(stream.GetByte()))
+			}
+		}
+	} else {
+		chunk.RecalculateHeightMap()
+	}
+
+	var entities, entityErr = provider.get(provider.getKey(x, z, dimension, TagEntity))
+	if entityErr != nil {
+		return nil, entityErr
+	}
+	if entities != nil {
+		chunk.SetEntityNBT(entities)
+	}
+	var tiles, tileErr = provider.get(provider.getKey(x, z, dimension, TagBlockEntity))
+	if tileErr != nil {
+		return nil, tileErr
+	}
+	if tiles != nil {
+		chunk.SetTileNBT(tiles)
+	}
+
+	var state, stateErr = provider.get(provider.getKey(x, z, dimension, TagFinalizedState))
+	if stateErr != nil {
+		return nil, stateErr
+	}
+	// Chunks saved before the finalized state was introduced are always populated.
+	chunk.SetTerrainPopulated(true)
+	if len(state) >= 4 {
+		var stream = binutils.NewStream()
+		stream.SetBuffer(state)
+		chunk.SetTerrainPopulated(stream.GetLittleInt() == FinalizedStateDone)
+	}
+
+	return chunk, nil
+}
+
+// SaveChunk writes the given chunk to the database in the given dimension.
+// All data of the chunk gets written in one atomic batch.
+func (provider *LevelDBProvider) SaveChunk(chunk interfaces.IChunk, dimension int) error {
+	var x, z = chunk.GetX(), chunk.GetZ()
+	var batch = new(leveldb.Batch)
+
+	batch.Put(provider.getKey(x, z, dimension, TagVersion), []byte{CurrentChunkVersion})
+
+	var subChunks = chunk.GetSubChunks()
+	for y := 0; y < 16; y++ {
+		var key = provider.getKey(x, z, dimension, TagSubChunkPrefix, byte(y))
+		if subChunk, ok := subChunks[y]; ok && !subChunk.IsAllAir() {
+			batch.Put(key, encodeSubChunk(subChunk))
+			continue
+		}
+		batch.Delete(key)
+	}
+
+	var stream = binutils.NewStream()
+	for z := 0; z < 16; z++ {
+		for x := 0; x < 16; x++ {
+			stream.PutLittleShort(chunk.GetHeightMap(x, z))
+		}
+	}
+	for z := 0; z < 16; z++ {
+		for x := 0; x < 16; x++ {
+			stream.PutByte(byte(chunk.GetBiome(x, z)))
+		}
+	}
+	batch.Put(provider.getKey(x, z, dimension, TagData2D), stream.GetBuffer())
+
+	putOrDelete(batch, provider.getKey(x, z, dimension, TagEntity), chunk.GetEntityNBT())
+	// Tiles that can not be encoded are left out, and reported once the rest of the chunk is saved.
+	var tileNBT, tileErr = chunk.GetTileNBT()
+	putOrDelete(batch, provider.getKey(x, z, dimension, TagBlockEntity), tileNBT)
+
+	var state = binutils.NewStream()
+	if chunk.IsTerrainPopulated() {
+		state.PutLittleInt(FinalizedStateDone)
+	} else {
+		state.PutLittleInt(FinalizedStateNeedsPopulation)
+	}
+	batch.Put(provider.getKey(x, z, dimension, TagFinalizedState), state.GetBuffer())
+
+	provider.mutex.RLock()
+	defer provider.mutex.RUnlock()
+	if err := provider.db.Write(batch, nil); err != nil {
+		return err
+	}
+	return tileErr
+}
+
+// Backup writes a consistent copy of the level folder of the provider to the given path, while the database stays open.
+// Saving chunks is paused while a snapshot of the database is taken and the other files of the level get copied.
+// The database of the copy is then written from the snapshot, while chunks can be saved again.
+func (provider *LevelDBProvider) Backup(path string) error {
+	provider.mutex.Lock()
+	var snapshot, err = provider.db.GetSnapshot()
+	if err == nil {
+		err = copyLevelFiles(provider.path, path)
+	}
+	provider.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	if err := os.MkdirAll(path+"db", 0700); err != nil {
+		return err
+	}
+	var db, openErr = leveldb.OpenFile(path+"db", &opt.Options{Compression: opt.NoCompression})
+	if openErr != nil {
+		return openErr
+	}
+
+	var iterator = snapshot.NewIterator(nil, nil)
+	var batch = new(leveldb.Batch)
+	for iterator.Next() {
+		batch.Put(iterator.Key(), iterator.Value())
+		if batch.Len() >= backupBatchSize {
+			if err = db.Write(batch, nil); err != nil {
+				break
+			}
+			batch.Reset()
+		}
+	}
+	iterator.Release()
+	if err == nil {
+		err = iterator.Error()
+	}
+	if err == nil && batch.Len() > 0 {
+		err = db.Write(batch, nil)
+	}
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close closes the database of the provider.
+// The provider should not be used after closing.
+func (provider *LevelDBProvider) Close() error {
+	return provider.db.Close()
+}
+
+// copyLevelFiles copies all files directly in the level folder, such as level.dat, to the destination folder.
+// The database folder is not copied.
+func copyLevelFiles(from string, to string) error {
+	if err := os.MkdirAll(to, 0700); err != nil {
+		return err
+	}
+	var files, err = ioutil.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		var data, err = ioutil.ReadFile(from + file.Name())
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(to+file.Name(), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCompression checks the blocks of the index of every table in the database folder for their compression type.
+// The vanilla game compresses all blocks of a table alike, so that one block is enough to detect compressed tables.
+// Returns UnsupportedCompression if any table uses a compression other than none or snappy.
+func checkCompression(path string) error {
+	var files, err = ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, info := range files {
+		var extension = filepath.Ext(info.Name())
+		if info.IsDir() || (extension != ".ldb" && extension != ".sst") || info.Size() < tableFooterSize {
+			continue
+		}
+		var compression, err = getTableCompression(filepath.Join(path, info.Name()), info.Size())
+		if err != nil {
+			return err
+		}
+		if compression != compressionNone && compression != compressionSnappy {
+			return UnsupportedCompression
+		}
+	}
+	return nil
+}
+
+// getTableCompression returns the compression type of the index block of the table file with the given size.
+// The type is stored in the byte following the block, of which the handle is the second one in the footer of the file.
+func getTableCompression(path string, size int64) (byte, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var footer = make([]byte, tableFooterSize)
+	if _, err := file.ReadAt(footer, size-tableFooterSize); err != nil {
+		return 0, err
+	}
+	// The footer starts with the handle of the meta index block, followed by the handle of the index block.
+	var offset, length, n = 0, 0, 0
+	for i := 0; i < 2; i++ {
+		var value, read = binary.Uvarint(footer[n:])
+		if read <= 0 {
+			return 0, CorruptedTable
+		}
+		n += read
+		var handleLength, lengthRead = binary.Uvarint(footer[n:])
+		if lengthRead <= 0 {
+			return 0, CorruptedTable
+		}
+		n += lengthRead
+		offset, length = int(value), int(handleLength)
+	}
+	if int64(offset+length) >= size {
+		return 0, CorruptedTable
+	}
+	var compression = make([]byte, 1)
+	if _, err := file.ReadAt(compression, int64(offset+length)); err != nil {
+		return 0, err
+	}
+	return compression[0], nil
+}
+
+// translateError returns UnsupportedCompression if the error was caused by a block with an unknown compression type,
+// or the error itself otherwise.
+func translateError(err error) error {
+	var corrupted, ok = err.(*leveldbErrors.ErrCorrupted)
+	if !ok {
+		return err
+	}
+	if tableErr, ok := corrupted.Err.(*table.ErrCorrupted); ok && strings.HasPrefix(tableErr.Reason, "unknown compression type") {
+		return UnsupportedCompression
+	}
+	return err
+}
+
+// putOrDelete puts the value in the batch if it is not empty, and deletes the key otherwise.
+func putOrDelete(batch *leveldb.Batch, key []byte, value []byte) {
+	if len(value) == 0 {
+		batch.Delete(key)
+		return
+	}
+	batch.Put(key, value)
+}
+
+// decodeSubChunk decodes a sub chunk in the legacy (pre-palette) format.
+// Returns a bool indicating if the sub chunk contained light data.
+func decodeSubChunk(data []byte, chunkVersion byte) (interfaces.ISubChunk, bool, error) {
+	if len(data) == 0 {
+		return nil, false, CorruptedSubChunk
+	}
+	switch data[0] {
+	case 0, 2, 3, 4, 5, 6, 7:
+	default:
+		return nil, false, UnsupportedSubChunkVersion
+	}
+	data = data[1:]
+	if len(data) < subChunkIdsSize+subChunkNibbleSize {
+		return nil, false, CorruptedSubChunk
+	}
+
+	var subChunk = chunks.NewSubChunk()
+	var ids = data[:subChunkIdsSize]
+	var meta = data[subChunkIdsSize : subChunkIdsSize+subChunkNibbleSize]
+
+	// Light stopped being saved in chunk version 4, without the sub chunk version changing.
+	var hasLight = chunkVersion < 4 && len(data) >= subChunkIdsSize+subChunkNibbleSize*3
+	var skyLight, blockLight []byte
+	if hasLight {
+		skyLight = data[subChunkIdsSize+subChunkNibbleSize : subChunkIdsSize+subChunkNibbleSize*2]
+		blockLight = data[subChunkIdsSize+subChunkNibbleSize*2 : subChunkIdsSize+subChunkNibbleSize*3]
+	}
+
+	for i := 0; i < subChunkIdsSize; i++ {
+		var x, y, z = i >> 8, i & 15, (i >> 4) & 15
+		subChunk.SetBlockId(x, y, z, ids[i])
+		subChunk.SetBlockData(x, y, z, getNibble(meta, i))
+		if hasLight {
+			subChunk.SetSkyLight(x, y, z, getNibble(skyLight, i))
+			subChunk.SetBlockLight(x, y, z, getNibble(blockLight, i))
+		}
+	}
+	subChunk.Compact()
+	return subChunk, hasLight, nil
+}
+
+// encodeSubChunk encodes a sub chunk in the legacy format, without light.
+func encodeSubChunk(subChunk interfaces.ISubChunk) []byte {
+	var data = make([]byte, 1+subChunkIdsSize+subChunkNibbleSize)
+	data[0] = CurrentSubChunkVersion
+
+	var ids = data[1 : 1+subChunkIdsSize]
+	var meta = data[1+subChunkIdsSize:]
+	for i := 0; i < subChunkIdsSize; i++ {
+		var x, y, z = i >> 8, i & 15, (i >> 4) & 15
+		ids[i] = subChunk.GetBlockId(x, y, z)
+		setNibble(meta, i, subChunk.GetBlockData(x, y, z))
+	}
+	return data
+}
+
+// getNibble returns the nibble at the given block index in the nibble array.
+func getNibble(array []byte, index int) byte {
+	if index&1 == 0 {
+		return array[index>>1] & 0x0f
+	}
+	return array[index>>1] >> 4
+}
+
+// setNibble sets the nibble at the given block index in the nibble array.
+func setNibble(array []byte, index int, value byte) {
+	var i = index >> 1
+	if index&1 == 0 {
+		array[i] = (array[i] & 0xf0) | (value & 0x0f)
+		return
+	}
+	array[i] = ((value & 0x0f) << 4) | (array[i] & 0x0f)
+}
@@ -0,0 +1,194 @@
+package worlds
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/vectors"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// RayTraceResult is the block or entity hit by a ray traced through a dimension.
+type RayTraceResult struct {
+	position      r3.Vector
+	distance      float64
+	face          int
+	blockPosition r3.Vector
+	block         interfaces.IBlock
+	entity        interfaces.IEntity
+}
+
+// GetPosition returns the position at which the ray hit the block or entity.
+func (result *RayTraceResult) GetPosition() r3.Vector {
+	return result.position
+}
+
+// GetDistance returns the distance in blocks from the origin of the ray to the hit position.
+func (result *RayTraceResult) GetDistance() float64 {
+	return result.distance
+}
+
+// GetFace returns the face of the block or bounding box of the entity the ray entered through.
+// The face is vectors.FaceNone if the ray started inside of the block or entity.
+func (result *RayTraceResult) GetFace() int {
+	return result.face
+}
+
+// GetBlockPosition returns the position of the block that was hit, or of the block the hit entity is in.
+func (result *RayTraceResult) GetBlockPosition() r3.Vector {
+	return result.blockPosition
+}
+
+// GetBlock returns the block that was hit, or nil if an entity was hit.
+func (result *RayTraceResult) GetBlock() interfaces.IBlock {
+	return result.block
+}
+
+// GetEntity returns the entity that was hit, or nil if a block was hit.
+func (result *RayTraceResult) GetEntity() interfaces.IEntity {
+	return result.entity
+}
+
+// Traces a ray from the origin in the direction, and returns the first block with a collision box the ray hits
+// within the maximum distance in blocks. Blocks are visited one by one along the ray, and hits are tested against
+// the collision boxes of the blocks, so that rays pass over slabs and through blocks without collision box.
+// Returns nil if no block was hit, or if the ray reaches a chunk that is not loaded first.
+
+func (dimension *Dimension) RayTraceBlocks(origin, direction r3.Vector, maxDistance float64) interfaces.IRayTraceResult {
+	if direction.Norm() == 0 {
+		return nil
+	}
+	direction = direction.Normalize()
+
+	var x, y, z = int(math.Floor(origin.X)), int(math.Floor(origin.Y)), int(math.Floor(origin.Z))
+	var stepX, distanceX, deltaX = getTraversal(origin.X, direction.X)
+	var stepY, distanceY, deltaY = getTraversal(origin.Y, direction.Y)
+	var stepZ, distanceZ, deltaZ = getTraversal(origin.Z, direction.Z)
+
+	for {
+		if y >= 0 && y <= 255 {
+			var chunk = dimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+			if chunk == nil {
+				return nil
+			}
+			var block = blocks.GetBlock(int(chunk.GetBlockId(x&15, y, z&15)), chunk.GetBlockData(x&15, y, z&15))
+			if result := traceBlock(block, x, y, z, origin, direction, maxDistance); result != nil {
+				return result
+			}
+		} else if (y < 0 && stepY <= 0) || (y > 255 && stepY >= 0) {
+			return nil
+		}
+
+		// Step into the next block on the axis of which the block boundary is closest along the ray.
+		if distanceX <= distanceY && distanceX <= distanceZ {
+			if distanceX > maxDistance {
+				return nil
+			}
+			x += stepX
+			distanceX += deltaX
+		} else if distanceY <= distanceZ {
+			if distanceY > maxDistance {
+				return nil
+			}
+			y += stepY
+			distanceY += deltaY
+		} else {
+			if distanceZ > maxDistance {
+				return nil
+			}
+			z += stepZ
+			distanceZ += deltaZ
+		}
+	}
+}
+
+// Traces a ray from the origin in the direction, and returns the first entity of which the bounding box the ray hits
+// within the maximum distance in blocks. Entities for which the filter returns false are ignored, and a nil filter
+// accepts all entities. Blocks do not stop the ray: compare the distance with the result of RayTraceBlocks
+// to check if an entity is visible. Only entities in loaded chunks are hit. Returns nil if no entity was hit.
+
+func (dimension *Dimension) RayTraceEntities(origin, direction r3.Vector, maxDistance float64, filter func(interfaces.IEntity) bool) interfaces.IRayTraceResult {
+	if direction.Norm() == 0 {
+		return nil
+	}
+	direction = direction.Normalize()
+	var end = origin.Add(direction.Mul(maxDistance))
+	// Entities may stick out of the chunk they are in, so chunks next to the ray are searched too.
+	var minX, maxX = int32(math.Floor(math.Min(origin.X, end.X)))>>4 - 1, int32(math.Floor(math.Max(origin.X, end.X)))>>4 + 1
+	var minZ, maxZ = int32(math.Floor(math.Min(origin.Z, end.Z)))>>4 - 1, int32(math.Floor(math.Max(origin.Z, end.Z)))>>4 + 1
+
+	var closest *RayTraceResult
+	for chunkX := minX; chunkX <= maxX; chunkX++ {
+		for chunkZ := minZ; chunkZ <= maxZ; chunkZ++ {
+			var chunk = dimension.GetLoadedChunk(chunkX, chunkZ)
+			if chunk == nil {
+				continue
+			}
+			for _, entity := range chunk.GetEntities() {
+				if entity.IsClosed() || (filter != nil && !filter(entity)) {
+					continue
+				}
+				var distance, face, ok = entity.GetBoundingBox().IntersectRay(origin, direction, maxDistance)
+				if !ok || (closest != nil && distance >= closest.distance) {
+					continue
+				}
+				var position = entity.GetPosition()
+				closest = &RayTraceResult{
+					position:      origin.Add(direction.Mul(distance)),
+					distance:      distance,
+					face:          face,
+					blockPosition: r3.Vector{X: math.Floor(position.X), Y: math.Floor(position.Y), Z: math.Floor(position.Z)},
+					entity:        entity,
+				}
+			}
+		}
+	}
+	if closest == nil {
+		return nil
+	}
+	return closest
+}
+
+// Returns the closest hit of a ray with the collision box of the given block at the given position, or nil if the ray misses.
+
+func traceBlock(block interfaces.IBlock, x, y, z int, origin, direction r3.Vector, maxDistance float64) *RayTraceResult {
+	if !block.HasCollisionBox() {
+		return nil
+	}
+	var closest *RayTraceResult
+	for _, cube := range block.GetCollisionBox().GetCubes() {
+		var distance, face, ok = cube.Offset(float64(x), float64(y), float64(z)).IntersectRay(origin, direction, maxDistance)
+		if !ok || (closest != nil && distance >= closest.distance) {
+			continue
+		}
+		closest = &RayTraceResult{
+			position:      origin.Add(direction.Mul(distance)),
+			distance:      distance,
+			face:          face,
+			blockPosition: r3.Vector{X: float64(x), Y: float64(y), Z: float64(z)},
+			block:         block,
+		}
+	}
+	return closest
+}
+
+// Returns the step of a ray on one axis through the block grid: the direction in which the block coordinate changes,
+// the distance along the ray to the first block boundary, and the distance along the ray between block boundaries.
+
+func getTraversal(origin, direction float64) (int, float64, float64) {
+	switch {
+	case direction > 0:
+		return 1, (math.Floor(origin) + 1 - origin) / direction, 1 / direction
+	case direction < 0:
+		return -1, (origin - math.Floor(origin)) / -direction, 1 / -direction
+	}
+	return 0, math.Inf(1), math.Inf(1)
+}
+
+// Returns the position of the block next to the hit block at the face the ray entered through,
+// where a block would be placed when using the hit block. Returns the hit block position if the face is unknown.
+
+func GetAdjacentPosition(result interfaces.IRayTraceResult) r3.Vector {
+	return result.GetBlockPosition().Add(vectors.GetFaceOffset(result.GetFace()))
+}
@@ -0,0 +1,235 @@
+package worlds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/vectors"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/chunks"
+)
+
+// newTestDimension returns a dimension with the empty chunks from -1 to 1 on both axes loaded.
+func newTestDimension() *Dimension {
+	var dimension = &Dimension{chunks: make(map[int]interfaces.IChunk)}
+	for x := int32(-1); x <= 1; x++ {
+		for z := int32(-1); z <= 1; z++ {
+			dimension.chunks[GetChunkIndex(x, z)] = chunks.NewChunk(x, z)
+		}
+	}
+	return dimension
+}
+
+// setTestBlock sets the block at the given position in a loaded chunk of the dimension.
+func setTestBlock(dimension *Dimension, x, y, z int, id int, data byte) {
+	var chunk = dimension.chunks[GetChunkIndex(int32(x>>4), int32(z>>4))]
+	chunk.SetBlockId(x&15, y, z&15, byte(id))
+	chunk.SetBlockData(x&15, y, z&15, data)
+}
+
+// checkBlockHit checks if the result is a hit of the block at the given position, through the face at the position.
+func checkBlockHit(t *testing.T, name string, result interfaces.IRayTraceResult, block, position r3.Vector, face int) {
+	t.Helper()
+	if result == nil {
+		t.Errorf("%v: ray missed, want hit of block %v", name, block)
+		return
+	}
+	if result.GetBlockPosition() != block {
+		t.Errorf("%v: hit block %v, want %v", name, result.GetBlockPosition(), block)
+	}
+	if result.GetPosition().Sub(position).Norm() > 1e-9 {
+		t.Errorf("%v: hit at %v, want %v", name, result.GetPosition(), position)
+	}
+	if result.GetFace() != face {
+		t.Errorf("%v: hit face %v, want %v", name, result.GetFace(), face)
+	}
+}
+
+func TestRayTraceAxisAligned(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 5, 10, 0, blocks.STONE, 0)
+
+	var result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: 1}, 10)
+	checkBlockHit(t, "east", result, r3.Vector{X: 5, Y: 10}, r3.Vector{X: 5, Y: 10.5, Z: 0.5}, vectors.FaceWest)
+	if result != nil && math.Abs(result.GetDistance()-4.5) > 1e-9 {
+		t.Errorf("hit at distance %v, want 4.5", result.GetDistance())
+	}
+	if result != nil && result.GetBlock().GetId() != blocks.STONE {
+		t.Errorf("hit block %v, want stone", result.GetBlock().GetId())
+	}
+
+	if result := dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: 1}, 4); result != nil {
+		t.Errorf("ray hit block %v beyond its maximum distance", result.GetBlockPosition())
+	}
+	if result := dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: -1}, 10); result != nil {
+		t.Errorf("ray away from the block hit %v", result.GetBlockPosition())
+	}
+}
+
+func TestRayTraceDiagonal(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 3, 10, 3, blocks.STONE, 0)
+
+	// The ray passes the corner of the block at x 3, and enters it through its north face at z 3.
+	var result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.2}, r3.Vector{X: 1, Z: 1}, 10)
+	checkBlockHit(t, "horizontal", result, r3.Vector{X: 3, Y: 10, Z: 3}, r3.Vector{X: 3.3, Y: 10.5, Z: 3}, vectors.FaceNorth)
+	if result != nil && math.Abs(result.GetDistance()-2.8*math.Sqrt2) > 1e-9 {
+		t.Errorf("hit at distance %v, want %v", result.GetDistance(), 2.8*math.Sqrt2)
+	}
+
+	setTestBlock(dimension, -2, 8, -2, blocks.STONE, 0)
+	result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: -1, Y: -0.8, Z: -1}, 10)
+	checkBlockHit(t, "descending", result, r3.Vector{X: -2, Y: 8, Z: -2}, r3.Vector{X: -1.375, Y: 9, Z: -1.375}, vectors.FaceUp)
+}
+
+func TestRayTraceOverSlab(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 3, 10, 0, blocks.STONE_SLAB, 0)
+	setTestBlock(dimension, 6, 10, 0, blocks.STONE, 0)
+
+	var result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.75, Z: 0.5}, r3.Vector{X: 1}, 10)
+	checkBlockHit(t, "over slab", result, r3.Vector{X: 6, Y: 10}, r3.Vector{X: 6, Y: 10.75, Z: 0.5}, vectors.FaceWest)
+
+	result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.25, Z: 0.5}, r3.Vector{X: 1}, 10)
+	checkBlockHit(t, "into slab", result, r3.Vector{X: 3, Y: 10}, r3.Vector{X: 3, Y: 10.25, Z: 0.5}, vectors.FaceWest)
+
+	// A ray coming down on the slab hits its top in the middle of the block.
+	result = dimension.RayTraceBlocks(r3.Vector{X: 3.5, Y: 12.5, Z: 0.5}, r3.Vector{Y: -1}, 10)
+	checkBlockHit(t, "onto slab", result, r3.Vector{X: 3, Y: 10}, r3.Vector{X: 3.5, Y: 10.5, Z: 0.5}, vectors.FaceUp)
+}
+
+func TestRayTraceWithoutCollisionBox(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 3, 10, 0, blocks.TALL_GRASS, 1)
+	setTestBlock(dimension, 6, 10, 0, blocks.STONE, 0)
+
+	var result = dimension.RayTraceBlocks(r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: 1}, 10)
+	checkBlockHit(t, "through grass", result, r3.Vector{X: 6, Y: 10}, r3.Vector{X: 6, Y: 10.5, Z: 0.5}, vectors.FaceWest)
+}
+
+func TestRayTraceUnloadedChunk(t *testing.T) {
+	var dimension = newTestDimension()
+	var origin, direction = r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}, r3.Vector{X: 1}
+	if result := dimension.RayTraceBlocks(origin, direction, 100); result != nil {
+		t.Fatalf("ray hit %v in empty chunks", result.GetBlockPosition())
+	}
+
+	// The block is in chunk 2, which is not loaded, so the ray stops at the chunk border.
+	var chunk = chunks.NewChunk(2, 0)
+	chunk.SetBlockId(8, 10, 0, blocks.STONE)
+	if result := dimension.RayTraceBlocks(origin, direction, 100); result != nil {
+		t.Errorf("ray hit %v in an unloaded chunk", result.GetBlockPosition())
+	}
+
+	dimension.chunks[GetChunkIndex(2, 0)] = chunk
+	var result = dimension.RayTraceBlocks(origin, direction, 100)
+	checkBlockHit(t, "loaded", result, r3.Vector{X: 40, Y: 10}, r3.Vector{X: 40, Y: 10.5, Z: 0.5}, vectors.FaceWest)
+}
+
+func TestRayTraceOriginInsideBlock(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 0, 10, 0, blocks.STONE, 0)
+
+	var origin = r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}
+	var result = dimension.RayTraceBlocks(origin, r3.Vector{X: 1, Y: 1}, 10)
+	checkBlockHit(t, "inside", result, r3.Vector{Y: 10}, origin, vectors.FaceNone)
+	if result != nil && result.GetDistance() != 0 {
+		t.Errorf("hit at distance %v, want 0", result.GetDistance())
+	}
+}
+
+func TestRayTraceFaces(t *testing.T) {
+	var dimension = newTestDimension()
+	setTestBlock(dimension, 0, 10, 0, blocks.STONE, 0)
+	var block = r3.Vector{Y: 10}
+
+	var tests = []struct {
+		name   string
+		offset r3.Vector
+		face   int
+	}{
+		{"down", r3.Vector{Y: -1}, vectors.FaceDown},
+		{"up", r3.Vector{Y: 1}, vectors.FaceUp},
+		{"north", r3.Vector{Z: -1}, vectors.FaceNorth},
+		{"south", r3.Vector{Z: 1}, vectors.FaceSouth},
+		{"west", r3.Vector{X: -1}, vectors.FaceWest},
+		{"east", r3.Vector{X: 1}, vectors.FaceEast},
+	}
+	for _, test := range tests {
+		var center = r3.Vector{X: 0.5, Y: 10.5, Z: 0.5}
+		var origin = center.Add(test.offset.Mul(3))
+		var result = dimension.RayTraceBlocks(origin, test.offset.Mul(-1), 10)
+		checkBlockHit(t, test.name, result, block, center.Add(test.offset.Mul(0.5)), test.face)
+		if result != nil && GetAdjacentPosition(result) != block.Add(test.offset) {
+			t.Errorf("%v: adjacent position %v, want %v", test.name, GetAdjacentPosition(result), block.Add(test.offset))
+		}
+	}
+}
+
+// testEntity is an entity with a bounding box of 0.6 by 1.8 blocks standing at its position.
+type testEntity struct {
+	interfaces.IEntity
+	id       uint64
+	position r3.Vector
+	closed   bool
+}
+
+func (entity *testEntity) GetRuntimeId() uint64 {
+	return entity.id
+}
+
+func (entity *testEntity) GetPosition() r3.Vector {
+	return entity.position
+}
+
+func (entity *testEntity) IsClosed() bool {
+	return entity.closed
+}
+
+func (entity *testEntity) GetBoundingBox() *vectors.Cube {
+	var position = entity.position
+	return vectors.NewCube(position.X-0.3, position.Y, position.Z-0.3, position.X+0.3, position.Y+1.8, position.Z+0.3)
+}
+
+func TestRayTraceEntitiesClosest(t *testing.T) {
+	var dimension = newTestDimension()
+	var far = &testEntity{id: 1, position: r3.Vector{X: 6.5, Y: 10, Z: 0.5}}
+	var near = &testEntity{id: 2, position: r3.Vector{X: 4.5, Y: 10, Z: 0.5}}
+	var closed = &testEntity{id: 3, position: r3.Vector{X: 2.5, Y: 10, Z: 0.5}}
+	var filtered = &testEntity{id: 4, position: r3.Vector{X: 3.5, Y: 10, Z: 0.5}}
+	var beside = &testEntity{id: 5, position: r3.Vector{X: 1.5, Y: 10, Z: 3.5}}
+	// This entity is in a chunk at z -1, but its bounding box sticks out into the chunks the ray passes through.
+	var other = &testEntity{id: 6, position: r3.Vector{X: 20.5, Y: 10, Z: -0.1}}
+	for _, entity := range []*testEntity{far, near, closed, filtered, beside, other} {
+		dimension.chunks[GetChunkIndex(int32(math.Floor(entity.position.X))>>4, int32(math.Floor(entity.position.Z))>>4)].AddEntity(entity)
+	}
+	closed.closed = true
+
+	var filter = func(entity interfaces.IEntity) bool {
+		return entity != filtered
+	}
+	var result = dimension.RayTraceEntities(r3.Vector{X: 0.5, Y: 11, Z: 0.5}, r3.Vector{X: 1}, 30, filter)
+	if result == nil {
+		t.Fatal("ray missed all entities")
+	}
+	if result.GetEntity() != near {
+		t.Fatalf("ray hit entity %v, want %v", result.GetEntity().GetRuntimeId(), near.id)
+	}
+	if math.Abs(result.GetDistance()-3.7) > 1e-9 || result.GetFace() != vectors.FaceWest {
+		t.Errorf("hit at distance %v through face %v, want 3.7 through %v", result.GetDistance(), result.GetFace(), vectors.FaceWest)
+	}
+	if result.GetBlockPosition() != (r3.Vector{X: 4, Y: 10}) {
+		t.Errorf("hit entity in block %v, want 4, 10, 0", result.GetBlockPosition())
+	}
+
+	result = dimension.RayTraceEntities(r3.Vector{X: 0.5, Y: 11, Z: 0.5}, r3.Vector{X: -1}, 30, filter)
+	if result != nil {
+		t.Errorf("ray away from all entities hit entity %v", result.GetEntity().GetRuntimeId())
+	}
+	result = dimension.RayTraceEntities(r3.Vector{X: 8.5, Y: 11, Z: 0.1}, r3.Vector{X: 1}, 30, nil)
+	if result == nil || result.GetEntity() != other {
+		t.Errorf("ray did not hit the entity sticking out of the next chunk")
+	}
+}
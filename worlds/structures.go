@@ -0,0 +1,106 @@
+package worlds
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/interfaces"
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/tiles"
+	"github.com/irmine/gomine/worlds/blocks"
+	"github.com/irmine/gomine/worlds/lighting"
+	"github.com/irmine/gomine/worlds/structures"
+)
+
+// Pastes the structure with its minimum corner at the given position, after mirroring and rotating it.
+// Structure void leaves blocks unchanged, and tiles of the structure replace tiles in the dimension.
+// Chunks in the area get loaded or generated if needed, and are relit and resent to their viewers.
+// Block data, such as the facing of stairs, is not rotated.
+
+func (dimension *Dimension) PasteStructure(position r3.Vector, structure interfaces.IStructure, rotation int, mirror int) {
+	var originX, originY, originZ = int(math.Floor(position.X)), int(math.Floor(position.Y)), int(math.Floor(position.Z))
+	var width, height, length = structure.GetSize()
+	var modified = make(map[int]interfaces.IChunk)
+
+	for y := 0; y < height; y++ {
+		var blockY = originY + y
+		if blockY < 0 || blockY > 255 {
+			continue
+		}
+		for z := 0; z < length; z++ {
+			for x := 0; x < width; x++ {
+				var id, data = structure.GetBlock(x, y, z)
+				if id == blocks.STRUCTURE_VOID {
+					continue
+				}
+				var offsetX, offsetZ = structures.Transform(x, z, width, length, rotation, mirror)
+				var blockX, blockZ = originX + offsetX, originZ + offsetZ
+				var chunk = dimension.GetChunk(int32(blockX>>4), int32(blockZ>>4))
+
+				if tile := chunk.GetTile(blockX&15, blockY, blockZ&15); tile != nil {
+					chunk.RemoveTile(tile)
+					tile.Close()
+				}
+				chunk.SetBlockId(blockX&15, blockY, blockZ&15, id)
+				chunk.SetBlockData(blockX&15, blockY, blockZ&15, data)
+
+				if compound := structure.GetTile(x, y, z); compound != nil {
+					var copied = nbt.NewCompound()
+					for name, value := range compound {
+						copied[name] = value
+					}
+					copied["x"], copied["y"], copied["z"] = int32(blockX), int32(blockY), int32(blockZ)
+					if tile := tiles.NewTileFromNBT(copied); tile != nil {
+						chunk.AddTile(tile)
+					}
+				}
+				modified[GetChunkIndex(chunk.GetX(), chunk.GetZ())] = chunk
+			}
+		}
+	}
+
+	var changed = make([]interfaces.IChunk, 0, len(modified))
+	for _, chunk := range modified {
+		changed = append(changed, chunk)
+	}
+	dimension.RefreshChunks(changed)
+}
+
+// Recalculates the light of chunks of which many blocks were changed directly, and resends the chunks to their viewers.
+// Every chunk is sent as one batch, instead of an UpdateBlock packet for every changed block.
+
+func (dimension *Dimension) RefreshChunks(chunks []interfaces.IChunk) {
+	for _, chunk := range chunks {
+		lighting.LightChunk(chunk)
+	}
+	for _, chunk := range chunks {
+		dimension.light.SpreadBorders(chunk)
+	}
+	for _, chunk := range chunks {
+		for _, viewer := range chunk.GetViewers() {
+			viewer.SendFullChunkData(chunk)
+		}
+	}
+}
+
+// Returns a structure holding the blocks and tiles in the cuboid between the two positions, both inclusive.
+// Chunks in the area get loaded or generated if needed. Positions below or above the dimension are captured as air.
+
+func (dimension *Dimension) CaptureStructure(first, second r3.Vector) interfaces.IStructure {
+	var minX, minY, minZ = int(math.Floor(math.Min(first.X, second.X))), int(math.Floor(math.Min(first.Y, second.Y))), int(math.Floor(math.Min(first.Z, second.Z)))
+	var maxX, maxY, maxZ = int(math.Floor(math.Max(first.X, second.X))), int(math.Floor(math.Max(first.Y, second.Y))), int(math.Floor(math.Max(first.Z, second.Z)))
+	var structure = structures.NewStructure(maxX-minX+1, maxY-minY+1, maxZ-minZ+1)
+
+	for x := minX; x <= maxX; x++ {
+		for z := minZ; z <= maxZ; z++ {
+			var chunk = dimension.GetChunk(int32(x>>4), int32(z>>4))
+			for y := int(math.Max(float64(minY), 0)); y <= int(math.Min(float64(maxY), 255)); y++ {
+				structure.SetBlock(x-minX, y-minY, z-minZ, chunk.GetBlockId(x&15, y, z&15), chunk.GetBlockData(x&15, y, z&15))
+				if tile := chunk.GetTile(x&15, y, z&15); tile != nil {
+					structure.SetTile(x-minX, y-minY, z-minZ, tile.Save())
+				}
+			}
+		}
+	}
+	return structure
+}
@@ -0,0 +1,137 @@
+package structures
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// MCStructureFormatVersion is the format version of .mcstructure files.
+const MCStructureFormatVersion = 1
+
+// paletteEntry is a block ID and block data in the block palette of a .mcstructure file.
+type paletteEntry struct {
+	id, data byte
+}
+
+// ReadMCStructure reads a structure from a Bedrock Edition .mcstructure file, which holds little endian NBT.
+// Blocks in the palette are identified by their name and legacy 'val' data value. Blocks with unknown names,
+// and positions without block, are read as structure void. Entities and the second block layer are not read.
+func ReadMCStructure(r io.Reader) (*Structure, error) {
+	var compound, err = nbt.NewDecoder(r, nbt.LittleEndian).Decode()
+	if err != nil {
+		return nil, err
+	}
+	var size = compound.GetList("size")
+	if len(size) != 3 {
+		return nil, InvalidSize
+	}
+	var sizes [3]int
+	for i, value := range size {
+		var v, ok = value.(int32)
+		if !ok {
+			return nil, InvalidSize
+		}
+		sizes[i] = int(v)
+	}
+	var width, height, length = sizes[0], sizes[1], sizes[2]
+	structure, err := newStructure(width, height, length)
+	if err != nil {
+		return nil, err
+	}
+
+	var content = compound.GetCompound("structure")
+	var palette = content.GetCompound("palette").GetCompound("default")
+	var entries []paletteEntry
+	for _, value := range palette.GetList("block_palette") {
+		var block, _ = value.(nbt.Compound)
+		var id, ok = blocks.GetIdByIdentifier(block.GetString("name"))
+		if !ok {
+			id = blocks.STRUCTURE_VOID
+		}
+		entries = append(entries, paletteEntry{id, byte(block.GetShort("val"))})
+	}
+
+	var layers = content.GetList("block_indices")
+	if len(layers) > 0 {
+		var indices, _ = layers[0].([]interface{})
+		for i, value := range indices {
+			var x, y, z = i / (height * length), (i / length) % height, i % length
+			var index, _ = value.(int32)
+			if index < 0 || int(index) >= len(entries) {
+				structure.SetBlock(x, y, z, blocks.STRUCTURE_VOID, 0)
+				continue
+			}
+			structure.SetBlock(x, y, z, entries[index].id, entries[index].data)
+		}
+	}
+
+	for key, value := range palette.GetCompound("block_position_data") {
+		var i, err = strconv.Atoi(key)
+		var data, _ = value.(nbt.Compound)
+		if err != nil || i < 0 || data == nil || data.GetCompound("block_entity_data") == nil {
+			continue
+		}
+		structure.SetTile(i/(height*length), (i/length)%height, i%length, data.GetCompound("block_entity_data"))
+	}
+	return structure, nil
+}
+
+// WriteMCStructure writes the structure to a Bedrock Edition .mcstructure file.
+// Blocks are written with their name and legacy 'val' data value, and structure void is written as position without block.
+func WriteMCStructure(w io.Writer, structure *Structure) error {
+	var width, height, length = structure.GetSize()
+	var palette []interface{}
+	var paletteIndices = make(map[paletteEntry]int32)
+	var indices = make([]interface{}, width*height*length)
+	var voidLayer = make([]interface{}, len(indices))
+	var positionData = nbt.NewCompound()
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			for z := 0; z < length; z++ {
+				var i = (x*height+y)*length + z
+				voidLayer[i] = int32(-1)
+
+				var id, data = structure.GetBlock(x, y, z)
+				if id == blocks.STRUCTURE_VOID {
+					indices[i] = int32(-1)
+					continue
+				}
+				var entry = paletteEntry{id, data}
+				var index, ok = paletteIndices[entry]
+				if !ok {
+					index = int32(len(palette))
+					paletteIndices[entry] = index
+					palette = append(palette, nbt.Compound{"name": blocks.GetIdentifier(id), "val": int16(data)})
+				}
+				indices[i] = index
+
+				if tile := structure.GetTile(x, y, z); tile != nil {
+					positionData[strconv.Itoa(i)] = nbt.Compound{"block_entity_data": tile}
+				}
+			}
+		}
+	}
+	if palette == nil {
+		palette = []interface{}{}
+	}
+
+	return nbt.NewEncoder(w, nbt.LittleEndian).Encode(nbt.Compound{
+		"format_version": int32(MCStructureFormatVersion),
+		"size":           []interface{}{int32(width), int32(height), int32(length)},
+		"structure": nbt.Compound{
+			"block_indices": []interface{}{indices, voidLayer},
+			"entities":      []interface{}{},
+			"palette": nbt.Compound{
+				"default": nbt.Compound{
+					"block_palette":       palette,
+					"block_position_data": positionData,
+				},
+			},
+		},
+		"structure_world_origin": []interface{}{int32(0), int32(0), int32(0)},
+	})
+}
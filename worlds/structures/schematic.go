@@ -0,0 +1,278 @@
+package structures
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/tiles"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// anyData matches any block data in a block conversion, or keeps the block data when converting.
+const anyData = -1
+
+// conversion converts a block of Java Edition to the equal block of Bedrock Edition.
+type conversion struct {
+	javaId, javaData       int
+	bedrockId, bedrockData int
+}
+
+// conversions are the Java Edition blocks of which the block ID or block data differs in Bedrock Edition.
+// Blocks not in the list are kept as they are. Differences in the meaning of block data, such as the
+// facing of blocks, are not converted.
+var conversions = newConversions()
+
+// javaTiles maps the block entity IDs of Java Edition to the block entity IDs of Bedrock Edition.
+var javaTiles = map[string]string{
+	"banner":           tiles.BannerId,
+	"bed":              tiles.BedId,
+	"brewing_stand":    tiles.BrewingStandId,
+	"chest":            tiles.ChestId,
+	"enchanting_table": tiles.EnchantTableId,
+	"flower_pot":       tiles.FlowerPotId,
+	"furnace":          tiles.FurnaceId,
+	"mob_spawner":      tiles.MobSpawnerId,
+	"sign":             tiles.SignId,
+	"skull":            tiles.SkullId,
+}
+
+// legacyJavaTiles maps the lower case block entity IDs Java Edition used before 1.11 to their current IDs,
+// where the current ID is not simply the lower case legacy ID.
+var legacyJavaTiles = map[string]string{
+	"cauldron":     "brewing_stand",
+	"enchanttable": "enchanting_table",
+	"flowerpot":    "flower_pot",
+	"mobspawner":   "mob_spawner",
+}
+
+// newConversions returns the list of block conversions between Java Edition and Bedrock Edition.
+func newConversions() []conversion {
+	var list = []conversion{
+		{95, anyData, blocks.STAINED_GLASS, anyData},
+		{125, anyData, blocks.DOUBLE_WOODEN_SLAB, anyData},
+		{126, anyData, blocks.WOODEN_SLAB, anyData},
+		{157, anyData, blocks.ACTIVATOR_RAIL, anyData},
+		{158, anyData, blocks.DROPPER, anyData},
+		{166, anyData, blocks.INVISIBLE_BEDROCK, 0},
+		{188, anyData, blocks.FENCE, 1},
+		{189, anyData, blocks.FENCE, 2},
+		{190, anyData, blocks.FENCE, 3},
+		{191, anyData, blocks.FENCE, 5},
+		{192, anyData, blocks.FENCE, 4},
+		{198, anyData, blocks.END_ROD, anyData},
+		{199, anyData, blocks.CHORUS_PLANT, anyData},
+		{202, anyData, blocks.PURPUR_BLOCK, 2},
+		{204, anyData, blocks.DOUBLE_STONE_SLAB2, 1},
+		{205, anyData, blocks.STONE_SLAB2, 1},
+		{207, anyData, blocks.BEETROOT, anyData},
+		{208, anyData, blocks.GRASS_PATH, anyData},
+		{210, anyData, blocks.REPEATING_COMMAND_BLOCK, anyData},
+		{211, anyData, blocks.CHAIN_COMMAND_BLOCK, anyData},
+		{212, anyData, blocks.FROSTED_ICE, anyData},
+		{218, anyData, blocks.OBSERVER, anyData},
+		{251, anyData, blocks.CONCRETE, anyData},
+		{252, anyData, blocks.CONCRETE_POWDER, anyData},
+		{255, anyData, blocks.STRUCTURE_BLOCK, anyData},
+		{3, 2, blocks.PODZOL, 0},
+	}
+	// Java Edition has a block ID per shulker box color, and per glazed terracotta color.
+	for color := 0; color < 16; color++ {
+		list = append(list, conversion{219 + color, anyData, blocks.SHULKER_BOX, color})
+	}
+	var terracotta = []int{
+		blocks.WHITE_GLAZED_TERRACOTTA, blocks.ORANGE_GLAZED_TERRACOTTA, blocks.MAGENTA_GLAZED_TERRACOTTA, blocks.LIGHT_BLUE_GLAZED_TERRACOTTA,
+		blocks.YELLOW_GLAZED_TERRACOTTA, blocks.LIME_GLAZED_TERRACOTTA, blocks.PINK_GLAZED_TERRACOTTA, blocks.GRAY_GLAZED_TERRACOTTA,
+		blocks.SILVER_GLAZED_TERRACOTTA, blocks.CYAN_GLAZED_TERRACOTTA, blocks.PURPLE_GLAZED_TERRACOTTA, blocks.BLUE_GLAZED_TERRACOTTA,
+		blocks.BROWN_GLAZED_TERRACOTTA, blocks.GREEN_GLAZED_TERRACOTTA, blocks.RED_GLAZED_TERRACOTTA, blocks.BLACK_GLAZED_TERRACOTTA,
+	}
+	for color, id := range terracotta {
+		list = append(list, conversion{235 + color, anyData, id, anyData})
+	}
+	return list
+}
+
+// fromJava converts a Java Edition block to the equal Bedrock Edition block.
+func fromJava(id, data byte) (byte, byte) {
+	for _, conversion := range conversions {
+		if conversion.javaId == int(id) && (conversion.javaData == anyData || conversion.javaData == int(data)) {
+			if conversion.bedrockData != anyData {
+				data = byte(conversion.bedrockData)
+			}
+			return byte(conversion.bedrockId), data
+		}
+	}
+	return id, data
+}
+
+// toJava converts a Bedrock Edition block to the equal Java Edition block.
+func toJava(id, data byte) (byte, byte) {
+	for _, conversion := range conversions {
+		if conversion.bedrockId == int(id) && (conversion.bedrockData == anyData || conversion.bedrockData == int(data)) {
+			if conversion.javaData != anyData {
+				data = byte(conversion.javaData)
+			} else if conversion.bedrockData != anyData {
+				data = 0
+			}
+			return byte(conversion.javaId), data
+		}
+	}
+	return id, data
+}
+
+// ReadSchematic reads a structure from a gzip compressed MCEdit .schematic file, which holds big endian NBT.
+// Blocks are converted from Java Edition to Bedrock Edition. Block entities are converted if their type is known,
+// and entities are not read.
+func ReadSchematic(r io.Reader) (*Structure, error) {
+	var reader, err = gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var compound, decodeErr = nbt.NewDecoder(reader, nbt.BigEndian).Decode()
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	var width, height, length = int(compound.GetShort("Width")), int(compound.GetShort("Height")), int(compound.GetShort("Length"))
+	structure, err := newStructure(width, height, length)
+	if err != nil {
+		return nil, err
+	}
+	var ids, data = compound.GetByteArray("Blocks"), compound.GetByteArray("Data")
+	if len(ids) != width*height*length || len(data) != len(ids) {
+		return nil, InvalidSize
+	}
+	for i := range ids {
+		structure.ids[i], structure.data[i] = fromJava(ids[i], data[i])
+	}
+
+	for _, value := range compound.GetList("TileEntities") {
+		var tile, _ = value.(nbt.Compound)
+		if tile = tileFromJava(tile); tile != nil {
+			structure.SetTile(int(tile.GetInt("x")), int(tile.GetInt("y")), int(tile.GetInt("z")), tile)
+		}
+	}
+	return structure, nil
+}
+
+// WriteSchematic writes the structure to a gzip compressed MCEdit .schematic file.
+// Blocks are converted from Bedrock Edition to Java Edition, and structure void is written as air.
+func WriteSchematic(w io.Writer, structure *Structure) error {
+	var width, height, length = structure.GetSize()
+	var ids, data = make([]byte, len(structure.ids)), make([]byte, len(structure.data))
+	for i := range ids {
+		if structure.ids[i] == blocks.STRUCTURE_VOID {
+			continue
+		}
+		ids[i], data[i] = toJava(structure.ids[i], structure.data[i])
+	}
+
+	var tileEntities = []interface{}{}
+	for y := 0; y < height; y++ {
+		for z := 0; z < length; z++ {
+			for x := 0; x < width; x++ {
+				if tile := structure.GetTile(x, y, z); tile != nil {
+					tileEntities = append(tileEntities, tileToJava(tile, x, y, z))
+				}
+			}
+		}
+	}
+
+	var writer = gzip.NewWriter(w)
+	var err = nbt.NewEncoder(writer, nbt.BigEndian).EncodeNamed("Schematic", nbt.Compound{
+		"Width":        int16(width),
+		"Height":       int16(height),
+		"Length":       int16(length),
+		"Materials":    "Alpha",
+		"Blocks":       ids,
+		"Data":         data,
+		"Entities":     []interface{}{},
+		"TileEntities": tileEntities,
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// tileFromJava converts the NBT of a Java Edition block entity to Bedrock Edition.
+// Returns nil if the type of the block entity is not known.
+func tileFromJava(tile nbt.Compound) nbt.Compound {
+	if tile == nil {
+		return nil
+	}
+	var id = strings.ToLower(strings.TrimPrefix(tile.GetString("id"), blocks.IdentifierNamespace))
+	if current, ok := legacyJavaTiles[id]; ok {
+		id = current
+	}
+	var bedrockId, ok = javaTiles[id]
+	if !ok {
+		return nil
+	}
+	var converted = nbt.NewCompound()
+	for name, value := range tile {
+		converted[name] = value
+	}
+	converted["id"] = bedrockId
+	if bedrockId == tiles.SignId {
+		var lines = make([]string, tiles.SignLines)
+		for i := range lines {
+			lines[i] = signLineFromJava(tile.GetString("Text" + string('1'+rune(i))))
+			delete(converted, "Text"+string('1'+rune(i)))
+		}
+		converted["Text"] = strings.Join(lines, "\n")
+	}
+	return converted
+}
+
+// tileToJava converts the NBT of a Bedrock Edition block entity to Java Edition, at the given position.
+func tileToJava(tile nbt.Compound, x, y, z int) nbt.Compound {
+	var converted = nbt.NewCompound()
+	for name, value := range tile {
+		converted[name] = value
+	}
+	for javaId, bedrockId := range javaTiles {
+		if tile.GetString("id") == bedrockId {
+			converted["id"] = blocks.IdentifierNamespace + javaId
+		}
+	}
+	if tile.GetString("id") == tiles.SignId {
+		var lines = strings.Split(tile.GetString("Text"), "\n")
+		for i := 0; i < tiles.SignLines; i++ {
+			var line = ""
+			if i < len(lines) {
+				line = lines[i]
+			}
+			var text, _ = json.Marshal(map[string]string{"text": line})
+			converted["Text"+string('1'+rune(i))] = string(text)
+		}
+		delete(converted, "Text")
+	}
+	converted["x"], converted["y"], converted["z"] = int32(x), int32(y), int32(z)
+	return converted
+}
+
+// signLineFromJava returns the plain text of a line of a Java Edition sign, which is a JSON text component.
+func signLineFromJava(line string) string {
+	var component struct {
+		Text  string `json:"text"`
+		Extra []struct {
+			Text string `json:"text"`
+		} `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(line), &component); err != nil {
+		var text string
+		if json.Unmarshal([]byte(line), &text) == nil {
+			return text
+		}
+		return line
+	}
+	var text = component.Text
+	for _, extra := range component.Extra {
+		text += extra.Text
+	}
+	return text
+}
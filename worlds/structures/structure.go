@@ -0,0 +1,183 @@
+// Package structures reads and writes structures: cuboids of blocks and block entities that can be pasted into dimensions.
+// Structures are read from and written to Bedrock Edition .mcstructure files and legacy MCEdit .schematic files.
+package structures
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/irmine/gomine/nbt"
+	"github.com/irmine/gomine/worlds/blocks"
+)
+
+// Rotations of a structure when pasting it, clockwise around the Y axis when looking down.
+const (
+	Rotation0 = iota
+	Rotation90
+	Rotation180
+	Rotation270
+)
+
+// Mirrors of a structure when pasting it. Mirrors are applied before rotating.
+const (
+	MirrorNone = iota
+	MirrorX
+	MirrorZ
+)
+
+// MaximumVolume is the maximum amount of blocks a structure may hold.
+const MaximumVolume = 64 * 1024 * 1024
+
+var (
+	UnknownFormat = errors.New("unknown structure file format")
+	InvalidSize   = errors.New("invalid structure size")
+)
+
+// Structure is a cuboid of blocks with their block entities.
+// Positions in the structure are relative to its minimum corner.
+// Blocks with the structure void ID are left unchanged when the structure is pasted.
+type Structure struct {
+	width, height, length int
+	ids                   []byte
+	data                  []byte
+	tiles                 map[int]nbt.Compound
+}
+
+// NewStructure returns a new structure of air with the given size on the X, Y and Z axis.
+func NewStructure(width, height, length int) *Structure {
+	var volume = width * height * length
+	return &Structure{width, height, length, make([]byte, volume), make([]byte, volume), make(map[int]nbt.Compound)}
+}
+
+// newStructure returns a new structure with the given size, or InvalidSize if the size is negative or too large.
+func newStructure(width, height, length int) (*Structure, error) {
+	if width < 0 || height < 0 || length < 0 || int64(width)*int64(height)*int64(length) > MaximumVolume {
+		return nil, InvalidSize
+	}
+	return NewStructure(width, height, length), nil
+}
+
+// GetSize returns the size of the structure on the X, Y and Z axis.
+func (structure *Structure) GetSize() (int, int, int) {
+	return structure.width, structure.height, structure.length
+}
+
+// getIndex returns the index of a position in the structure.
+func (structure *Structure) getIndex(x, y, z int) int {
+	return (y*structure.length+z)*structure.width + x
+}
+
+// contains checks if a position is inside of the structure.
+func (structure *Structure) contains(x, y, z int) bool {
+	return x >= 0 && y >= 0 && z >= 0 && x < structure.width && y < structure.height && z < structure.length
+}
+
+// GetBlock returns the block ID and block data at a position in the structure.
+// Positions outside of the structure hold structure void.
+func (structure *Structure) GetBlock(x, y, z int) (byte, byte) {
+	if !structure.contains(x, y, z) {
+		return blocks.STRUCTURE_VOID, 0
+	}
+	var index = structure.getIndex(x, y, z)
+	return structure.ids[index], structure.data[index]
+}
+
+// SetBlock sets the block ID and block data at a position in the structure.
+func (structure *Structure) SetBlock(x, y, z int, id, data byte) {
+	if !structure.contains(x, y, z) {
+		return
+	}
+	var index = structure.getIndex(x, y, z)
+	structure.ids[index], structure.data[index] = id, data
+}
+
+// GetTile returns the NBT of the block entity at a position in the structure, or nil if there is none.
+// The coordinates in the NBT are not relevant, and get replaced when pasting.
+func (structure *Structure) GetTile(x, y, z int) nbt.Compound {
+	if !structure.contains(x, y, z) {
+		return nil
+	}
+	return structure.tiles[structure.getIndex(x, y, z)]
+}
+
+// SetTile sets the NBT of the block entity at a position in the structure. A nil compound removes the block entity.
+func (structure *Structure) SetTile(x, y, z int, compound nbt.Compound) {
+	if !structure.contains(x, y, z) {
+		return
+	}
+	if compound == nil {
+		delete(structure.tiles, structure.getIndex(x, y, z))
+		return
+	}
+	structure.tiles[structure.getIndex(x, y, z)] = compound
+}
+
+// GetTransformedSize returns the size on the X and Z axis of a structure with the given size after rotating it.
+func GetTransformedSize(width, length, rotation int) (int, int) {
+	if rotation == Rotation90 || rotation == Rotation270 {
+		return length, width
+	}
+	return width, length
+}
+
+// Transform returns the X and Z position a position in a structure of the given size moves to when mirroring and rotating
+// the structure. The transformed position is relative to the minimum corner of the transformed structure.
+func Transform(x, z, width, length, rotation, mirror int) (int, int) {
+	switch mirror {
+	case MirrorX:
+		x = width - 1 - x
+	case MirrorZ:
+		z = length - 1 - z
+	}
+	switch rotation {
+	case Rotation90:
+		return length - 1 - z, x
+	case Rotation180:
+		return width - 1 - x, length - 1 - z
+	case Rotation270:
+		return z, width - 1 - x
+	}
+	return x, z
+}
+
+// Load reads a structure from the file at the path. The format is chosen by the file extension,
+// which must be either .mcstructure or .schematic.
+func Load(path string) (*Structure, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mcstructure":
+		return ReadMCStructure(file)
+	case ".schematic":
+		return ReadSchematic(file)
+	}
+	return nil, UnknownFormat
+}
+
+// Save writes the structure to a file at the path. The format is chosen by the file extension,
+// which must be either .mcstructure or .schematic.
+func Save(path string, structure *Structure) error {
+	var extension = strings.ToLower(filepath.Ext(path))
+	if extension != ".mcstructure" && extension != ".schematic" {
+		return UnknownFormat
+	}
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if extension == ".mcstructure" {
+		err = WriteMCStructure(file, structure)
+	} else {
+		err = WriteSchematic(file, structure)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,338 @@
+package worlds
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/entities"
+	"github.com/irmine/gomine/interfaces"
+)
+
+// Weathers a level can have.
+const (
+	WeatherClear = iota
+	WeatherRain
+	WeatherThunder
+)
+
+// Level events sent to clients to start and stop rain and thunder.
+const (
+	LevelEventStartRain    = 3001
+	LevelEventStartThunder = 3002
+	LevelEventStopRain     = 3003
+	LevelEventStopThunder  = 3004
+)
+
+// Minimum and maximum durations in ticks of the weathers chosen by the weather cycle.
+const (
+	ClearDurationMin   = 12000
+	ClearDurationMax   = 180000
+	RainDurationMin    = 12000
+	RainDurationMax    = 24000
+	ThunderDurationMin = 3600
+	ThunderDurationMax = 15600
+)
+
+// LightningChance is the chance of one in LightningChance per tick for lightning to strike near a player during thunder.
+const LightningChance = 2000
+
+// LightningRange is the maximum horizontal distance in blocks from a player at which lightning strikes near the player.
+const LightningRange = 64
+
+// WeatherChange is a weather transition of a level, passed to weather change handlers before it happens.
+// Handlers may change the weather or duration of the transition, or cancel it altogether.
+type WeatherChange struct {
+	level     interfaces.ILevel
+	from      int
+	to        int
+	duration  int
+	cancelled bool
+}
+
+// GetLevel returns the level of which the weather changes.
+func (change *WeatherChange) GetLevel() interfaces.ILevel {
+	return change.level
+}
+
+// GetFrom returns the current weather of the level.
+func (change *WeatherChange) GetFrom() int {
+	return change.from
+}
+
+// GetTo returns the weather the level changes to.
+func (change *WeatherChange) GetTo() int {
+	return change.to
+}
+
+// SetTo sets the weather the level changes to.
+func (change *WeatherChange) SetTo(weather int) {
+	change.to = weather
+}
+
+// GetDuration returns the duration in ticks of the new weather.
+func (change *WeatherChange) GetDuration() int {
+	return change.duration
+}
+
+// SetDuration sets the duration in ticks of the new weather.
+func (change *WeatherChange) SetDuration(duration int) {
+	change.duration = duration
+}
+
+// Cancel cancels the weather change. The current weather of the level gets kept for the duration of the change.
+func (change *WeatherChange) Cancel() {
+	change.cancelled = true
+}
+
+// IsCancelled checks if the weather change was cancelled.
+func (change *WeatherChange) IsCancelled() bool {
+	return change.cancelled
+}
+
+// Returns the current weather of this level.
+
+func (level *Level) GetWeather() int {
+	return level.weather
+}
+
+// Returns the amount of ticks left until the weather cycle changes the weather of this level.
+// Thunder only shows while it rains, so the thunder cycle only counts while it rains.
+
+func (level *Level) GetWeatherDuration() int {
+	if level.raining && level.thunderTime < level.rainTime {
+		return level.thunderTime
+	}
+	return level.rainTime
+}
+
+// Sets the weather of this level for the given amount of ticks, and sends it to all players in the level.
+// A duration of 0 or less picks a random duration for the weather.
+// Weather change handlers get called first, and may change or cancel the weather change.
+// Returns false if the weather change was cancelled.
+
+func (level *Level) SetWeather(weather int, duration int) bool {
+	if duration <= 0 {
+		duration = level.randomWeatherDuration(weather)
+	}
+	var change = level.callWeatherHandlers(weather, duration)
+	if change.IsCancelled() {
+		level.setWeatherTimers(change.GetDuration())
+		return false
+	}
+	level.raining, level.thundering = change.GetTo() != WeatherClear, change.GetTo() == WeatherThunder
+	level.setWeatherTimers(change.GetDuration())
+	level.updateWeather()
+	return true
+}
+
+// Calls the weather change handlers for a change to the given weather, and returns the change after they handled it.
+
+func (level *Level) callWeatherHandlers(weather int, duration int) *WeatherChange {
+	var change = &WeatherChange{level: level, from: level.weather, to: weather, duration: duration}
+	for _, handler := range level.weatherHandlers {
+		handler(change)
+	}
+	return change
+}
+
+// Sets the rain and thunder timers to the given duration, so that the weather is kept until both run out.
+
+func (level *Level) setWeatherTimers(duration int) {
+	level.rainTime, level.thunderTime = duration, duration
+}
+
+// Sets the weather of this level to the weather of the rain and thunder cycles,
+// and sends it to all players in the level if it changed.
+
+func (level *Level) updateWeather() {
+	var previous = level.weather
+	level.weather = getWeather(level.raining, level.thundering)
+	if previous == level.weather {
+		return
+	}
+	for _, player := range level.GetPlayers() {
+		level.sendWeatherChange(player, previous, level.weather)
+	}
+}
+
+// Adds a handler called whenever the weather of this level is about to change.
+
+func (level *Level) AddWeatherChangeHandler(handler func(interfaces.IWeatherChange)) {
+	level.weatherHandlers = append(level.weatherHandlers, handler)
+}
+
+// Returns the rain level of this level, which is 1 if it rains or thunders and 0 otherwise.
+
+func (level *Level) GetRainLevel() float32 {
+	if level.weather == WeatherClear {
+		return 0
+	}
+	return 1
+}
+
+// Returns the lightning level of this level, which is 1 if it thunders and 0 otherwise.
+
+func (level *Level) GetLightningLevel() float32 {
+	if level.weather == WeatherThunder {
+		return 1
+	}
+	return 0
+}
+
+// Sends the current weather of this level to the given player.
+
+func (level *Level) SendWeather(player interfaces.IPlayer) {
+	level.sendWeatherChange(player, WeatherClear, level.weather)
+}
+
+// Sends the current weather of this level to a player that had the given weather before,
+// such as a player coming from another level.
+
+func (level *Level) SendWeatherFrom(player interfaces.IPlayer, previous int) {
+	level.sendWeatherChange(player, previous, level.weather)
+}
+
+// Sends the level events needed to change the weather of the given player from one weather to another.
+
+func (level *Level) sendWeatherChange(player interfaces.IPlayer, from, to int) {
+	if from == WeatherThunder && to != WeatherThunder {
+		player.SendLevelEvent(LevelEventStopThunder, r3.Vector{}, 0)
+	}
+	if from != WeatherClear && to == WeatherClear {
+		player.SendLevelEvent(LevelEventStopRain, r3.Vector{}, 0)
+	}
+	if from == WeatherClear && to != WeatherClear {
+		player.SendLevelEvent(LevelEventStartRain, r3.Vector{}, int32(level.random.Intn(50000)+10000))
+	}
+	if from != WeatherThunder && to == WeatherThunder {
+		player.SendLevelEvent(LevelEventStartThunder, r3.Vector{}, int32(level.random.Intn(50000)+10000))
+	}
+}
+
+// Strikes lightning at the given position in the given dimension.
+// No lightning strikes if the chunk of the position is not loaded.
+
+func (level *Level) StrikeLightning(dimension interfaces.IDimension, position r3.Vector) {
+	var chunk = dimension.GetLoadedChunk(int32(math.Floor(position.X))>>4, int32(math.Floor(position.Z))>>4)
+	if chunk == nil {
+		return
+	}
+	var lightning = entities.NewLightning(position, level, dimension)
+	chunk.AddEntity(lightning)
+	lightning.SpawnToAll()
+}
+
+// Returns the weather for the states of the rain and thunder cycles. Thunder only shows while it rains.
+
+func getWeather(raining, thundering bool) int {
+	switch {
+	case raining && thundering:
+		return WeatherThunder
+	case raining:
+		return WeatherRain
+	}
+	return WeatherClear
+}
+
+// Returns a random duration in ticks for the given weather.
+
+func (level *Level) randomWeatherDuration(weather int) int {
+	switch weather {
+	case WeatherRain:
+		return RainDurationMin + level.random.Intn(RainDurationMax-RainDurationMin)
+	case WeatherThunder:
+		return ThunderDurationMin + level.random.Intn(ThunderDurationMax-ThunderDurationMin)
+	}
+	return ClearDurationMin + level.random.Intn(ClearDurationMax-ClearDurationMin)
+}
+
+// Returns a random duration in ticks for the rain cycle to keep raining, or to keep it from raining.
+
+func (level *Level) randomRainTime(raining bool) int {
+	if raining {
+		return level.randomWeatherDuration(WeatherRain)
+	}
+	return level.randomWeatherDuration(WeatherClear)
+}
+
+// Returns a random duration in ticks for the thunder cycle to keep thundering, or to keep it from thundering.
+
+func (level *Level) randomThunderTime(thundering bool) int {
+	if thundering {
+		return level.randomWeatherDuration(WeatherThunder)
+	}
+	return level.randomWeatherDuration(WeatherClear)
+}
+
+// Advances the weather cycle of this level if the weather cycle game rule is enabled,
+// and strikes lightning near players during thunder.
+// Rain and thunder have their own cycles, which start or stop rain or thunder whenever their timer runs out.
+
+func (level *Level) tickWeather() {
+	if level.gameRules[GameRuleDoWeatherCycle].GetValue().(bool) {
+		var raining, thundering = level.raining, level.thundering
+		level.rainTime--
+		level.thunderTime--
+		if level.thunderTime <= 0 {
+			thundering = !thundering
+			level.thunderTime = level.randomThunderTime(thundering)
+		}
+		if level.rainTime <= 0 {
+			raining = !raining
+			level.rainTime = level.randomRainTime(raining)
+		}
+		level.cycleWeather(raining, thundering)
+	}
+
+	if level.weather == WeatherThunder {
+		level.strikeRandomLightning()
+	}
+}
+
+// Moves the rain and thunder cycles to the given states.
+// Weather change handlers get called if this changes the weather. If a handler changes the weather or its duration,
+// the weather gets set like SetWeather does, and if a handler cancels the change, the current weather is kept
+// for the duration of the change.
+
+func (level *Level) cycleWeather(raining, thundering bool) {
+	var to = getWeather(raining, thundering)
+	if to == level.weather {
+		level.raining, level.thundering = raining, thundering
+		return
+	}
+	var wasRaining, wasThundering = level.raining, level.thundering
+	level.raining, level.thundering = raining, thundering
+	var duration = level.GetWeatherDuration()
+	var change = level.callWeatherHandlers(to, duration)
+	switch {
+	case change.IsCancelled():
+		level.raining, level.thundering = wasRaining, wasThundering
+		level.setWeatherTimers(change.GetDuration())
+	case change.GetTo() != to || change.GetDuration() != duration:
+		level.raining, level.thundering = change.GetTo() != WeatherClear, change.GetTo() == WeatherThunder
+		level.setWeatherTimers(change.GetDuration())
+		level.updateWeather()
+	default:
+		level.updateWeather()
+	}
+}
+
+// Strikes lightning at random positions near players in the default dimension of this level.
+// Lightning only strikes in loaded chunks, on top of the highest block of the column.
+
+func (level *Level) strikeRandomLightning() {
+	for _, player := range level.GetPlayers() {
+		if player.GetDimension() != level.defaultDimension || level.random.Intn(LightningChance) != 0 {
+			continue
+		}
+		var position = player.GetPosition()
+		var x = int(position.X) + level.random.Intn(LightningRange*2) - LightningRange
+		var z = int(position.Z) + level.random.Intn(LightningRange*2) - LightningRange
+		var chunk = level.defaultDimension.GetLoadedChunk(int32(x>>4), int32(z>>4))
+		if chunk == nil {
+			continue
+		}
+		var y = chunk.GetHighestBlock(x&15, z&15) + 1
+		level.StrikeLightning(level.defaultDimension, r3.Vector{X: float64(x), Y: float64(y), Z: float64(z)})
+	}
+}
@@ -0,0 +1,72 @@
+package worlds
+
+import (
+	"runtime"
+	"sync"
+)
+
+// WorkerPool is a fixed size pool of goroutines used to load and generate chunks in parallel.
+// Jobs are queued without bound, so submitting a job never blocks the caller.
+type WorkerPool struct {
+	jobs    []func()
+	stopped bool
+	mux     sync.Mutex
+	cond    *sync.Cond
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool returns a new worker pool running the given amount of workers.
+// If the worker count is zero or lower, one worker per CPU is used.
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var pool = &WorkerPool{}
+	pool.cond = sync.NewCond(&pool.mux)
+	pool.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+// Submit queues a job to be executed by one of the workers.
+// Jobs submitted after the pool was stopped are discarded.
+func (pool *WorkerPool) Submit(job func()) {
+	pool.mux.Lock()
+	if !pool.stopped {
+		pool.jobs = append(pool.jobs, job)
+		pool.cond.Signal()
+	}
+	pool.mux.Unlock()
+}
+
+// Stop stops the pool after all queued jobs have been executed, and waits for the workers to finish.
+func (pool *WorkerPool) Stop() {
+	pool.mux.Lock()
+	pool.stopped = true
+	pool.cond.Broadcast()
+	pool.mux.Unlock()
+	pool.wg.Wait()
+}
+
+// work executes jobs until the pool gets stopped and the queue is empty.
+func (pool *WorkerPool) work() {
+	defer pool.wg.Done()
+	for {
+		pool.mux.Lock()
+		for len(pool.jobs) == 0 && !pool.stopped {
+			pool.cond.Wait()
+		}
+		if len(pool.jobs) == 0 {
+			pool.mux.Unlock()
+			return
+		}
+		var job = pool.jobs[0]
+		pool.jobs[0] = nil
+		pool.jobs = pool.jobs[1:]
+		pool.mux.Unlock()
+
+		job()
+	}
+}